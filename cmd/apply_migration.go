@@ -10,9 +10,7 @@ package cmd
 import (
 	"fmt"
 	"log"
-	"os/exec"
-
-	"github.com/amirkode/go-mongr8/internal/config"
+	"time"
 
 	"github.com/amirkode/go-mongr8/migration/option"
 
@@ -25,36 +23,16 @@ var applyMigrationCmd = &cobra.Command{
 	Short: "Apply all migrations",
 	Long:  `Apply migration changes to MongoDB`,
 	Run: func(cmd *cobra.Command, args []string) {
-		projectPath, err := config.GetProjectRootDir()
-		if err != nil {
-			log.Printf("Error applying migration: %s", err.Error())
-			return
-		}
-
-		// name := cmd.PersistentFlags().Lookup("name").Value.String()
-		flags := []string{"run", "main.go"}
-		for _, flag := range []string{
-			option.MigrationOptionArgUseSortedSchema,
-			option.MigrationOptionArgUseForceConversion,
-			option.MigrationOptionArgUseSchemaValidation,
+		flags := getMigrationCmdArgs(cmd, []string{
 			option.MigrationOptionArgUseTransaction,
-			option.MigrationOptionArgDesc,
-		} {
-			currFlag := cmd.PersistentFlags().Lookup(flag)
-			if currFlag != nil {
-				value := currFlag.Value.String()
-				flags = append(flags, fmt.Sprintf("-%s", flag))
-				// if not boolean
-				if value != "true" {
-					flags = append(flags, value)
-				}
-			}
-		}
-
-		migrationCmdPath := fmt.Sprintf("%s/mongr8/cmd/apply", *projectPath)
-		migrationCmd := exec.Command("go", flags...)
-		migrationCmd.Dir = migrationCmdPath
-		output, err := migrationCmd.CombinedOutput()
+			option.MigrationOptionArgLockTimeout,
+			option.MigrationOptionArgDryRun,
+			option.MigrationOptionArgAllowOutOfOrder,
+			option.MigrationOptionArgDuplicateReport,
+			option.MigrationOptionArgAllowIndexDrop,
+		})
+
+		output, err := runMigrationCmd("apply", flags)
 		if err != nil {
 			log.Printf("Error applying migration: %s: %s\n", err.Error(), output)
 			return
@@ -67,4 +45,11 @@ var applyMigrationCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(applyMigrationCmd)
+
+	applyMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgUseTransaction, false, "Use transaction on migration")
+	applyMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgDryRun, false, "Print planned MongoDB commands without executing them")
+	applyMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgAllowOutOfOrder, false, "Apply pending migrations older than the latest applied migration")
+	applyMigrationCmd.PersistentFlags().Duration(option.MigrationOptionArgLockTimeout, time.Minute, "Maximum duration to wait for the migration lock held by another process")
+	applyMigrationCmd.PersistentFlags().String(option.MigrationOptionArgDuplicateReport, "", "Write the duplicate key report to this file when a unique index cannot be created")
+	applyMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgAllowIndexDrop, false, "Drop an index conflicting with its rebuilt index before creating it, the index is missing while it's rebuilt")
 }
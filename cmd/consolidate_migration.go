@@ -9,6 +9,10 @@ package cmd
 
 import (
 	"fmt"
+	"log"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/option"
 
 	"github.com/spf13/cobra"
 )
@@ -19,10 +23,25 @@ var consolidateMigrationCmd = &cobra.Command{
 	Short: "Consolidate migration with current database schema",
 	Long:  `This command will consolidate current migration files with current schema/data in database`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("consolidateMigration called")
+		flags := getMigrationCmdArgs(cmd, []string{
+			option.MigrationOptionArgDesc,
+			option.MigrationOptionArgLockTimeout,
+		})
+
+		output, err := runMigrationCmd("consolidate", flags)
+		if err != nil {
+			log.Printf("Error consolidating migration: %s: %s\n", err.Error(), output)
+			return
+		}
+
+		// print original output
+		fmt.Printf("%s", output)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(consolidateMigrationCmd)
+
+	consolidateMigrationCmd.PersistentFlags().String(option.MigrationOptionArgDesc, "", "Description for the consolidation migration")
+	consolidateMigrationCmd.PersistentFlags().Duration(option.MigrationOptionArgLockTimeout, time.Minute, "Maximum duration to wait for the migration lock held by another process")
 }
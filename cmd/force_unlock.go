@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+// forceUnlockCmd represents the force-unlock command
+var forceUnlockCmd = &cobra.Command{
+	Use:   "force-unlock",
+	Short: "Release the migration lock",
+	Long:  `Release the migration lock regardless of its owner, i.e: a lock left by a crashed process`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := getMigrationCmdArgs(cmd, []string{})
+
+		output, err := runMigrationCmd("unlock", flags)
+		if err != nil {
+			log.Printf("Error releasing migration lock: %s: %s\n", err.Error(), output)
+			return
+		}
+
+		// print original output
+		fmt.Printf("%s", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(forceUnlockCmd)
+}
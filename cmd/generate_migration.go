@@ -10,9 +10,6 @@ package cmd
 import (
 	"fmt"
 	"log"
-	"os/exec"
-
-	"github.com/amirkode/go-mongr8/internal/config"
 
 	"github.com/amirkode/go-mongr8/migration/option"
 	"github.com/spf13/cobra"
@@ -24,35 +21,15 @@ var generateMigrationCmd = &cobra.Command{
 	Short: "Generate migration files",
 	Long:  `Generate migration files based on defined collections`,
 	Run: func(cmd *cobra.Command, args []string) {
-		projectPath, err := config.GetProjectRootDir()
-		if err != nil {
-			log.Printf("Error generating migration: %s", err.Error())
-			return
-		}
-
-		// name := cmd.PersistentFlags().Lookup("name").Value.String()
-		flags := []string{"run", "main.go"}
-		for _, flag := range []string{
+		flags := getMigrationCmdArgs(cmd, []string{
 			option.MigrationOptionArgUseSortedSchema,
 			option.MigrationOptionArgUseForceConversion,
 			option.MigrationOptionArgUseSchemaValidation,
+			option.MigrationOptionArgUseModelGeneration,
 			option.MigrationOptionArgDesc,
-		} {
-			currFlag := cmd.PersistentFlags().Lookup(flag)
-			if currFlag != nil {
-				value := currFlag.Value.String()
-				flags = append(flags, fmt.Sprintf("-%s", flag))
-				// if not boolean
-				if value != "true" {
-					flags = append(flags, value)
-				}
-			}
-		}
+		})
 
-		migrationCmdPath := fmt.Sprintf("%s/mongr8/cmd/generate", *projectPath)
-		migrationCmd := exec.Command("go", flags...)
-		migrationCmd.Dir = migrationCmdPath
-		output, err := migrationCmd.CombinedOutput()
+		output, err := runMigrationCmd("generate", flags)
 		if err != nil {
 			log.Printf("Error generating migration: %s: %s\n", err.Error(), output)
 			return
@@ -69,5 +46,6 @@ func init() {
 	generateMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgUseSortedSchema, true, "Use sorted schema on migration")
 	generateMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgUseForceConversion, true, "Force on type convertion on migration")
 	generateMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgUseSchemaValidation, true, "Apply schema validation on migration")
+	generateMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgUseModelGeneration, true, "Generate Go models and field paths of the collections")
 	generateMigrationCmd.PersistentFlags().String(option.MigrationOptionArgDesc, "", "Description for current migration")
 }
@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"github.com/spf13/cobra"
+)
+
+// migrationStatusCmd represents the migration-status command
+var migrationStatusCmd = &cobra.Command{
+	Use:   "migration-status",
+	Short: "Show migration status",
+	Long:  `List all migration files with their applied or pending state`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := getMigrationCmdArgs(cmd, []string{
+			option.MigrationOptionArgUseJson,
+		})
+
+		output, err := runMigrationCmd("status", flags)
+		if err != nil {
+			log.Printf("Error getting migration status: %s: %s\n", err.Error(), output)
+			return
+		}
+
+		// print original output
+		fmt.Printf("%s", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrationStatusCmd)
+
+	migrationStatusCmd.PersistentFlags().Bool(option.MigrationOptionArgUseJson, false, "Print the status in JSON format")
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"github.com/spf13/cobra"
+)
+
+// repairMigrationCmd represents the repair command
+var repairMigrationCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Repair a stuck migration",
+	Long:  `Mark a partially applied migration as clean (applied) or dirty (resumed on the next apply)`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := getMigrationCmdArgs(cmd, []string{
+			option.MigrationOptionArgRepairID,
+			option.MigrationOptionArgRepairMark,
+			option.MigrationOptionArgLockTimeout,
+		})
+
+		output, err := runMigrationCmd("repair", flags)
+		if err != nil {
+			log.Printf("Error repairing migration: %s: %s\n", err.Error(), output)
+			return
+		}
+
+		// print original output
+		fmt.Printf("%s", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(repairMigrationCmd)
+
+	repairMigrationCmd.PersistentFlags().String(option.MigrationOptionArgRepairID, "", "Migration ID to repair (default the only dirty migration)")
+	repairMigrationCmd.PersistentFlags().String(option.MigrationOptionArgRepairMark, "", "Repaired migration state: clean or dirty")
+	repairMigrationCmd.PersistentFlags().Duration(option.MigrationOptionArgLockTimeout, time.Minute, "Maximum duration to wait for the migration lock held by another process")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"github.com/spf13/cobra"
+)
+
+// rollbackMigrationCmd represents the rollback-migration command
+var rollbackMigrationCmd = &cobra.Command{
+	Use:   "rollback-migration",
+	Short: "Rollback applied migrations",
+	Long:  `Revert the latest applied migrations by executing their Down actions`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := getMigrationCmdArgs(cmd, []string{
+			option.MigrationOptionArgUseTransaction,
+			option.MigrationOptionArgLockTimeout,
+			option.MigrationOptionArgRollbackSteps,
+			option.MigrationOptionArgRollbackTo,
+			option.MigrationOptionArgAllowIndexDrop,
+		})
+
+		output, err := runMigrationCmd("rollback", flags)
+		if err != nil {
+			log.Printf("Error rolling back migration: %s: %s\n", err.Error(), output)
+			return
+		}
+
+		// print original output
+		fmt.Printf("%s", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackMigrationCmd)
+
+	rollbackMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgUseTransaction, false, "Use transaction on rollback")
+	rollbackMigrationCmd.PersistentFlags().Int(option.MigrationOptionArgRollbackSteps, 0, "Number of latest applied migrations to rollback (default 1)")
+	rollbackMigrationCmd.PersistentFlags().String(option.MigrationOptionArgRollbackTo, "", "Rollback all migrations applied after this migration ID")
+	rollbackMigrationCmd.PersistentFlags().Duration(option.MigrationOptionArgLockTimeout, time.Minute, "Maximum duration to wait for the migration lock held by another process")
+	rollbackMigrationCmd.PersistentFlags().Bool(option.MigrationOptionArgAllowIndexDrop, false, "Drop an index conflicting with its rebuilt index before creating it, the index is missing while it's rebuilt")
+}
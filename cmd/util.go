@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/amirkode/go-mongr8/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// this forwards provided command flags to the arguments of "go run main.go"
+// the value is always attached with "=", so a false boolean flag stays false
+func getMigrationCmdArgs(cmd *cobra.Command, flags []string) []string {
+	args := []string{"run", "main.go"}
+	for _, flag := range flags {
+		currFlag := cmd.PersistentFlags().Lookup(flag)
+		if currFlag != nil {
+			args = append(args, fmt.Sprintf("-%s=%s", flag, currFlag.Value.String()))
+		}
+	}
+
+	return args
+}
+
+// this runs a migration command in "[project dir]/mongr8/cmd/[operation]"
+func runMigrationCmd(operation string, args []string) ([]byte, error) {
+	projectPath, err := config.GetProjectRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	migrationCmdPath := fmt.Sprintf("%s/mongr8/cmd/%s", *projectPath, operation)
+	if !config.DoesPathExist(migrationCmdPath) {
+		return nil, fmt.Errorf("%s was not found, please re-initiate the migration folder", migrationCmdPath)
+	}
+
+	migrationCmd := exec.Command("go", args...)
+	migrationCmd.Dir = migrationCmdPath
+
+	return migrationCmd.CombinedOutput()
+}
@@ -8,9 +8,12 @@ Licensed under the MIT License
 package collection
 
 import (
+	"fmt"
+
 	"github.com/amirkode/go-mongr8/collection/field"
 	"github.com/amirkode/go-mongr8/collection/index"
 	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/internal/util"
 )
 
 type (
@@ -34,6 +37,13 @@ type (
 		Fields() []Field
 		Indexes() []Index
 	}
+
+	// EmbeddedSchema is a named set of fields declared once
+	// and embedded as an object in any collection
+	EmbeddedSchema interface {
+		Name() string
+		Fields() []Field
+	}
 )
 
 // // LookupField finds a field by key
@@ -74,6 +84,24 @@ func NewCollection(metadata Metadata, fields []Field, indexes []Index) Collectio
 	}
 }
 
+// EmbeddedField declares an object field whose children are the fields of `schema`.
+// The fields are copied, so each embedding is expanded independently
+// and any change of the schema applies to every collection embedding it
+func EmbeddedField(name string, schema EmbeddedSchema) *field.FieldSpec {
+	fields := schema.Fields()
+	if len(fields) == 0 {
+		panic(fmt.Sprintf("EmbeddedField: schema %s must have at least one field", schema.Name()))
+	}
+
+	children := make([]*field.FieldSpec, len(fields))
+	for index, _field := range fields {
+		spec := util.DeepCopy(*_field.Spec())
+		children[index] = field.FromFieldSpec(&spec)
+	}
+
+	return field.ObjectField(name, children...)
+}
+
 func FieldFromType(name string, _type field.FieldType) Field {
 	switch _type {
 	case field.TypeString:
@@ -90,8 +118,22 @@ func FieldFromType(name string, _type field.FieldType) Field {
 		return field.ArrayField(name) // since no child provided, we don't need to pass any field
 	case field.TypeObject:
 		return field.ObjectField(name) // since no child provided, we don't need to pass any field
+	case field.TypeMap:
+		return field.MapField(name, nil) // since no value provided, we don't need to pass any field
+	case field.TypeOneOf:
+		return field.OneOf(name, "", nil) // the discriminator key must be set by the caller
 	case field.TypeTimestamp:
 		return field.TimestampField(name)
+	case field.TypeObjectID:
+		return field.ObjectIDField(name)
+	case field.TypeDecimal128:
+		return field.Decimal128Field(name)
+	case field.TypeBinary:
+		return field.BinaryField(name)
+	case field.TypeUUID:
+		return field.UUIDField(name)
+	case field.TypeDate:
+		return field.DateField(name)
 	case field.TypeGeoJSONPoint:
 		return field.GeoJSONPointField(name)
 	case field.TypeGeoJSONLineString:
@@ -36,11 +36,30 @@ const (
 	TypeGeoJSONGeometryCollection   FieldType = "TypeGeoJSONGeometryCollection"
 	TypeLegacyCoordinateArray       FieldType = "TypeLegacyCoordinateArray"
 	TypeLegacyCoordinateEmbeddedDoc FieldType = "TypeLegacyCoordinateEmbeddedDoc"
-	// other types such as Decimal128, etc.
-	// might be added in the future update
+	TypeObjectID                    FieldType = "TypeObjectID"
+	TypeDecimal128                  FieldType = "TypeDecimal128"
+	TypeBinary                      FieldType = "TypeBinary"
+	TypeUUID                        FieldType = "TypeUUID"
+	TypeDate                        FieldType = "TypeDate"
+	TypeMap                         FieldType = "TypeMap"
+	TypeOneOf                       FieldType = "TypeOneOf"
 
 	// extra keys
-	ExtraDrop FieldExtra = "drop"
+	ExtraDrop        FieldExtra = "drop"
+	ExtraRenamedFrom FieldExtra = "renamed_from"
+	ExtraDefault     FieldExtra = "default"
+	// discriminator key of a one of field
+	ExtraDiscriminator FieldExtra = "discriminator"
+	// value constraints
+	ExtraEnum        FieldExtra = "enum"
+	ExtraMin         FieldExtra = "min"
+	ExtraMax         FieldExtra = "max"
+	ExtraMinLength   FieldExtra = "min_length"
+	ExtraMaxLength   FieldExtra = "max_length"
+	ExtraPattern     FieldExtra = "pattern"
+	ExtraMinItems    FieldExtra = "min_items"
+	ExtraMaxItems    FieldExtra = "max_items"
+	ExtraUniqueItems FieldExtra = "unique_items"
 )
 
 func GetTypePointer(fieldType FieldType) *FieldType {
@@ -64,10 +83,70 @@ func (f FieldType) CompareTo(other FieldType) int {
 	return 0
 }
 
+// returns types those can be indexed as geospatial
+func GetGeoTypes() []FieldType {
+	return []FieldType{
+		TypeGeoJSONPoint,
+		TypeGeoJSONLineString,
+		TypeGeoJSONPolygonSingleRing,
+		TypeGeoJSONPolygonMultipleRing,
+		TypeGeoJSONMultiPoint,
+		TypeGeoJSONMultiLineString,
+		TypeGeoJSONMultiPolygon,
+		TypeGeoJSONGeometryCollection,
+		TypeLegacyCoordinateArray,
+		TypeLegacyCoordinateEmbeddedDoc,
+	}
+}
+
 func (f FieldType) IsNumeric() bool {
 	return util.InList(f, []FieldType{
 		TypeInt32,
 		TypeInt64,
 		TypeDouble,
+		TypeDecimal128,
 	})
 }
+
+// timestamp field is also stored as a BSON date
+func (f FieldType) IsDate() bool {
+	return util.InList(f, []FieldType{
+		TypeTimestamp,
+		TypeDate,
+	})
+}
+
+// This checks whether both types are stored as the same BSON type,
+// so changing between them doesn't convert any value
+func (f FieldType) IsSameStoredType(other FieldType) bool {
+	return f == other || (f.IsDate() && other.IsDate())
+}
+
+func (f FieldType) IsBinary() bool {
+	return util.InList(f, []FieldType{
+		TypeBinary,
+		TypeUUID,
+	})
+}
+
+// This checks whether existing values of a type can be converted to this type
+// reference: https://www.mongodb.com/docs/manual/reference/operator/aggregation/convert/
+func (f FieldType) IsConvertibleFrom(from FieldType) bool {
+	switch {
+	case f == TypeString:
+		// binary to string requires MongoDB 8.0 or later
+		return !from.IsBinary()
+	case f == TypeDecimal128:
+		// decimal is commonly stored as a string to keep its precision
+		return from.IsNumeric() || from == TypeString
+	case f.IsNumeric():
+		return from.IsNumeric()
+	case f.IsDate():
+		// other date types are stored the same, see IsSameStoredType
+		return from == TypeObjectID || from == TypeString
+	case f == TypeObjectID:
+		return from == TypeString
+	}
+
+	return false
+}
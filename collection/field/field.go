@@ -7,19 +7,25 @@ Licensed under the MIT License
 */
 package field
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/amirkode/go-mongr8/internal/util"
+)
 
-// TODO: add default value
 type Spec struct {
 	Name string
 	// Type of the field
 	Type FieldType
 
 	// Array items, if current type is an array
+	// or map values, if current type is a map
 	// this can be any field type
 	ArrayFields *[]Spec
 
 	// Children of object, if current type is an object
+	// or variants, if current type is a one of
 	Object *[]Spec
 
 	// Nullable flag
@@ -104,6 +110,286 @@ func (b *FieldSpec) SetExtra(key FieldExtra, value any) *FieldSpec {
 	return b
 }
 
+// RenamedFrom declares the previous name of the field,
+// so the migration renames the existing field instead of dropping it and adding a new one
+func (b *FieldSpec) RenamedFrom(name string) *FieldSpec {
+	return b.SetExtra(ExtraRenamedFrom, name)
+}
+
+// GetRenamedFrom returns the previous name of the field, or empty if it's not renamed
+func (s *Spec) GetRenamedFrom() string {
+	if s.Extra == nil {
+		return ""
+	}
+
+	val, ok := s.Extra[ExtraRenamedFrom]
+	if !ok {
+		return ""
+	}
+
+	name, ok := val.(string)
+	if !ok {
+		panic(fmt.Sprintf("ExtraRenamedFrom must be a string, got %T", val))
+	}
+
+	return name
+}
+
+// SetDefault declares the value set on existing documents when the field is added,
+// instead of the empty value of the field type.
+// It's only available for a field without children
+func (b *FieldSpec) SetDefault(value any) *FieldSpec {
+	if util.InList(b.spec.Type, []FieldType{TypeArray, TypeObject, TypeMap, TypeOneOf, TypeLegacyCoordinateEmbeddedDoc}) {
+		panic(fmt.Sprintf("Default value is not available for %s field: %s", b.spec.Type, b.spec.Name))
+	}
+
+	if value == nil {
+		panic(fmt.Sprintf("Default value must not be nil on field: %s", b.spec.Name))
+	}
+
+	return b.SetExtra(ExtraDefault, value)
+}
+
+// GetDefault returns the default value of the field, if it's declared
+func (s *Spec) GetDefault() (any, bool) {
+	if s.Extra == nil {
+		return nil, false
+	}
+
+	val, ok := s.Extra[ExtraDefault]
+
+	return val, ok
+}
+
+func (b *FieldSpec) mustBeConstrainedBy(constraint string, allowed func(t FieldType) bool) {
+	if !allowed(b.spec.Type) {
+		panic(fmt.Sprintf("%s constraint is not available for %s field: %s", constraint, b.spec.Type, b.spec.Name))
+	}
+}
+
+// Enum declares the allowed values of a string or numeric field
+func (b *FieldSpec) Enum(values ...any) *FieldSpec {
+	b.mustBeConstrainedBy("Enum", func(t FieldType) bool {
+		return t == TypeString || t.IsNumeric()
+	})
+
+	if len(values) == 0 {
+		panic(fmt.Sprintf("Enum must have at least a value on field: %s", b.spec.Name))
+	}
+
+	return b.SetExtra(ExtraEnum, values)
+}
+
+// Min declares the inclusive lower bound of a numeric field
+func (b *FieldSpec) Min(value float64) *FieldSpec {
+	b.mustBeConstrainedBy("Min", FieldType.IsNumeric)
+
+	return b.SetExtra(ExtraMin, value)
+}
+
+// Max declares the inclusive upper bound of a numeric field
+func (b *FieldSpec) Max(value float64) *FieldSpec {
+	b.mustBeConstrainedBy("Max", FieldType.IsNumeric)
+
+	return b.SetExtra(ExtraMax, value)
+}
+
+// MinLength declares the minimum number of characters of a string field
+func (b *FieldSpec) MinLength(length int) *FieldSpec {
+	b.mustBeConstrainedBy("MinLength", func(t FieldType) bool {
+		return t == TypeString
+	})
+
+	return b.SetExtra(ExtraMinLength, length)
+}
+
+// MaxLength declares the maximum number of characters of a string field
+func (b *FieldSpec) MaxLength(length int) *FieldSpec {
+	b.mustBeConstrainedBy("MaxLength", func(t FieldType) bool {
+		return t == TypeString
+	})
+
+	return b.SetExtra(ExtraMaxLength, length)
+}
+
+// Pattern declares the regular expression a string field must match
+func (b *FieldSpec) Pattern(pattern string) *FieldSpec {
+	b.mustBeConstrainedBy("Pattern", func(t FieldType) bool {
+		return t == TypeString
+	})
+
+	return b.SetExtra(ExtraPattern, pattern)
+}
+
+// MinItems declares the minimum number of items of an array field
+func (b *FieldSpec) MinItems(count int) *FieldSpec {
+	b.mustBeConstrainedBy("MinItems", func(t FieldType) bool {
+		return t == TypeArray
+	})
+
+	return b.SetExtra(ExtraMinItems, count)
+}
+
+// MaxItems declares the maximum number of items of an array field
+func (b *FieldSpec) MaxItems(count int) *FieldSpec {
+	b.mustBeConstrainedBy("MaxItems", func(t FieldType) bool {
+		return t == TypeArray
+	})
+
+	return b.SetExtra(ExtraMaxItems, count)
+}
+
+// UniqueItems declares that items of an array field must be unique
+func (b *FieldSpec) UniqueItems() *FieldSpec {
+	b.mustBeConstrainedBy("UniqueItems", func(t FieldType) bool {
+		return t == TypeArray
+	})
+
+	return b.SetExtra(ExtraUniqueItems, true)
+}
+
+// GetEnum returns the allowed values of the field, or nil if it's not declared
+func (s *Spec) GetEnum() []any {
+	if s.Extra == nil {
+		return nil
+	}
+
+	val, ok := s.Extra[ExtraEnum]
+	if !ok {
+		return nil
+	}
+
+	values, ok := val.([]any)
+	if !ok {
+		panic(fmt.Sprintf("ExtraEnum must be a slice, got %T", val))
+	}
+
+	return values
+}
+
+// GetMin returns the lower bound of the field, if it's declared
+func (s *Spec) GetMin() (float64, bool) {
+	return getNumberExtra(s, ExtraMin)
+}
+
+// GetMax returns the upper bound of the field, if it's declared
+func (s *Spec) GetMax() (float64, bool) {
+	return getNumberExtra(s, ExtraMax)
+}
+
+// GetMinLength returns the minimum length of the field, if it's declared
+func (s *Spec) GetMinLength() (int, bool) {
+	return getCountExtra(s, ExtraMinLength)
+}
+
+// GetMaxLength returns the maximum length of the field, if it's declared
+func (s *Spec) GetMaxLength() (int, bool) {
+	return getCountExtra(s, ExtraMaxLength)
+}
+
+// GetPattern returns the regular expression of the field, if it's declared
+func (s *Spec) GetPattern() (string, bool) {
+	if s.Extra == nil {
+		return "", false
+	}
+
+	val, ok := s.Extra[ExtraPattern]
+	if !ok {
+		return "", false
+	}
+
+	pattern, ok := val.(string)
+	if !ok {
+		panic(fmt.Sprintf("ExtraPattern must be a string, got %T", val))
+	}
+
+	return pattern, true
+}
+
+// GetMinItems returns the minimum number of items of the field, if it's declared
+func (s *Spec) GetMinItems() (int, bool) {
+	return getCountExtra(s, ExtraMinItems)
+}
+
+// GetMaxItems returns the maximum number of items of the field, if it's declared
+func (s *Spec) GetMaxItems() (int, bool) {
+	return getCountExtra(s, ExtraMaxItems)
+}
+
+// IsUniqueItems returns whether items of the field must be unique
+func (s *Spec) IsUniqueItems() bool {
+	if s.Extra == nil {
+		return false
+	}
+
+	val, ok := s.Extra[ExtraUniqueItems]
+	if !ok {
+		return false
+	}
+
+	unique, ok := val.(bool)
+	if !ok {
+		panic(fmt.Sprintf("ExtraUniqueItems must be a boolean, got %T", val))
+	}
+
+	return unique
+}
+
+// HasConstraints returns whether any value constraint is declared on the field
+func (s *Spec) HasConstraints() bool {
+	if s.Extra == nil {
+		return false
+	}
+
+	for _, key := range []FieldExtra{
+		ExtraEnum, ExtraMin, ExtraMax,
+		ExtraMinLength, ExtraMaxLength, ExtraPattern,
+		ExtraMinItems, ExtraMaxItems, ExtraUniqueItems,
+	} {
+		if _, ok := s.Extra[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getNumberExtra(s *Spec, key FieldExtra) (float64, bool) {
+	if s.Extra == nil {
+		return 0, false
+	}
+
+	val, ok := s.Extra[key]
+	if !ok {
+		return 0, false
+	}
+
+	number, ok := val.(float64)
+	if !ok {
+		panic(fmt.Sprintf("Extra %s must be a float64, got %T", key, val))
+	}
+
+	return number, true
+}
+
+func getCountExtra(s *Spec, key FieldExtra) (int, bool) {
+	if s.Extra == nil {
+		return 0, false
+	}
+
+	val, ok := s.Extra[key]
+	if !ok {
+		return 0, false
+	}
+
+	count, ok := val.(int)
+	if !ok {
+		panic(fmt.Sprintf("Extra %s must be an int, got %T", key, val))
+	}
+
+	return count, true
+}
+
 func baseField(name string, fieldType FieldType) *FieldSpec {
 	// already validated in translation level
 	// if len(name) > 128 {
@@ -169,10 +455,132 @@ func ObjectField(name string, fields ...*FieldSpec) *FieldSpec {
 	return field
 }
 
+// MapField declares an object with arbitrary keys,
+// whose values follow `value`. The name of the value is ignored
+func MapField(name string, value *FieldSpec) *FieldSpec {
+	field := baseField(name, TypeMap)
+	// empty value is still supported,
+	// since there's a case that the field is a type representative only
+	if value != nil {
+		valueSpec := *value.Spec()
+		valueSpec.Name = ""
+		field.addArrayField(FromFieldSpec(&valueSpec))
+	}
+
+	return field
+}
+
+// GetMapValue returns the value spec of a map field, or nil if it's not declared
+func (s *Spec) GetMapValue() *Spec {
+	if s.Type != TypeMap || s.ArrayFields == nil || len(*s.ArrayFields) == 0 {
+		return nil
+	}
+
+	return &(*s.ArrayFields)[0]
+}
+
+// OneOf declares an object whose shape depends on the value of `discriminatorKey`.
+// Each variant is an object field keyed by its discriminator value,
+// the discriminator key itself is implied, so it's not declared inside the variants
+func OneOf(name string, discriminatorKey string, variants map[string]*FieldSpec) *FieldSpec {
+	field := baseField(name, TypeOneOf)
+	field.SetExtra(ExtraDiscriminator, discriminatorKey)
+
+	values := []string{}
+	for value := range variants {
+		values = append(values, value)
+	}
+	// variants are sorted to keep a stable order
+	sort.Strings(values)
+
+	for _, value := range values {
+		variant := variants[value]
+		if variant == nil || variant.Spec().Type != TypeObject {
+			panic(fmt.Sprintf("Variant %s of field %s must be an object field", value, name))
+		}
+
+		variantSpec := *variant.Spec()
+		variantSpec.Name = value
+		field.addObjectFields(FromFieldSpec(&variantSpec))
+	}
+
+	return field
+}
+
+// GetDiscriminator returns the discriminator key of a one of field, or empty if it's not a one of
+func (s *Spec) GetDiscriminator() string {
+	if s.Type != TypeOneOf || s.Extra == nil {
+		return ""
+	}
+
+	val, ok := s.Extra[ExtraDiscriminator]
+	if !ok {
+		return ""
+	}
+
+	key, ok := val.(string)
+	if !ok {
+		panic(fmt.Sprintf("ExtraDiscriminator must be a string, got %T", val))
+	}
+
+	return key
+}
+
+// GetVariants returns the variants of a one of field sorted by the discriminator value,
+// since the order might change after the variants are merged from migrations
+func (s *Spec) GetVariants() []Spec {
+	if s.Type != TypeOneOf || s.Object == nil {
+		return nil
+	}
+
+	res := append([]Spec{}, *s.Object...)
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// GetVariant returns the variant of a one of field given the discriminator value, or nil if it's not declared
+func (s *Spec) GetVariant(value string) *Spec {
+	if s.Type != TypeOneOf || s.Object == nil {
+		return nil
+	}
+
+	for i := range *s.Object {
+		if (*s.Object)[i].Name == value {
+			return &(*s.Object)[i]
+		}
+	}
+
+	return nil
+}
+
 func TimestampField(name string) *FieldSpec {
 	return baseField(name, TypeTimestamp)
 }
 
+func ObjectIDField(name string) *FieldSpec {
+	return baseField(name, TypeObjectID)
+}
+
+func Decimal128Field(name string) *FieldSpec {
+	return baseField(name, TypeDecimal128)
+}
+
+func BinaryField(name string) *FieldSpec {
+	return baseField(name, TypeBinary)
+}
+
+// UUID is stored as a binary with subtype 4
+func UUIDField(name string) *FieldSpec {
+	return baseField(name, TypeUUID)
+}
+
+func DateField(name string) *FieldSpec {
+	return baseField(name, TypeDate)
+}
+
 func GeoJSONPointField(name string) *FieldSpec {
 	return baseField(name, TypeGeoJSONPoint)
 }
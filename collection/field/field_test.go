@@ -8,6 +8,9 @@ Licensed under the MIT License
 package field
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/amirkode/go-mongr8/internal/test"
@@ -99,6 +102,18 @@ func TestStringField(t *testing.T) {
 	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
 }
 
+func TestRenamedFrom(t *testing.T) {
+	// case 1: renamed field
+	case1Actual := StringField("full_name").RenamedFrom("name")
+
+	test.AssertEqual(t, case1Actual.spec.GetRenamedFrom(), "name", "Case 1: Unexpected previous name")
+
+	// case 2: not renamed field
+	case2Actual := StringField("full_name")
+
+	test.AssertEqual(t, case2Actual.spec.GetRenamedFrom(), "", "Case 2: Previous name must be empty")
+}
+
 func TestInt32Field(t *testing.T) {
 	// case 1: default
 	case1Actual := Int32Field("name")
@@ -282,6 +297,60 @@ func TestObjectField(t *testing.T) {
 	test.AssertTrue(t, fieldsAreEqual(case3Actual.spec, &case3Expected), "Case 3: Unexpected field value")
 }
 
+func TestMapField(t *testing.T) {
+	// case 1: default, the value name is ignored
+	case1Actual := MapField("name", StringField("value"))
+	case1Expected := baseField("name", TypeMap)
+	case1Expected.addArrayField(StringField(""))
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+	test.AssertTrue(t, case1Actual.Spec().GetMapValue().Type == TypeString, "Case 1: Unexpected map value")
+
+	// case 2: the declared value must not be modified
+	case2Value := ObjectField("value", Int32Field("count"))
+	MapField("name", case2Value)
+
+	test.AssertTrue(t, case2Value.Spec().Name == "value", "Case 2: Value must not be modified")
+
+	// case 3: type representative only
+	case3Actual := MapField("name", nil)
+
+	test.AssertTrue(t, case3Actual.Spec().GetMapValue() == nil, "Case 3: Map value must not exist")
+}
+
+func TestOneOf(t *testing.T) {
+	// case 1: default, variants are named by the discriminator value
+	case1Actual := OneOf("payload", "kind", map[string]*FieldSpec{
+		"view":  ObjectField("", StringField("page")),
+		"click": ObjectField("variant", Int32Field("x"), Int32Field("y")),
+	})
+	case1Expected := baseField("payload", TypeOneOf)
+	case1Expected.addObjectFields(
+		ObjectField("click", Int32Field("x"), Int32Field("y")),
+		ObjectField("view", StringField("page")),
+	)
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+	test.AssertTrue(t, case1Actual.Spec().GetDiscriminator() == "kind", "Case 1: Unexpected discriminator")
+	test.AssertTrue(t, case1Actual.Spec().GetVariant("view").Type == TypeObject, "Case 1: Unexpected variant")
+	test.AssertTrue(t, case1Actual.Spec().GetVariant("scroll") == nil, "Case 1: Variant must not exist")
+
+	// case 2: variants are sorted by the discriminator value
+	case2Spec := *case1Actual.Spec()
+	case2Spec.Object = &[]Spec{(*case1Actual.Spec().Object)[1], (*case1Actual.Spec().Object)[0]}
+	case2Variants := case2Spec.GetVariants()
+
+	test.AssertTrue(t, case2Variants[0].Name == "click" && case2Variants[1].Name == "view", "Case 2: Unexpected variants order")
+
+	// case 3: variant must be an object
+	defer func() {
+		r := recover()
+		test.AssertTrue(t, r != nil && strings.Contains(fmt.Sprintf("%v", r), "must be an object field"), "Case 3: Unexpected panic")
+	}()
+
+	OneOf("payload", "kind", map[string]*FieldSpec{"view": StringField("page")})
+}
+
 func TestTimestampField(t *testing.T) {
 	// case 1: default
 	case1Actual := TimestampField("name")
@@ -290,6 +359,59 @@ func TestTimestampField(t *testing.T) {
 	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
 }
 
+func TestObjectIDField(t *testing.T) {
+	// case 1: default
+	case1Actual := ObjectIDField("_id")
+	case1Expected := baseField("_id", TypeObjectID)
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+}
+
+func TestDecimal128Field(t *testing.T) {
+	// case 1: default
+	case1Actual := Decimal128Field("name")
+	case1Expected := baseField("name", TypeDecimal128)
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+}
+
+func TestBinaryField(t *testing.T) {
+	// case 1: default
+	case1Actual := BinaryField("name")
+	case1Expected := baseField("name", TypeBinary)
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+}
+
+func TestUUIDField(t *testing.T) {
+	// case 1: default
+	case1Actual := UUIDField("name")
+	case1Expected := baseField("name", TypeUUID)
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+}
+
+func TestDateField(t *testing.T) {
+	// case 1: default
+	case1Actual := DateField("name")
+	case1Expected := baseField("name", TypeDate)
+
+	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
+}
+
+func TestIsConvertibleFrom(t *testing.T) {
+	test.AssertTrue(t, TypeString.IsConvertibleFrom(TypeObjectID), "Case 1: ObjectID must be convertible to string")
+	test.AssertTrue(t, !TypeString.IsConvertibleFrom(TypeUUID), "Case 2: UUID must not be convertible to string")
+	test.AssertTrue(t, TypeDecimal128.IsConvertibleFrom(TypeDouble), "Case 3: Double must be convertible to decimal")
+	test.AssertTrue(t, TypeInt64.IsConvertibleFrom(TypeDecimal128), "Case 4: Decimal must be convertible to int64")
+	test.AssertTrue(t, !TypeDate.IsConvertibleFrom(TypeTimestamp), "Case 5: Timestamp is stored as date, no conversion is required")
+	test.AssertTrue(t, TypeDate.IsSameStoredType(TypeTimestamp), "Case 5: Timestamp and date must be stored the same")
+	test.AssertTrue(t, TypeDate.IsConvertibleFrom(TypeString), "Case 5: String must be convertible to date")
+	test.AssertTrue(t, TypeObjectID.IsConvertibleFrom(TypeString), "Case 6: String must be convertible to ObjectID")
+	test.AssertTrue(t, !TypeObjectID.IsConvertibleFrom(TypeInt32), "Case 7: Int32 must not be convertible to ObjectID")
+	test.AssertTrue(t, !TypeInt32.IsConvertibleFrom(TypeString), "Case 8: String must not be convertible to int32")
+}
+
 func TestGeoJSONPointField(t *testing.T) {
 	// case 1: default
 	case1Actual := GeoJSONPointField("name")
@@ -361,3 +483,67 @@ func TestLegacyCoordinateArrayField(t *testing.T) {
 
 	test.AssertTrue(t, fieldsAreEqual(case1Actual.spec, case1Expected.spec), "Case 1: Unexpected field value")
 }
+
+func TestSetDefault(t *testing.T) {
+	// case 1: default on a scalar field
+	case1Actual := StringField("status").SetDefault("active")
+	case1Default, case1Ok := case1Actual.Spec().GetDefault()
+
+	test.AssertTrue(t, case1Ok && case1Default == "active", "Case 1: Unexpected default value")
+
+	// case 2: no default declared
+	_, case2Ok := StringField("status").Spec().GetDefault()
+
+	test.AssertTrue(t, !case2Ok, "Case 2: Unexpected default value")
+
+	// case 3: default on an object field
+	defer func() {
+		r := recover()
+		test.AssertTrue(t, r != nil && strings.Contains(fmt.Sprintf("%v", r), "Default value is not available"), "Case 3: Unexpected panic")
+	}()
+
+	ObjectField("info", StringField("name")).SetDefault(map[string]interface{}{"name": ""})
+}
+
+func TestConstraints(t *testing.T) {
+	// case 1: string constraints
+	case1Actual := StringField("status").Enum("active", "inactive").MinLength(1).MaxLength(8).Pattern("^[a-z]+$")
+	case1MinLength, case1HasMinLength := case1Actual.Spec().GetMinLength()
+	case1MaxLength, case1HasMaxLength := case1Actual.Spec().GetMaxLength()
+	case1Pattern, case1HasPattern := case1Actual.Spec().GetPattern()
+
+	test.AssertTrue(t, reflect.DeepEqual(case1Actual.Spec().GetEnum(), []any{"active", "inactive"}), "Case 1: Unexpected enum")
+	test.AssertTrue(t, case1HasMinLength && case1MinLength == 1, "Case 1: Unexpected min length")
+	test.AssertTrue(t, case1HasMaxLength && case1MaxLength == 8, "Case 1: Unexpected max length")
+	test.AssertTrue(t, case1HasPattern && case1Pattern == "^[a-z]+$", "Case 1: Unexpected pattern")
+	test.AssertTrue(t, case1Actual.Spec().HasConstraints(), "Case 1: Constraints must exist")
+
+	// case 2: numeric constraints
+	case2Actual := Int32Field("age").Min(0).Max(150)
+	case2Min, case2HasMin := case2Actual.Spec().GetMin()
+	case2Max, case2HasMax := case2Actual.Spec().GetMax()
+
+	test.AssertTrue(t, case2HasMin && case2Min == 0, "Case 2: Unexpected min")
+	test.AssertTrue(t, case2HasMax && case2Max == 150, "Case 2: Unexpected max")
+	test.AssertTrue(t, case2Actual.Spec().GetEnum() == nil, "Case 2: Enum must not exist")
+
+	// case 3: array constraints
+	case3Actual := ArrayField("tags", StringField("")).MinItems(1).MaxItems(5).UniqueItems()
+	case3MinItems, case3HasMinItems := case3Actual.Spec().GetMinItems()
+	case3MaxItems, case3HasMaxItems := case3Actual.Spec().GetMaxItems()
+
+	test.AssertTrue(t, case3HasMinItems && case3MinItems == 1, "Case 3: Unexpected min items")
+	test.AssertTrue(t, case3HasMaxItems && case3MaxItems == 5, "Case 3: Unexpected max items")
+	test.AssertTrue(t, case3Actual.Spec().IsUniqueItems(), "Case 3: Items must be unique")
+
+	// case 4: no constraints declared
+	test.AssertTrue(t, !StringField("name").RenamedFrom("title").Spec().HasConstraints(), "Case 4: Constraints must not exist")
+
+	// case 5: constraint on an unsupported field type
+	defer func() {
+		r := recover()
+		test.AssertTrue(t, r != nil && strings.Contains(fmt.Sprintf("%v", r), "MinLength constraint is not available"), "Case 5: Unexpected panic")
+	}()
+
+	BooleanField("active").MinLength(1)
+}
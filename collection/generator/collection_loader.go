@@ -9,96 +9,351 @@ package generator
 
 import (
 	"fmt"
-	"regexp"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/internal/config"
+	"github.com/amirkode/go-mongr8/internal/util"
 )
 
 const (
-	mongr8Path = "mongr8"
+	mongr8Path         = "mongr8"
 	baseCollectionPath = mongr8Path + "/collection"
+	// the generated package combining all collections, it's not a collection package
+	combinedCollectionsDir = "no_edit"
+
+	collectionPkgPath = "github.com/amirkode/go-mongr8/collection"
+	metadataPkgPath   = collectionPkgPath + "/metadata"
+	// the alias of the root collection package in the combined collections
+	collectionPkgAlias = "coll"
 )
 
-func LoadCollection() []collection.Collection {
-	collections := []collection.Collection{}
+// collectionEntity is a type implementing collection.Collection
+// found in the collection package(s)
+type collectionEntity struct {
+	// import path of the declaring package
+	PkgPath string
+	// declaring package directory, relative to the collection folder
+	PkgDir   string
+	TypeName string
+	// whether only the pointer type implements collection.Collection
+	Pointer  bool
+	IsStruct bool
+	// collection name, empty if it's not a constant passed to metadata.InitMetadata
+	Name string
+}
+
+// This returns the alias of the declaring package in the combined collections,
+// i.e: "coll" for the collection folder, "coll_billing" for "collection/billing"
+func (e collectionEntity) pkgAlias() string {
+	if e.PkgDir == "." {
+		return collectionPkgAlias
+	}
 
-	return collections
+	return collectionPkgAlias + "_" + util.ToSnakeCase(e.PkgDir)
 }
 
-func getAllCollectionStructs() []string {
-	res := []string{}
+// This returns an expression of the collection instance in the combined collections
+func (e collectionEntity) instanceLiteral() string {
+	typeName := fmt.Sprintf("%s.%s", e.pkgAlias(), e.TypeName)
+	switch {
+	case e.IsStruct && e.Pointer:
+		return fmt.Sprintf("&%s{}", typeName)
+	case e.IsStruct:
+		return fmt.Sprintf("%s{}", typeName)
+	case e.Pointer:
+		return fmt.Sprintf("new(%s)", typeName)
+	}
+
+	return fmt.Sprintf("*new(%s)", typeName)
+}
+
+var (
+	sourceFset     *token.FileSet
+	sourceImporter types.ImporterFrom
+)
+
+// This returns the importer type checking the dependencies from their source,
+// it's shared within the process, since type checking the dependencies is expensive
+func getSourceImporter() (*token.FileSet, types.ImporterFrom) {
+	if sourceImporter == nil {
+		sourceFset = token.NewFileSet()
+		sourceImporter = importer.ForCompiler(sourceFset, "source", nil).(types.ImporterFrom)
+	}
+
+	return sourceFset, sourceImporter
+}
+
+// This finds every exported type implementing collection.Collection
+// in the collection folder and its sub folders
+func getAllCollectionEntities() ([]collectionEntity, error) {
 	rootPath, err := config.GetProjectRootDir()
 	if err != nil {
-		return res
+		return nil, err
+	}
+
+	path := filepath.Join(*rootPath, baseCollectionPath)
+	if !config.DoesPathExist(path) {
+		return []collectionEntity{}, nil
+	}
+
+	pkgDirs := []string{}
+	err = filepath.WalkDir(path, func(currPath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		name := entry.Name()
+		if currPath != path && (name == combinedCollectionsDir || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+
+		pkgDirs = append(pkgDirs, currPath)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	path := fmt.Sprintf("%s/%s", *rootPath, baseCollectionPath)
-	collectionFileNames := config.GetAllFileNames(path)
-	for _, name := range collectionFileNames {
-		filePath := fmt.Sprintf("%s/%s", path, name)
-		structName, err := getCollectionStructName(filePath)
+	moduleName := config.GetProjectRootModuleName(*rootPath)
+	if moduleName == "" {
+		return nil, fmt.Errorf("module name was not found in %s", filepath.Join(*rootPath, "go.mod"))
+	}
+
+	fset, imp := getSourceImporter()
+	res := []collectionEntity{}
+	for _, dir := range pkgDirs {
+		relDir, err := filepath.Rel(path, dir)
 		if err != nil {
-			fmt.Println(err)
+			return nil, err
+		}
+
+		pkgPath := fmt.Sprintf("%s/%s", moduleName, filepath.ToSlash(filepath.Join(baseCollectionPath, relDir)))
+		entities, err := getPackageCollectionEntities(fset, imp, dir, pkgPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range entities {
+			entities[i].PkgDir = filepath.ToSlash(relDir)
+		}
+
+		res = append(res, entities...)
+	}
+
+	return res, nil
+}
+
+// This parses and type checks the package in `dir`,
+// then returns its types implementing collection.Collection sorted by name
+func getPackageCollectionEntities(fset *token.FileSet, imp types.ImporterFrom, dir, pkgPath string) ([]collectionEntity, error) {
+	files, err := parsePackageFiles(fset, dir)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+
+	collectionPkg, err := imp.ImportFrom(collectionPkgPath, dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: cannot import %s: %s", dir, collectionPkgPath, err.Error())
+	}
+
+	collectionIface, ok := collectionPkg.Scope().Lookup("Collection").Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s.Collection is not an interface", collectionPkgPath)
+	}
+
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	typeErrs := []string{}
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			typeErrs = append(typeErrs, err.Error())
+		},
+	}
+
+	pkg, _ := conf.Check(pkgPath, fset, files, info)
+	if len(typeErrs) > 0 {
+		return nil, fmt.Errorf("invalid collection package %s:\n%s", pkgPath, strings.Join(typeErrs, "\n"))
+	}
+
+	res := []collectionEntity{}
+	for _, name := range pkg.Scope().Names() {
+		typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok || !typeName.Exported() || typeName.IsAlias() {
 			continue
 		}
 
-		res = append(res, *structName)
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+			continue
+		}
+
+		entity := collectionEntity{
+			PkgPath:  pkgPath,
+			TypeName: name,
+		}
+
+		switch {
+		case types.Implements(named, collectionIface):
+		case types.Implements(types.NewPointer(named), collectionIface):
+			entity.Pointer = true
+		default:
+			// helper types
+			continue
+		}
+
+		_, entity.IsStruct = named.Underlying().(*types.Struct)
+		entity.Name = getCollectionName(files, info, typeName)
+		res = append(res, entity)
+	}
+
+	return res, nil
+}
+
+// This parses all non-test go files in `dir`
+func parsePackageFiles(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	res := []*ast.File{}
+	for _, name := range config.GetAllFileNames(dir) {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			// the error contains the position, i.e: users.go:10:2: expected ...
+			return nil, fmt.Errorf("cannot parse collection file: %w", err)
+		}
+
+		res = append(res, file)
+	}
+
+	return res, nil
+}
+
+// This returns the collection name passed to metadata.InitMetadata in the Collection method of `typeName`,
+// the name must be a constant expression, i.e: a string literal or a string constant
+func getCollectionName(files []*ast.File, info *types.Info, typeName *types.TypeName) string {
+	res := ""
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || funcDecl.Name.Name != "Collection" || funcDecl.Body == nil {
+				continue
+			}
+
+			if getReceiverTypeName(funcDecl, info) != typeName {
+				continue
+			}
+
+			ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if res != "" || !ok || len(call.Args) != 1 || !isInitMetadata(call.Fun, info) {
+					return res == ""
+				}
+
+				value := info.Types[call.Args[0]].Value
+				if value != nil && value.Kind() == constant.String {
+					res = constant.StringVal(value)
+				}
+
+				return false
+			})
+
+			return res
+		}
 	}
 
 	return res
 }
 
-func getCollectionStructName(filePath string) (*string, error) {
-	source := config.GetFileContent(filePath)
-	pattern := `type [a-zA-Z0-9]+ struct`
-	re := regexp.MustCompile(pattern)
-	matchedStr := re.FindString(source)
-	if matchedStr == "" {
-		return nil, fmt.Errorf("no valid collection struct was found")
+func getReceiverTypeName(funcDecl *ast.FuncDecl, info *types.Info) types.Object {
+	recvType := funcDecl.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
 	}
 
-	// get the second word
-	res := strings.Split(matchedStr, " ")[1]
+	ident, ok := recvType.(*ast.Ident)
+	if !ok {
+		return nil
+	}
 
-	return &res, nil
+	return info.Uses[ident]
 }
 
-func getAllCollectionNames() []string {
-	res := []string{}
-	rootPath, err := config.GetProjectRootDir()
+func isInitMetadata(fun ast.Expr, info *types.Info) bool {
+	var ident *ast.Ident
+	switch expr := fun.(type) {
+	case *ast.SelectorExpr:
+		ident = expr.Sel
+	case *ast.Ident:
+		ident = expr
+	default:
+		return false
+	}
+
+	obj, ok := info.Uses[ident].(*types.Func)
+
+	return ok && obj.Pkg() != nil && obj.Pkg().Path() == metadataPkgPath && obj.Name() == "InitMetadata"
+}
+
+// This returns the collection names,
+// a collection without a constant name is ignored
+func getAllCollectionNames() ([]string, error) {
+	entities, err := getAllCollectionEntities()
 	if err != nil {
-		return res
+		return nil, err
 	}
 
-	path := fmt.Sprintf("%s/%s", *rootPath, baseCollectionPath)
-	collectionFileNames := config.GetAllFileNames(path)
-	for _, name := range collectionFileNames {
-		filePath := fmt.Sprintf("%s/%s", path, name)
-		structName, err := getCollectionName(filePath)
-		if err != nil {
-			fmt.Println(err)
-			continue
+	res := []string{}
+	for _, entity := range entities {
+		if entity.Name != "" {
+			res = append(res, entity.Name)
 		}
-
-		res = append(res, *structName)
 	}
 
-	return res
+	sort.Strings(res)
+
+	return res, nil
 }
 
-func getCollectionName(filePath string) (*string, error) {
-	source := config.GetFileContent(filePath)
-	pattern := `metadata.InitMetadata\("[a-zA-Z0-9_]+"\)`
-	re := regexp.MustCompile(pattern)
-	matchedStr := re.FindString(source)
-	if matchedStr == "" {
-		return nil, fmt.Errorf("no valid collection name was found")
+// This returns the import declarations and the collection instances of the combined collections
+func getCombinedCollections() ([]string, []string, error) {
+	entities, err := getAllCollectionEntities()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// get anything inside the double quotes
-	res := strings.Split(matchedStr, "\"")[1]
+	imports := []string{}
+	instances := []string{}
+	aliases := map[string]string{}
+	for _, entity := range entities {
+		alias := entity.pkgAlias()
+		if pkgPath, ok := aliases[alias]; ok && pkgPath != entity.PkgPath {
+			return nil, nil, fmt.Errorf("collection packages %s and %s have the same alias %s", pkgPath, entity.PkgPath, alias)
+		} else if !ok {
+			aliases[alias] = entity.PkgPath
+			imports = append(imports, fmt.Sprintf("%s %q", alias, entity.PkgPath))
+		}
+
+		instances = append(instances, entity.instanceLiteral())
+	}
 
-	return &res, nil
+	return imports, instances, nil
 }
@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -28,14 +29,10 @@ func testSetupCollectionFolder() {
 	}
 
 	// init combined collection
-	tplVar := struct {
-		Collections []string
-		CreateDate  string
-		ModuleName  string
-	}{
-		Collections: []string{},
+	tplVar := CombinedCollectionsTemplateVar{
 		CreateDate:  time.Now().Format("2006-01-02"),
-		ModuleName:  "go-mongr8",
+		Imports:     []string{},
+		Collections: []string{},
 	}
 
 	tplPath, err := config.GetTemplatePath("collection", "generator.tpl")
@@ -78,7 +75,7 @@ func testSetupCollection(collectionName string) {
 	}
 
 	// generate combined collections
-	combinedCollsTemplateVar, err := getCombinedCollectionsTemplateVar(*rootPath)
+	combinedCollsTemplateVar, err := getCombinedCollectionsTemplateVar()
 	if err != nil {
 		panic(err)
 	}
@@ -102,171 +99,182 @@ func testTearDown() {
 	}
 }
 
-func TestGetAllCollectionStructs(t *testing.T) {
+// writes a custom source file into the collection folder
+func testSetupCollectionFile(relPath, source string) {
+	rootPath, err := config.GetProjectRootDir()
+	if err != nil {
+		panic(err)
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", *rootPath, baseCollectionPath, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		panic(err)
+	}
+
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		panic(err)
+	}
+}
+
+func getTestEntityNames(entities []collectionEntity) map[string]string {
+	res := map[string]string{}
+	for _, entity := range entities {
+		res[entity.PkgDir+"."+entity.TypeName] = entity.Name
+	}
+
+	return res
+}
+
+func TestGetAllCollectionEntities(t *testing.T) {
 	Convey("Case 1: Normal", t, func() {
 		testTearDown()
 		testSetupCollection("first_collection")
 		testSetupCollection("second_collection")
 
-		collectionStructs := getAllCollectionStructs()
-		Convey("Unexpected collection structs length", func() {
-			So(len(collectionStructs), ShouldEqual, 2)
+		entities, err := getAllCollectionEntities()
+		So(err, ShouldBeNil)
+		So(getTestEntityNames(entities), ShouldResemble, map[string]string{
+			"..FirstCollection":  "first_collection",
+			"..SecondCollection": "second_collection",
 		})
 
-		expectedStructs := map[string]bool{
-			"FirstCollection":  true,
-			"SecondCollection": true,
-		}
-
-		Convey("Unexpected struct name", func() {
-			for _, name := range collectionStructs {
-				_, ok := expectedStructs[name]
-				So(ok, ShouldBeTrue)
-			}
-		})
+		testTearDown()
 	})
-}
-
-func TestGetCollectionStructName(t *testing.T) {
-	Convey("Case 1: Normal", t, func() {
-		rootPath, err := config.GetProjectRootDir()
-		if err != nil {
-			panic(err)
-		}
 
+	Convey("Case 2: Multiple types, constant names, and sub folders", t, func() {
+		testTearDown()
 		testSetupCollection("first_collection")
-		testSetupCollection("second_collection")
+		testSetupCollectionFile("shared.go", `package collection
 
-		Convey("Unexpected struct name", func() {
-			coll1Path := fmt.Sprintf("%s/%s/first_collection.go", *rootPath, baseCollectionPath)
-			name1, err := getCollectionStructName(coll1Path)
-			if err != nil {
-				panic(err)
-			}
+import (
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+)
 
-			So(*name1, ShouldEqual, "FirstCollection")
+const ordersName = "orders"
 
-			coll2Path := fmt.Sprintf("%s/%s/second_collection.go", *rootPath, baseCollectionPath)
-			name2, err := getCollectionStructName(coll2Path)
-			if err != nil {
-				panic(err)
-			}
+// a helper type, not a collection
+type Address struct{}
 
-			So(*name2, ShouldEqual, "SecondCollection")
-		})
+func (Address) Name() string { return "address" }
 
-		testTearDown()
-	})
-}
+type Orders struct{}
 
-func TestGetAllCollectionNames(t *testing.T) {
-	Convey("Case 1: Normal snake case input", t, func() {
-		testSetupCollection("first_collection")
-		testSetupCollection("second_collection")
+func (Orders) Collection() collection.Metadata { return metadata.InitMetadata(ordersName) }
+func (Orders) Fields() []collection.Field      { return nil }
+func (Orders) Indexes() []collection.Index     { return nil }
+
+type Carts struct{}
+
+func (*Carts) Collection() collection.Metadata { return metadata.InitMetadata("carts" + "_v2") }
+func (*Carts) Fields() []collection.Field      { return nil }
+func (*Carts) Indexes() []collection.Index     { return nil }
+
+type dynamic struct{ name string }
+
+func (d dynamic) Collection() collection.Metadata { return metadata.InitMetadata(d.name) }
+func (dynamic) Fields() []collection.Field        { return nil }
+func (dynamic) Indexes() []collection.Index       { return nil }
+
+type Logs struct{ dynamic }
+`)
+		testSetupCollectionFile("billing/invoices.go", `package billing
+
+import (
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+)
 
-		collectionNames := getAllCollectionNames()
-		Convey("Unexpected collection names length", func() {
-			So(len(collectionNames), ShouldEqual, 2)
+type Invoices struct{}
+
+func (Invoices) Collection() collection.Metadata { return metadata.InitMetadata("invoices") }
+func (Invoices) Fields() []collection.Field      { return nil }
+func (Invoices) Indexes() []collection.Index     { return nil }
+`)
+
+		entities, err := getAllCollectionEntities()
+		So(err, ShouldBeNil)
+		So(getTestEntityNames(entities), ShouldResemble, map[string]string{
+			"..Carts":           "carts_v2",
+			"..FirstCollection": "first_collection",
+			"..Logs":            "",
+			"..Orders":          "orders",
+			"billing.Invoices":  "invoices",
 		})
 
-		expectedNames := map[string]bool{
-			"first_collection":  true,
-			"second_collection": true,
-		}
+		names, err := getAllCollectionNames()
+		So(err, ShouldBeNil)
+		So(names, ShouldResemble, []string{"carts_v2", "first_collection", "invoices", "orders"})
 
-		Convey("Unexpected collection name", func() {
-			for _, name := range collectionNames {
-				_, ok := expectedNames[name]
-				So(ok, ShouldBeTrue)
-			}
+		imports, instances, err := getCombinedCollections()
+		So(err, ShouldBeNil)
+		So(imports, ShouldResemble, []string{
+			`coll "github.com/amirkode/go-mongr8/mongr8/collection"`,
+			`coll_billing "github.com/amirkode/go-mongr8/mongr8/collection/billing"`,
+		})
+		So(instances, ShouldResemble, []string{
+			"&coll.Carts{}",
+			"coll.FirstCollection{}",
+			"coll.Logs{}",
+			"coll.Orders{}",
+			"coll_billing.Invoices{}",
 		})
 
 		testTearDown()
 	})
 
-	Convey("Case 2: Camel case input", t, func() {
-		testSetupCollection("FirstCollection")
-		testSetupCollection("SecondCollection")
+	Convey("Case 3: Unparseable file", t, func() {
+		testTearDown()
+		testSetupCollection("first_collection")
+		testSetupCollectionFile("broken.go", "package collection\n\ntype Broken struct {\n")
 
-		collectionNames := getAllCollectionNames()
-		Convey("Unexpected collection names length", func() {
-			So(len(collectionNames), ShouldEqual, 2)
-		})
+		_, err := getAllCollectionEntities()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "broken.go:3:22")
+
+		testTearDown()
+	})
 
-		expectedNames := map[string]bool{
-			"firstcollection":  true,
-			"secondcollection": true,
-		}
+	Convey("Case 4: Invalid file", t, func() {
+		testTearDown()
+		testSetupCollectionFile("invalid.go", "package collection\n\nvar count int = \"one\"\n")
 
-		Convey("Unexpected collection name", func() {
-			for _, name := range collectionNames {
-				_, ok := expectedNames[name]
-				So(ok, ShouldBeTrue)
-			}
-		})
+		_, err := getAllCollectionEntities()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "invalid.go:3:17")
 
 		testTearDown()
 	})
 }
 
-func TestGetCollectionName(t *testing.T) {
+func TestGetAllCollectionNames(t *testing.T) {
 	Convey("Case 1: Normal snake case input", t, func() {
-		rootPath, err := config.GetProjectRootDir()
-		if err != nil {
-			panic(err)
-		}
-
 		testSetupCollection("first_collection")
 		testSetupCollection("second_collection")
 
-		Convey("Unexpected collection name", func() {
-			coll1Path := fmt.Sprintf("%s/%s/first_collection.go", *rootPath, baseCollectionPath)
-			name1, err := getCollectionName(coll1Path)
-			if err != nil {
-				panic(err)
-			}
-
-			So(*name1, ShouldEqual, "first_collection")
-
-			coll2Path := fmt.Sprintf("%s/%s/second_collection.go", *rootPath, baseCollectionPath)
-			name2, err := getCollectionName(coll2Path)
-			if err != nil {
-				panic(err)
-			}
-
-			So(*name2, ShouldEqual, "second_collection")
-		})
+		collectionNames, err := getAllCollectionNames()
+		So(err, ShouldBeNil)
+		So(collectionNames, ShouldResemble, []string{"first_collection", "second_collection"})
 
 		testTearDown()
 	})
 
 	Convey("Case 2: Camel case input", t, func() {
-		rootPath, err := config.GetProjectRootDir()
-		if err != nil {
-			panic(err)
-		}
-
 		testSetupCollection("FirstCollection")
 		testSetupCollection("SecondCollection")
 
-		Convey("Unexpected collection name", func() {
-			coll1Path := fmt.Sprintf("%s/%s/firstcollection.go", *rootPath, baseCollectionPath)
-			name1, err := getCollectionName(coll1Path)
-			if err != nil {
-				panic(err)
-			}
-
-			So(*name1, ShouldEqual, "firstcollection")
-
-			coll2Path := fmt.Sprintf("%s/%s/secondcollection.go", *rootPath, baseCollectionPath)
-			name2, err := getCollectionName(coll2Path)
-			if err != nil {
-				panic(err)
-			}
+		collectionNames, err := getAllCollectionNames()
+		So(err, ShouldBeNil)
+		So(collectionNames, ShouldResemble, []string{"firstcollection", "secondcollection"})
 
-			So(*name2, ShouldEqual, "secondcollection")
-		})
+		testTearDown()
+	})
 
+	Convey("Case 3: No collection folder", t, func() {
 		testTearDown()
+
+		collectionNames, err := getAllCollectionNames()
+		So(err, ShouldBeNil)
+		So(len(collectionNames), ShouldEqual, 0)
 	})
 }
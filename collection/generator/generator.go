@@ -29,8 +29,10 @@ type CollectionTemplateVar struct {
 }
 
 type CombinedCollectionsTemplateVar struct {
-	CreateDate  string
-	ModuleName  string
+	CreateDate string
+	// import declarations of the collection packages, i.e: coll "module/mongr8/collection"
+	Imports []string
+	// collection instances, i.e: coll.Users{}
 	Collections []string
 }
 
@@ -41,7 +43,11 @@ func getCollectionTemplateVar(collectionName string) (*CollectionTemplateVar, er
 		return nil, errors.New("an empty string provided")
 	}
 
-	allCollectionNames := getAllCollectionNames()
+	allCollectionNames, err := getAllCollectionNames()
+	if err != nil {
+		return nil, err
+	}
+
 	if slices.Contains(allCollectionNames, collectionName) {
 		return nil, errors.New("the provided collection name already exists")
 	}
@@ -58,19 +64,16 @@ func getCollectionTemplateVar(collectionName string) (*CollectionTemplateVar, er
 	return templateVar, nil
 }
 
-func getCombinedCollectionsTemplateVar(rootPath string) (*CombinedCollectionsTemplateVar, error) {
+func getCombinedCollectionsTemplateVar() (*CombinedCollectionsTemplateVar, error) {
 	createDate := time.Now().Format("2006-01-02")
-	moduleName := config.GetProjectRootModuleName(rootPath)
-
-	existingCollections := getAllCollectionStructs()
-	collections := []string{}
-	for _, coll := range existingCollections {
-		collections = append(collections, fmt.Sprintf("Instance%s", coll))
+	imports, collections, err := getCombinedCollections()
+	if err != nil {
+		return nil, err
 	}
 
 	templateVar := &CombinedCollectionsTemplateVar{
 		CreateDate:  createDate,
-		ModuleName:  moduleName,
+		Imports:     imports,
 		Collections: collections,
 	}
 
@@ -101,7 +104,7 @@ func GenerateMigrationTemplate(collectionName string) error {
 	}
 
 	// generate combined collections
-	combinedCollsTemplateVar, err := getCombinedCollectionsTemplateVar(*rootPath)
+	combinedCollsTemplateVar, err := getCombinedCollectionsTemplateVar()
 	if err != nil { 
 		return err
 	}
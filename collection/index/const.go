@@ -18,7 +18,15 @@ const (
 	TypeText              IndexType = "TypeText"
 	TypeGeopatial2dsphere IndexType = "TypeGeopatial2dsphere"
 	TypeHashed            IndexType = "TypeHashedIndex"
+	TypeWildcard          IndexType = "TypeWildcard"
+	TypeGeospatial2d      IndexType = "TypeGeospatial2d"
 	TypeRaw               IndexType = "TypeRaw"
+	// Index Key Values
+	KeyText     = "text"
+	Key2dsphere = "2dsphere"
+	Key2d       = "2d"
+	KeyHashed   = "hashed"
+	KeyWildcard = "$**"
 	// Index Options
 	OptionSparse           = "sparse"
 	OptionBackground       = "background"
@@ -27,4 +35,23 @@ const (
 	OptionPartialFilterExp = "partialFilterExpression"
 	OptionTTL              = "expireAfterSeconds"
 	OptionCollation        = "collation"
+	// wildcard index options
+	OptionWildcardProjection = "wildcardProjection"
+	// geospatial 2d index options
+	OptionMin  = "min"
+	OptionMax  = "max"
+	OptionBits = "bits"
+	// text index options
+	OptionWeights          = "weights"
+	OptionDefaultLanguage  = "default_language"
+	OptionLanguageOverride = "language_override"
 )
+
+// options those can be modified on an existing index without rebuilding
+func GetModifiableOptions() []string {
+	return []string{
+		OptionHidden,
+		OptionTTL,
+		OptionUnique,
+	}
+}
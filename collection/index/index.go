@@ -10,6 +10,8 @@ package index
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 type IndexField struct {
@@ -43,6 +45,18 @@ func (f IndexField) NestedField(name string) IndexField {
 	return f
 }
 
+// Set the key as a 2dsphere key, i.e: in a compound index
+func (f IndexField) As2dsphere() IndexField {
+	f.Value = Key2dsphere
+	return f
+}
+
+// Set the key as a hashed key, i.e: in a compound index
+func (f IndexField) AsHashed() IndexField {
+	f.Value = KeyHashed
+	return f
+}
+
 func (f IndexField) MustHaveValue(_for string) {
 	if f.Value == nil {
 		panic(fmt.Sprintf("%s: Value should be provided for %s", f.Key, _for))
@@ -95,6 +109,69 @@ func (s *Spec) GetKey() string {
 	return key
 }
 
+// this is used for finding the same index
+// with different rules, only type and index fields are compared
+func (s *Spec) GetBaseKey() string {
+	key := string(s.Type)
+	for _, field := range s.Fields {
+		key += field.Key
+		key += fmt.Sprintf("%v", field.Value)
+	}
+
+	return key
+}
+
+// Check whether this index can be modified to `other` without rebuilding
+// both must share the same base key and name, and differ only in modifiable options.
+// Expiration can only be changed on a TTL index
+func (s *Spec) IsModifiableTo(other *Spec) bool {
+	if s.GetBaseKey() != other.GetBaseKey() {
+		return false
+	}
+
+	if other.Name != nil && *other.Name != s.GetName() {
+		return false
+	}
+
+	if s.HasRule(OptionTTL) != other.HasRule(OptionTTL) {
+		return false
+	}
+
+	getRule := func(spec *Spec, option string) interface{} {
+		if !spec.HasRule(option) {
+			return nil
+		}
+
+		return (*spec.Rules)[option]
+	}
+
+	options := map[string]bool{}
+	for _, spec := range []*Spec{s, other} {
+		if spec.Rules != nil {
+			for option := range *spec.Rules {
+				options[option] = true
+			}
+		}
+	}
+
+	modifiable := GetModifiableOptions()
+	for option := range options {
+		isModifiable := false
+		for _, m := range modifiable {
+			if m == option {
+				isModifiable = true
+				break
+			}
+		}
+
+		if !isModifiable && !reflect.DeepEqual(getRule(s, option), getRule(other, option)) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (s *Spec) GetName() string {
 	if s.Name != nil {
 		return *s.Name
@@ -116,8 +193,14 @@ func (s *Spec) GetName() string {
 			reflect.TypeOf(curr).Key().Kind() == reflect.String &&
 			reflect.TypeOf(curr).Elem().Kind() == reflect.Interface {
 			mp := curr.(map[string]interface{})
-			for key, val := range mp {
-				res = fmt.Sprintf("%s_%s%s", res, key, getPath(val))
+			// keys are sorted, so the name is deterministic
+			keys := []string{}
+			for key := range mp {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				res = fmt.Sprintf("%s_%s%s", res, key, getPath(mp[key]))
 			}
 		} else if reflect.TypeOf(curr).Kind() == reflect.Slice &&
 			reflect.TypeOf(curr).Elem().Kind() == reflect.Interface {
@@ -164,6 +247,13 @@ func (b *IndexSpec) SetRules(rules map[string]interface{}) {
 	b.spec.Rules = &rules
 }
 
+// Panic if index is not the expected type for an option
+func (b *IndexSpec) mustBeType(_type IndexType, option string) {
+	if b.spec.Type != _type {
+		panic(fmt.Sprintf("Option %s is only available for index type %s", option, _type))
+	}
+}
+
 // Panic if index is raw type
 func (b *IndexSpec) MustNotRaw() {
 	if b.spec.Type == TypeRaw {
@@ -248,6 +338,78 @@ func (b *IndexSpec) SetCollation(collation map[string]interface{}) *IndexSpec {
 	return b
 }
 
+// Set `wildcardProjection` option
+// Includes or excludes particular paths of a wildcard index on all fields
+func (b *IndexSpec) SetWildcardProjection(projection map[string]interface{}) *IndexSpec {
+	b.mustBeType(TypeWildcard, OptionWildcardProjection)
+	b.InitRules()
+
+	(*b.spec.Rules)[OptionWildcardProjection] = projection
+
+	return b
+}
+
+// Set `min` and `max` options
+// Sets the location bounds of a 2d index
+func (b *IndexSpec) SetBounds(min, max float64) *IndexSpec {
+	b.mustBeType(TypeGeospatial2d, "bounds")
+	b.InitRules()
+
+	(*b.spec.Rules)[OptionMin] = min
+	(*b.spec.Rules)[OptionMax] = max
+
+	return b
+}
+
+// Set `bits` option
+// Sets the precision of the geohash value of a 2d index
+func (b *IndexSpec) SetBits(bits int32) *IndexSpec {
+	b.mustBeType(TypeGeospatial2d, OptionBits)
+	b.InitRules()
+
+	(*b.spec.Rules)[OptionBits] = bits
+
+	return b
+}
+
+// Set `weights` option
+// Sets the significance of each field of a text index
+func (b *IndexSpec) SetWeights(weights map[string]int32) *IndexSpec {
+	b.mustBeType(TypeText, OptionWeights)
+	b.InitRules()
+
+	values := map[string]interface{}{}
+	for key, weight := range weights {
+		values[key] = weight
+	}
+
+	(*b.spec.Rules)[OptionWeights] = values
+
+	return b
+}
+
+// Set `default_language` option
+// Sets the language for the stop words and stemming of a text index
+func (b *IndexSpec) SetDefaultLanguage(language string) *IndexSpec {
+	b.mustBeType(TypeText, OptionDefaultLanguage)
+	b.InitRules()
+
+	(*b.spec.Rules)[OptionDefaultLanguage] = language
+
+	return b
+}
+
+// Set `language_override` option
+// Sets the field name containing the language of each document in a text index
+func (b *IndexSpec) SetLanguageOverride(fieldName string) *IndexSpec {
+	b.mustBeType(TypeText, OptionLanguageOverride)
+	b.InitRules()
+
+	(*b.spec.Rules)[OptionLanguageOverride] = fieldName
+
+	return b
+}
+
 func (b *IndexSpec) SetCustomIndexName(name string) *IndexSpec {
 	b.spec.Name = &name
 	return b
@@ -316,10 +478,24 @@ func CompoundIndex(fields ...IndexField) *IndexSpec {
 	return defaultIndex(TypeCompound, fields, nil)
 }
 
-func TextIndex(field IndexField) *IndexSpec {
-	field.Value = "text" // used for index name sufix
-	// TODO: check other index too
-	return defaultIndex(TypeText, []IndexField{field}, nil)
+// reference: https://www.mongodb.com/docs/manual/core/indexes/index-types/index-text/
+// fields without value are indexed as text,
+// while fields with value are the prefix or suffix keys of a compound text index
+func TextIndex(fields ...IndexField) *IndexSpec {
+	if len(fields) == 0 {
+		panic("Text index must have at least a field")
+	}
+
+	textFields := []IndexField{}
+	for _, field := range fields {
+		if field.Value == nil {
+			field.Value = KeyText // used for index name sufix
+		}
+
+		textFields = append(textFields, field)
+	}
+
+	return defaultIndex(TypeText, textFields, nil)
 }
 
 func Geospatial2dsphereIndex(field IndexField) *IndexSpec {
@@ -330,6 +506,36 @@ func HashedIndex(field IndexField) *IndexSpec {
 	return defaultIndex(TypeHashed, []IndexField{field}, nil)
 }
 
+// reference: https://www.mongodb.com/docs/manual/core/indexes/index-types/index-wildcard/
+// it indexes all fields if no path is provided, or all fields under the path
+func WildcardIndex(path ...string) *IndexSpec {
+	if len(path) > 1 {
+		panic("Wildcard index path at most declared once")
+	}
+
+	key := KeyWildcard
+	if len(path) == 1 && path[0] != "" {
+		key = path[0] + "." + KeyWildcard
+	}
+
+	return defaultIndex(TypeWildcard, []IndexField{NewIndexField(key, 1)}, nil)
+}
+
+// reference: https://www.mongodb.com/docs/manual/core/indexes/index-types/geospatial/2d/
+func Geospatial2dIndex(field IndexField) *IndexSpec {
+	field.Value = Key2d
+	return defaultIndex(TypeGeospatial2d, []IndexField{field}, nil)
+}
+
+// returns the path of a wildcard index key, empty if it's on all fields
+func GetWildcardPath(key string) string {
+	if key == KeyWildcard {
+		return ""
+	}
+
+	return strings.TrimSuffix(key, "."+KeyWildcard)
+}
+
 func RawIndex(fields map[string]interface{}, rules *map[string]interface{}) *IndexSpec {
 	return customValueIndex(TypeRaw, fields, rules)
 }
@@ -76,3 +76,70 @@ func TestCompoundIndex(t *testing.T) {
 		CompoundIndex()
 	})
 }
+
+func TestIsModifiableTo(t *testing.T) {
+	Convey("Modifiable options only", t, func() {
+		So(SingleFieldIndex(Field("name", 1)).Spec().IsModifiableTo(SingleFieldIndex(Field("name", 1)).AsHidden().Spec()), ShouldBeTrue)
+		So(SingleFieldIndex(Field("name", 1)).AsUnique().Spec().IsModifiableTo(SingleFieldIndex(Field("name", 1)).Spec()), ShouldBeTrue)
+		So(SingleFieldIndex(Field("created_at", 1)).SetTTL(60).Spec().IsModifiableTo(SingleFieldIndex(Field("created_at", 1)).SetTTL(120).AsHidden().Spec()), ShouldBeTrue)
+	})
+
+	Convey("Rebuild required", t, func() {
+		// different fields
+		So(SingleFieldIndex(Field("name", 1)).Spec().IsModifiableTo(SingleFieldIndex(Field("name", -1)).AsHidden().Spec()), ShouldBeFalse)
+		// expiration on a non TTL index
+		So(SingleFieldIndex(Field("created_at", 1)).Spec().IsModifiableTo(SingleFieldIndex(Field("created_at", 1)).SetTTL(60).Spec()), ShouldBeFalse)
+		// other options
+		So(SingleFieldIndex(Field("name", 1)).Spec().IsModifiableTo(SingleFieldIndex(Field("name", 1)).AsSparse().Spec()), ShouldBeFalse)
+		// different custom name
+		So(SingleFieldIndex(Field("name", 1)).Spec().IsModifiableTo(SingleFieldIndex(Field("name", 1)).AsHidden().SetCustomIndexName("hidden_name").Spec()), ShouldBeFalse)
+	})
+}
+
+func TestTextIndex(t *testing.T) {
+	Convey("Compound text index", t, func() {
+		index := TextIndex(Field("category", 1), Field("title"), Field("body")).SetWeights(map[string]int32{"title": 10})
+		So(index.Spec().Fields, ShouldResemble, []IndexField{
+			Field("category", 1),
+			Field("title", KeyText),
+			Field("body", KeyText),
+		})
+		So((*index.Spec().Rules)[OptionWeights], ShouldResemble, map[string]interface{}{"title": int32(10)})
+	})
+
+	Convey("Text option on other index type", t, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				Convey("Unexpected panic", func() {
+					So(fmt.Sprintf("%v", r), ShouldContainSubstring, OptionWeights)
+				})
+			}
+		}()
+
+		SingleFieldIndex(Field("title", 1)).SetWeights(map[string]int32{"title": 10})
+	})
+}
+
+func TestWildcardIndex(t *testing.T) {
+	Convey("Wildcard index on all fields", t, func() {
+		index := WildcardIndex()
+		So(index.Spec().Fields, ShouldResemble, []IndexField{Field(KeyWildcard, 1)})
+		So(GetWildcardPath(index.Spec().Fields[0].Key), ShouldEqual, "")
+	})
+
+	Convey("Wildcard index on a path", t, func() {
+		index := WildcardIndex("attributes")
+		So(index.Spec().Fields, ShouldResemble, []IndexField{Field("attributes.$**", 1)})
+		So(GetWildcardPath(index.Spec().Fields[0].Key), ShouldEqual, "attributes")
+	})
+}
+
+func TestGeospatial2dIndex(t *testing.T) {
+	Convey("2d index with bounds and bits", t, func() {
+		index := Geospatial2dIndex(Field("location")).SetBounds(-90, 90).SetBits(20)
+		So(index.Spec().Fields, ShouldResemble, []IndexField{Field("location", Key2d)})
+		So((*index.Spec().Rules)[OptionMin], ShouldEqual, float64(-90))
+		So((*index.Spec().Rules)[OptionMax], ShouldEqual, float64(90))
+		So((*index.Spec().Rules)[OptionBits], ShouldEqual, int32(20))
+	})
+}
@@ -23,12 +23,48 @@ const (
 	CollectionOptionCapped              CollectionOption = "capped"
 	CollectionOptionCappedSize          CollectionOption = "size"
 	CollectionOptionExpiredAfterSeconds CollectionOption = "expiredAfterSeconds"
+	CollectionOptionViewOn              CollectionOption = "viewOn"
+	CollectionOptionPipeline            CollectionOption = "pipeline"
+	CollectionOptionCollation           CollectionOption = "collation"
+	// time series options
+	CollectionOptionTimeField             CollectionOption = "timeField"
+	CollectionOptionMetaField             CollectionOption = "metaField"
+	CollectionOptionGranularity           CollectionOption = "granularity"
+	CollectionOptionBucketMaxSpanSeconds  CollectionOption = "bucketMaxSpanSeconds"
+	CollectionOptionBucketRoundingSeconds CollectionOption = "bucketRoundingSeconds"
 )
 
+type TimeSeriesGranularity string
+
+const (
+	GranularitySeconds TimeSeriesGranularity = "seconds"
+	GranularityMinutes TimeSeriesGranularity = "minutes"
+	GranularityHours   TimeSeriesGranularity = "hours"
+)
+
+// compare granularity by its bucket size, a coarser granularity is greater
+func (g TimeSeriesGranularity) CompareTo(other TimeSeriesGranularity) int {
+	order := map[TimeSeriesGranularity]int{
+		GranularitySeconds: 1,
+		GranularityMinutes: 2,
+		GranularityHours:   3,
+	}
+
+	return order[g] - order[other]
+}
+
 func GetAllOptionKeys() []CollectionOption {
 	return []CollectionOption{
 		CollectionOptionCapped,
 		CollectionOptionCappedSize,
 		CollectionOptionExpiredAfterSeconds,
+		CollectionOptionViewOn,
+		CollectionOptionPipeline,
+		CollectionOptionCollation,
+		CollectionOptionTimeField,
+		CollectionOptionMetaField,
+		CollectionOptionGranularity,
+		CollectionOptionBucketMaxSpanSeconds,
+		CollectionOptionBucketRoundingSeconds,
 	}
 }
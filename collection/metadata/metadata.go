@@ -63,6 +63,151 @@ func (s *MetadataSpec) AsView() *MetadataSpec {
 	return s
 }
 
+// ViewOn declares the collection as a view of `source` collection (or another view)
+// each stage of `pipeline` is an aggregation stage, i.e: {"$match": {"status": "active"}}
+func (s *MetadataSpec) ViewOn(source string, pipeline []map[string]interface{}) *MetadataSpec {
+	s.initOptions()
+
+	_, found := (*s.Spec().Options)[CollectionOptionViewOn]
+	if found {
+		panic(fmt.Sprintf("Cannot add view option, another option already exists on collection: %s", s.Spec().Name))
+	}
+
+	if pipeline == nil {
+		pipeline = []map[string]interface{}{}
+	}
+
+	(*s.Spec().Options)[CollectionOptionViewOn] = source
+	(*s.Spec().Options)[CollectionOptionPipeline] = pipeline
+
+	return s.AsView()
+}
+
+// SetCollation sets the default collation of the collection
+func (s *MetadataSpec) SetCollation(collation map[string]interface{}) *MetadataSpec {
+	s.initOptions()
+
+	_, found := (*s.Spec().Options)[CollectionOptionCollation]
+	if found {
+		panic(fmt.Sprintf("Cannot add collation option, another option already exists on collection: %s", s.Spec().Name))
+	}
+
+	(*s.Spec().Options)[CollectionOptionCollation] = collation
+
+	return s
+}
+
+// TimeSeries declares the collection as a time series collection,
+// `timeField` is the top level field holding the date of each measurement
+func (s *MetadataSpec) TimeSeries(timeField string) *MetadataSpec {
+	s.initOptions()
+
+	_, found := (*s.Spec().Options)[CollectionOptionTimeField]
+	if found {
+		panic(fmt.Sprintf("Cannot add time series option, another option already exists on collection: %s", s.Spec().Name))
+	}
+
+	(*s.Spec().Options)[CollectionOptionTimeField] = timeField
+
+	return s
+}
+
+func (s *MetadataSpec) mustTimeSeries(option CollectionOption) {
+	if !s.Spec().IsTimeSeries() {
+		panic(fmt.Sprintf("Cannot add %s option, collection %s is not a time series collection", option, s.Spec().Name))
+	}
+
+	_, found := (*s.Spec().Options)[option]
+	if found {
+		panic(fmt.Sprintf("Cannot add %s option, another option already exists on collection: %s", option, s.Spec().Name))
+	}
+}
+
+// SetMetaField sets the top level field holding the metadata of each measurement on a time series collection
+func (s *MetadataSpec) SetMetaField(metaField string) *MetadataSpec {
+	s.mustTimeSeries(CollectionOptionMetaField)
+	(*s.Spec().Options)[CollectionOptionMetaField] = metaField
+
+	return s
+}
+
+// SetGranularity sets the granularity of a time series collection
+func (s *MetadataSpec) SetGranularity(granularity TimeSeriesGranularity) *MetadataSpec {
+	s.mustTimeSeries(CollectionOptionGranularity)
+	(*s.Spec().Options)[CollectionOptionGranularity] = granularity
+
+	return s
+}
+
+// SetBucketSpan sets the custom bucketing of a time series collection, it's an alternative of granularity
+func (s *MetadataSpec) SetBucketSpan(maxSpanSeconds, roundingSeconds int64) *MetadataSpec {
+	s.mustTimeSeries(CollectionOptionBucketMaxSpanSeconds)
+	(*s.Spec().Options)[CollectionOptionBucketMaxSpanSeconds] = maxSpanSeconds
+	(*s.Spec().Options)[CollectionOptionBucketRoundingSeconds] = roundingSeconds
+
+	return s
+}
+
+// IsTimeSeries returns whether the collection is declared as a time series collection
+func (s *Spec) IsTimeSeries() bool {
+	return s.GetTimeField() != ""
+}
+
+// GetTimeField returns the time field of a time series collection, or empty if it's not declared
+func (s *Spec) GetTimeField() string {
+	if s.Options == nil {
+		return ""
+	}
+
+	timeField, _ := (*s.Options)[CollectionOptionTimeField].(string)
+
+	return timeField
+}
+
+// GetMetaField returns the meta field of a time series collection, or empty if it's not declared
+func (s *Spec) GetMetaField() string {
+	if s.Options == nil {
+		return ""
+	}
+
+	metaField, _ := (*s.Options)[CollectionOptionMetaField].(string)
+
+	return metaField
+}
+
+// GetViewOn returns the source collection of a view, or empty if it's not declared
+func (s *Spec) GetViewOn() string {
+	if s.Options == nil {
+		return ""
+	}
+
+	source, _ := (*s.Options)[CollectionOptionViewOn].(string)
+
+	return source
+}
+
+// GetPipeline returns the aggregation pipeline of a view
+func (s *Spec) GetPipeline() []map[string]interface{} {
+	if s.Options == nil {
+		return nil
+	}
+
+	pipeline, _ := (*s.Options)[CollectionOptionPipeline].([]map[string]interface{})
+
+	return pipeline
+}
+
+// GetCollation returns the default collation, or nil if it's not declared
+func (s *Spec) GetCollation() map[string]interface{} {
+	if s.Options == nil {
+		return nil
+	}
+
+	collation, _ := (*s.Options)[CollectionOptionCollation].(map[string]interface{})
+
+	return collation
+}
+
 func InitMetadata(name string) *MetadataSpec {
 	res := &MetadataSpec{
 		&Spec{
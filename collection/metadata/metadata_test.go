@@ -6,3 +6,62 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package metadata
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestViewOn(t *testing.T) {
+	Convey("Case 1: View with pipeline", t, func() {
+		meta := InitMetadata("active_users").ViewOn("users", []map[string]interface{}{
+			{"$match": map[string]interface{}{"status": "active"}},
+		})
+
+		So(meta.Spec().Type, ShouldEqual, TypeViewCollection)
+		So(meta.Spec().GetViewOn(), ShouldEqual, "users")
+		So(meta.Spec().GetPipeline(), ShouldResemble, []map[string]interface{}{
+			{"$match": map[string]interface{}{"status": "active"}},
+		})
+		So(meta.Spec().GetCollation(), ShouldBeNil)
+	})
+
+	Convey("Case 2: View with collation and empty pipeline", t, func() {
+		meta := InitMetadata("all_users").ViewOn("users", nil).SetCollation(map[string]interface{}{"locale": "en"})
+
+		So(meta.Spec().GetPipeline(), ShouldResemble, []map[string]interface{}{})
+		So(meta.Spec().GetCollation(), ShouldResemble, map[string]interface{}{"locale": "en"})
+	})
+
+	Convey("Case 3: Declared twice", t, func() {
+		So(func() {
+			InitMetadata("all_users").ViewOn("users", nil).ViewOn("customers", nil)
+		}, ShouldPanic)
+	})
+}
+
+func TestTimeSeries(t *testing.T) {
+	Convey("Case 1: Time series with meta field and granularity", t, func() {
+		meta := InitMetadata("metrics").TimeSeries("timestamp").SetMetaField("device").SetGranularity(GranularityMinutes)
+
+		So(meta.Spec().IsTimeSeries(), ShouldBeTrue)
+		So(meta.Spec().GetTimeField(), ShouldEqual, "timestamp")
+		So(meta.Spec().GetMetaField(), ShouldEqual, "device")
+		So((*meta.Spec().Options)[CollectionOptionGranularity], ShouldEqual, GranularityMinutes)
+	})
+
+	Convey("Case 2: Time series with bucket span", t, func() {
+		meta := InitMetadata("metrics").TimeSeries("timestamp").SetBucketSpan(300, 300)
+
+		So((*meta.Spec().Options)[CollectionOptionBucketMaxSpanSeconds], ShouldEqual, int64(300))
+		So((*meta.Spec().Options)[CollectionOptionBucketRoundingSeconds], ShouldEqual, int64(300))
+	})
+
+	Convey("Case 3: Time series options without time field", t, func() {
+		So(func() {
+			InitMetadata("metrics").SetMetaField("device")
+		}, ShouldPanic)
+		So(InitMetadata("metrics").Spec().IsTimeSeries(), ShouldBeFalse)
+	})
+}
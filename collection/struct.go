@@ -0,0 +1,395 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package collection
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+)
+
+const (
+	// tag of the field name, follows the MongoDB driver
+	structTagBson = "bson"
+	// tag of the additional options, i.e: `mongr8:"index,unique,min_length=3"`
+	structTagMongr8 = "mongr8"
+)
+
+var (
+	typeTime       = reflect.TypeOf(time.Time{})
+	typeDateTime   = reflect.TypeOf(primitive.DateTime(0))
+	typeObjectID   = reflect.TypeOf(primitive.ObjectID{})
+	typeDecimal128 = reflect.TypeOf(primitive.Decimal128{})
+	typeBinary     = reflect.TypeOf(primitive.Binary{})
+	typeBytes      = reflect.TypeOf([]byte{})
+	typeDocument   = reflect.TypeOf(primitive.D{})
+	typeRaw        = reflect.TypeOf(bson.Raw{})
+)
+
+// FromStruct returns the fields of a struct with bson tags:
+// - a nested struct is declared as an object
+// - a slice or an array is declared as an array
+// - a map with string keys is declared as a map
+// - a pointer is declared as a nullable field
+// a field without a fixed schema is skipped, i.e: interface{}, bson.M, bson.D, bson.Raw,
+// or a slice or a map of them.
+// constraints are declared with the mongr8 tag, i.e: `mongr8:"min=0,max=150"`
+func FromStruct[T any]() []Field {
+	fields, _ := parseStruct[T]()
+
+	return fields
+}
+
+// IndexesFromStruct returns the single field indexes
+// declared with the mongr8 tag of a struct, i.e: `mongr8:"index,unique"`
+func IndexesFromStruct[T any]() []Index {
+	_, indexes := parseStruct[T]()
+
+	return indexes
+}
+
+func parseStruct[T any]() ([]Field, []Index) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("FromStruct: %s must be a struct", t))
+	}
+
+	parser := structParser{
+		visited: map[reflect.Type]bool{},
+	}
+	specs := parser.structFields(t, "")
+
+	fields := make([]Field, len(specs))
+	for i, spec := range specs {
+		fields[i] = spec
+	}
+
+	return fields, parser.indexes
+}
+
+// options declared with the mongr8 tag of a struct field
+type structFieldOptions struct {
+	nullable bool
+	index    *int
+	unique   bool
+	sparse   bool
+	// other options in the declared order
+	constraints [][2]string
+}
+
+type structParser struct {
+	// structs being parsed, to detect a recursive struct
+	visited map[reflect.Type]bool
+	indexes []Index
+}
+
+func (p *structParser) structFields(t reflect.Type, path string) []*field.FieldSpec {
+	if p.visited[t] {
+		panic(fmt.Sprintf("FromStruct: recursive struct %s is not supported", t))
+	}
+
+	p.visited[t] = true
+	defer delete(p.visited, t)
+
+	res := []*field.FieldSpec{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		// an embedded struct is still encoded even if it's unexported
+		if !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		name, inline, skip := parseBsonTag(sf)
+		if skip {
+			continue
+		}
+
+		if inline {
+			inlineType := sf.Type
+			if inlineType.Kind() == reflect.Pointer {
+				inlineType = inlineType.Elem()
+			}
+
+			if inlineType.Kind() != reflect.Struct {
+				panic(fmt.Sprintf("FromStruct: inline field %s.%s must be a struct", t, sf.Name))
+			}
+
+			res = append(res, p.structFields(inlineType, path)...)
+			continue
+		}
+
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		options := parseMongr8Tag(sf.Tag.Get(structTagMongr8))
+		spec := p.typeField(name, sf.Type, fieldPath)
+		// an index is still declared on a field without a fixed schema
+		p.addIndex(fieldPath, options)
+		if spec == nil {
+			continue
+		}
+
+		if options.nullable {
+			spec.SetNullable()
+		}
+
+		applyStructConstraints(spec, options.constraints)
+		res = append(res, spec)
+	}
+
+	return res
+}
+
+// This returns the field of type `t`, or nil if it has no fixed schema,
+// `path` is the path of the field from the document root
+func (p *structParser) typeField(name string, t reflect.Type, path string) *field.FieldSpec {
+	if t.Kind() == reflect.Pointer {
+		res := p.typeField(name, t.Elem(), path)
+		if res == nil {
+			return nil
+		}
+
+		return res.SetNullable()
+	}
+
+	switch t {
+	case typeDocument, typeRaw:
+		return nil
+	case typeTime:
+		return field.TimestampField(name)
+	case typeDateTime:
+		return field.DateField(name)
+	case typeObjectID:
+		return field.ObjectIDField(name)
+	case typeDecimal128:
+		return field.Decimal128Field(name)
+	case typeBinary, typeBytes:
+		return field.BinaryField(name)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return field.StringField(name)
+	// the driver stores an int as an int32 whenever it fits
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return field.Int32Field(name)
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return field.Int64Field(name)
+	case reflect.Float32, reflect.Float64:
+		return field.DoubleField(name)
+	case reflect.Bool:
+		return field.BooleanField(name)
+	case reflect.Slice, reflect.Array:
+		item := p.typeField("", t.Elem(), path)
+		if item == nil {
+			return nil
+		}
+
+		return field.ArrayField(name, item)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			panic(fmt.Sprintf("FromStruct: map key of field %s must be a string", path))
+		}
+
+		value := p.typeField("", t.Elem(), path)
+		if value == nil {
+			return nil
+		}
+
+		return field.MapField(name, value)
+	case reflect.Struct:
+		return field.ObjectField(name, p.structFields(t, path)...)
+	case reflect.Interface:
+		// any value might be stored
+		return nil
+	}
+
+	panic(fmt.Sprintf("FromStruct: type %s of field %s is not supported", t, path))
+}
+
+func (p *structParser) addIndex(path string, options structFieldOptions) {
+	if options.index == nil && !options.unique {
+		return
+	}
+
+	direction := 1
+	if options.index != nil {
+		direction = *options.index
+	}
+
+	idx := index.SingleFieldIndex(index.Field(path, direction))
+	if options.unique {
+		idx.AsUnique()
+	}
+
+	if options.sparse {
+		idx.AsSparse()
+	}
+
+	p.indexes = append(p.indexes, idx)
+}
+
+// This returns the field name declared in the bson tag,
+// the name defaults to the lowercased struct field name as the driver does
+func parseBsonTag(sf reflect.StructField) (name string, inline bool, skip bool) {
+	tag := sf.Tag.Get(structTagBson)
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, part := range parts[1:] {
+		if part == "inline" {
+			inline = true
+		}
+	}
+
+	if name == "" {
+		name = strings.ToLower(sf.Name)
+	}
+
+	return name, inline, false
+}
+
+// This parses the mongr8 tag of a struct field, options are separated by a comma.
+// Since a pattern may contain a comma, it must be the last option
+func parseMongr8Tag(tag string) structFieldOptions {
+	res := structFieldOptions{}
+	for tag = strings.TrimSpace(tag); tag != ""; tag = strings.TrimSpace(tag) {
+		option := tag
+		if strings.HasPrefix(tag, "pattern=") {
+			tag = ""
+		} else if i := strings.Index(tag, ","); i >= 0 {
+			option, tag = tag[:i], tag[i+1:]
+		} else {
+			tag = ""
+		}
+
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "":
+			continue
+		case "nullable":
+			res.nullable = true
+		case "index":
+			direction := 1
+			if value == "-1" {
+				direction = -1
+			} else if value != "" && value != "1" {
+				panic(fmt.Sprintf("FromStruct: index direction must be 1 or -1, got %s", value))
+			}
+
+			res.index = &direction
+		case "unique":
+			res.unique = true
+		case "sparse":
+			res.sparse = true
+		default:
+			res.constraints = append(res.constraints, [2]string{key, value})
+		}
+	}
+
+	return res
+}
+
+func applyStructConstraints(spec *field.FieldSpec, constraints [][2]string) {
+	name := spec.Spec().Name
+	for _, constraint := range constraints {
+		key, value := constraint[0], constraint[1]
+		switch key {
+		case "renamed_from":
+			spec.RenamedFrom(value)
+		case "default":
+			spec.SetDefault(parseTagValue(spec, key, value))
+		case "enum":
+			values := []any{}
+			for _, v := range strings.Split(value, "|") {
+				values = append(values, parseTagValue(spec, key, v))
+			}
+
+			spec.Enum(values...)
+		case "min":
+			spec.Min(parseTagFloat(name, key, value))
+		case "max":
+			spec.Max(parseTagFloat(name, key, value))
+		case "min_length":
+			spec.MinLength(parseTagInt(name, key, value))
+		case "max_length":
+			spec.MaxLength(parseTagInt(name, key, value))
+		case "pattern":
+			spec.Pattern(value)
+		case "min_items":
+			spec.MinItems(parseTagInt(name, key, value))
+		case "max_items":
+			spec.MaxItems(parseTagInt(name, key, value))
+		case "unique_items":
+			spec.UniqueItems()
+		default:
+			panic(fmt.Sprintf("FromStruct: unknown mongr8 tag option %s on field: %s", key, name))
+		}
+	}
+}
+
+// This parses a default or an enum value by the field type,
+// the value is converted to the stored type on the translation
+func parseTagValue(spec *field.FieldSpec, key, value string) any {
+	var (
+		res any
+		err error
+	)
+
+	switch spec.Spec().Type {
+	case field.TypeInt32, field.TypeInt64:
+		res, err = strconv.ParseInt(value, 10, 64)
+	case field.TypeDouble:
+		res, err = strconv.ParseFloat(value, 64)
+	case field.TypeBoolean:
+		res, err = strconv.ParseBool(value)
+	default:
+		res = value
+	}
+
+	if err != nil {
+		panic(fmt.Sprintf("FromStruct: invalid %s value %s on field: %s", key, value, spec.Spec().Name))
+	}
+
+	return res
+}
+
+func parseTagFloat(name, key, value string) float64 {
+	res, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		panic(fmt.Sprintf("FromStruct: %s must be a number on field: %s", key, name))
+	}
+
+	return res
+}
+
+func parseTagInt(name, key, value string) int {
+	res, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("FromStruct: %s must be an integer on field: %s", key, name))
+	}
+
+	return res
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package collection
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/internal/test"
+)
+
+type testAudit struct {
+	CreatedAt time.Time  `bson:"created_at"`
+	DeletedAt *time.Time `bson:"deleted_at"`
+}
+
+type testUser struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Email     string             `bson:"email" mongr8:"index,unique,pattern=^[a-z]+@[a-z]{2,}$"`
+	Age       int                `bson:"age,omitempty" mongr8:"min=0,max=150"`
+	Status    string             `bson:"status" mongr8:"enum=active|inactive,default=active"`
+	Score     float64            `bson:"score"`
+	Verified  bool
+	Tags      []string         `bson:"tags" mongr8:"min_items=1,unique_items"`
+	Labels    map[string]int64 `bson:"labels"`
+	Address   *testAddress     `bson:"address"`
+	Orders    []testOrder      `bson:"orders"`
+	Avatar    []byte           `bson:"avatar"`
+	Extra     interface{}      `bson:"extra" mongr8:"index"`
+	Metadata  map[string]any   `bson:"metadata"`
+	Payload   []any            `bson:"payload"`
+	Note      *any             `bson:"note"`
+	Filter    bson.M           `bson:"filter"`
+	Sort      bson.D           `bson:"sort"`
+	Raw       bson.Raw         `bson:"raw"`
+	Internal  string           `bson:"-"`
+	password  string
+	testAudit `bson:",inline"`
+}
+
+type testAddress struct {
+	City    string `bson:"city" mongr8:"index=-1,sparse"`
+	ZipCode string `bson:"zip_code" mongr8:"renamed_from=zip"`
+}
+
+type testOrder struct {
+	Sku    string               `bson:"sku" mongr8:"index"`
+	Amount primitive.Decimal128 `bson:"amount"`
+}
+
+type testNode struct {
+	Children []testNode `bson:"children"`
+}
+
+func TestFromStruct(t *testing.T) {
+	expected := []Field{
+		field.ObjectIDField("_id"),
+		field.StringField("email").Pattern("^[a-z]+@[a-z]{2,}$"),
+		field.Int32Field("age").Min(0).Max(150),
+		field.StringField("status").Enum("active", "inactive").SetDefault("active"),
+		field.DoubleField("score"),
+		field.BooleanField("verified"),
+		field.ArrayField("tags", field.StringField("")).MinItems(1).UniqueItems(),
+		field.MapField("labels", field.Int64Field("")),
+		field.ObjectField("address",
+			field.StringField("city"),
+			field.StringField("zip_code").RenamedFrom("zip"),
+		).SetNullable(),
+		field.ArrayField("orders",
+			field.ObjectField("",
+				field.StringField("sku"),
+				field.Decimal128Field("amount"),
+			),
+		),
+		field.BinaryField("avatar"),
+		field.TimestampField("created_at"),
+		field.TimestampField("deleted_at").SetNullable(),
+	}
+
+	// Case 1: fields, the ones without a fixed schema are skipped
+	fields := FromStruct[testUser]()
+
+	test.AssertTrue(t, reflect.DeepEqual(SpecsFromFields(fields), SpecsFromFields(expected)), "Case 1: Unexpected fields")
+	test.AssertTrue(t, reflect.DeepEqual(SpecsFromFields(FromStruct[*testUser]()), SpecsFromFields(expected)), "Case 1: Unexpected fields of pointer")
+
+	// Case 2: indexes
+	indexes := IndexesFromStruct[testUser]()
+	expectedIndexes := []Index{
+		index.SingleFieldIndex(index.Field("email", 1)).AsUnique(),
+		index.SingleFieldIndex(index.Field("address.city", -1)).AsSparse(),
+		index.SingleFieldIndex(index.Field("orders.sku", 1)),
+		index.SingleFieldIndex(index.Field("extra", 1)),
+	}
+
+	test.AssertEqual(t, len(indexes), len(expectedIndexes), "Case 2: Unexpected indexes length")
+	for i := range expectedIndexes {
+		test.AssertTrue(t, reflect.DeepEqual(indexes[i].Spec(), expectedIndexes[i].Spec()), "Case 2: Unexpected index")
+	}
+
+	// Case 3: unsupported structs
+	assertPanics := func(fn func(), message string) {
+		defer func() {
+			test.AssertTrue(t, recover() != nil, message)
+		}()
+
+		fn()
+	}
+
+	assertPanics(func() { FromStruct[testNode]() }, "Case 3: Recursive struct must panic")
+	assertPanics(func() { FromStruct[string]() }, "Case 3: Non struct must panic")
+	assertPanics(func() {
+		FromStruct[struct {
+			Count int `mongr8:"min_length=2"`
+		}]()
+	}, "Case 3: Constraint of other type must panic")
+	assertPanics(func() {
+		FromStruct[struct {
+			Count int `mongr8:"maximum=2"`
+		}]()
+	}, "Case 3: Unknown option must panic")
+}
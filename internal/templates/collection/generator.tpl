@@ -62,12 +62,13 @@ package no_edit
 
 import (
 	"github.com/amirkode/go-mongr8/collection"
-	coll "{{ .ModuleName}}/mongr8/collection"
+	{{ range .Imports }}{{ . }}
+	{{ end }}
 )
 
 func GetAllCollections() []collection.Collection {
 	res := []collection.Collection{
-		{{ range .Collections }}coll.{{ . }},
+		{{ range .Collections }}{{ . }},
 		{{ end }}
 	}
 
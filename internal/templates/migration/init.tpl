@@ -186,6 +186,41 @@ func CmdApplyMigration(ctx *context.Context) {
 	}
 }
 
+func CmdRollbackMigration(ctx *context.Context) {
+	migrations := migration_no_edit.GetAllMigrations()
+	migration := migration.NewMigration(ctx, config.Database())
+	err := migration.RollbackMigration(migrations)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
+func CmdMigrationStatus(ctx *context.Context) {
+	migrations := migration_no_edit.GetAllMigrations()
+	migration := migration.NewMigration(ctx, config.Database())
+	err := migration.Status(migrations)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
+func CmdRepairMigration(ctx *context.Context) {
+	migrations := migration_no_edit.GetAllMigrations()
+	migration := migration.NewMigration(ctx, config.Database())
+	err := migration.RepairMigration(migrations)
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
+func CmdForceUnlock(ctx *context.Context) {
+	migration := migration.NewMigration(ctx, config.Database())
+	err := migration.ForceUnlock()
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
 func CmdConsolidateMigration(ctx *context.Context) {
 	collections := collection_no_edit.GetAllCollections()
 	migrationSubActionSchemas := migration_no_edit.GetAllMigrations()
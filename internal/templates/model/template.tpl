@@ -0,0 +1,31 @@
+{{ define "models" }}
+/*
+DOT NOT EDIT, THIS FILE WAS GENERATED BY CODE GEN
+Create date: {{ .CreateDate}}
+Created by: go-mongr8
+
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+
+package model
+
+{{ if or .UseTime .UsePrimitive .UseBson -}}
+import (
+	{{- if .UseTime }}
+	"time"
+	{{- end }}
+	{{- if .UseBson }}
+	"go.mongodb.org/mongo-driver/bson"
+	{{- end }}
+	{{- if .UsePrimitive }}
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	{{- end }}
+)
+{{- end }}
+
+{{ .Models}}
+{{ end }}
@@ -11,6 +11,7 @@ import "github.com/amirkode/go-mongr8/version"
 
 const (
 	MigrationHistoryCollection = "mongr8_migration_history"
+	MigrationLockCollection    = "mongr8_migration_lock"
 )
 
 func Mongr8Version() string {
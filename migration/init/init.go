@@ -97,6 +97,10 @@ func initFolderStructure(projectPath string) error {
 		fmt.Sprintf("%s/cmd/apply", mainDir),
 		fmt.Sprintf("%s/cmd/consolidate", mainDir),
 		fmt.Sprintf("%s/cmd/generate", mainDir),
+		fmt.Sprintf("%s/cmd/rollback", mainDir),
+		fmt.Sprintf("%s/cmd/repair", mainDir),
+		fmt.Sprintf("%s/cmd/status", mainDir),
+		fmt.Sprintf("%s/cmd/unlock", mainDir),
 		fmt.Sprintf("%s/collection/no_edit", mainDir),
 		fmt.Sprintf("%s/migration", mainDir),
 		fmt.Sprintf("%s/config", mainDir),
@@ -200,6 +204,22 @@ func initCmd(projectPath, tplPath string) error {
 			operation: "generate",
 			funcName:  "CmdGenerateMigration",
 		},
+		{
+			operation: "rollback",
+			funcName:  "CmdRollbackMigration",
+		},
+		{
+			operation: "repair",
+			funcName:  "CmdRepairMigration",
+		},
+		{
+			operation: "status",
+			funcName:  "CmdMigrationStatus",
+		},
+		{
+			operation: "unlock",
+			funcName:  "CmdForceUnlock",
+		},
 	}
 	for _, output := range outputs {
 		tplCmdCallVar := struct {
@@ -11,12 +11,19 @@ import (
 	"context"
 	"time"
 
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/migration/migrator"
 	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	"github.com/amirkode/go-mongr8/migration/migrator/consolidate"
 	"github.com/amirkode/go-mongr8/migration/migrator/generate"
-	"github.com/amirkode/go-mongr8/migration/migrator/loader"
+	"github.com/amirkode/go-mongr8/migration/migrator/lock"
+	"github.com/amirkode/go-mongr8/migration/migrator/repair"
+	"github.com/amirkode/go-mongr8/migration/migrator/rollback"
+	"github.com/amirkode/go-mongr8/migration/migrator/status"
 	"github.com/amirkode/go-mongr8/migration/translator"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -26,6 +33,10 @@ type (
 		ApplyMigration(migrations []migrator.Migration) error
 		ConsolidateMigration(collections []collection.Collection, migrations []migrator.Migration) error
 		GenerateMigration(collections []collection.Collection, migrations []migrator.Migration) error
+		RollbackMigration(migrations []migrator.Migration) error
+		Status(migrations []migrator.Migration) error
+		ForceUnlock() error
+		RepairMigration(migrations []migrator.Migration) error
 	}
 
 	Migration struct {
@@ -45,23 +56,46 @@ func NewMigration(ctx *context.Context, db *mongo.Database) Cmd {
 }
 
 func (m *Migration) ApplyMigration(migrations []migrator.Migration) error {
-	dbSchemas := loader.GetSchemaFromDB()
 	processor := translator.NewProcessor(m.ctx)
-	apis := processor.GetApi(migrations, dbSchemas)
+	apis := processor.GetApi(migrations)
 
-	return apply.Run(m.ctx, m.db, apis)
+	return apply.Run(m.ctx, m.db, migrations, apis)
 }
 
 func (m *Migration) ConsolidateMigration(collections []collection.Collection, migrations []migrator.Migration) error {
-	dbSchemas := loader.GetSchemaFromDB()
 	processor := translator.NewProcessor(m.ctx)
-	processor.Consolidate(collections, dbSchemas, migrations)
-	return nil
+
+	return consolidate.Run(m.ctx, m.db, migrations, func(dbSchemas []collection.Collection) dt.Pair[[]si.Action, []si.Action] {
+		return processor.Consolidate(collections, dbSchemas, migrations)
+	})
 }
 
 func (m *Migration) GenerateMigration(collections []collection.Collection, migrations []migrator.Migration) error {
 	processor := translator.NewProcessor(m.ctx)
 	actions := processor.Generate(collections, migrations)
+	err := generate.Run(m.ctx, actions)
+	if err != nil {
+		return err
+	}
+
+	return generate.RunModels(m.ctx, collections)
+}
+
+func (m *Migration) RollbackMigration(migrations []migrator.Migration) error {
+	processor := translator.NewProcessor(m.ctx)
+	apis := processor.GetRollbackApi(migrations)
+
+	return rollback.Run(m.ctx, m.db, migrations, apis)
+}
+
+func (m *Migration) Status(migrations []migrator.Migration) error {
+	return status.Run(m.ctx, m.db, migrations)
+}
+
+func (m *Migration) ForceUnlock() error {
+	return lock.ForceRelease(*m.ctx, m.db)
+}
 
-	return generate.Run(m.ctx, actions)
+func (m *Migration) RepairMigration(migrations []migrator.Migration) error {
+	return repair.Run(m.ctx, m.db, migrations)
 }
@@ -11,59 +11,163 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/lock"
 	"github.com/amirkode/go-mongr8/migration/option"
 	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func execSubActions(ctx context.Context, db *mongo.Database, apis []ai.SubActionApi) error {
-	filteredApis, err := filterSubActionApi(apis, ctx, db)
+// this returns pending migrations with their sub action apis, and reports
+// out of order pending migrations and applied migrations those have been edited
+func getPendingMigrationApis(ctx context.Context, db *mongo.Database, migrations []migrator.Migration, apis []ai.SubActionApi) ([]pendingMigration, error) {
+	histories, err := GetMigrationHistories(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range GetModifiedMigrationIDs(migrations, histories) {
+		log.Printf("Warning: migration %s has been modified after it was applied\n", id)
+	}
+
+	allowOutOfOrder := option.GetMigrationOptionFromContext(&ctx).AllowOutOfOrder
+	pending, err := getPendingMigrations(apis, migrations, histories, allowOutOfOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	_, outOfOrderIDs := GetPendingMigrationIDs(migrations, histories)
+	for _, id := range outOfOrderIDs {
+		log.Printf("Applying out of order migration: %s\n", id)
+	}
+
+	for _, p := range pending {
+		if history, exists := histories[p.Migration.ID]; exists && history.Dirty {
+			log.Printf("Resuming dirty migration %s from the last executed sub action\n", p.Migration.ID)
+		}
+	}
+
+	return pending, nil
+}
+
+func execSubActions(ctx context.Context, db *mongo.Database, migrations []migrator.Migration, apis []ai.SubActionApi) error {
+	pending, err := getPendingMigrationApis(ctx, db, migrations, apis)
 	if err != nil {
 		return err
 	}
 
-	if len(*filteredApis) > 0 {
-		migrations := []migrator.Migration{}
-		added := map[string]bool{}
-		for _, api := range *filteredApis {
-			// execute the action api synchronously
-			err := api.Execute(ctx, db)
+	if len(pending) == 0 {
+		log.Printf("Nothing to migrate.\n")
+		return nil
+	}
+
+	for _, p := range pending {
+		for _, api := range p.Apis {
+			// execute the action api synchronously, unless the run is aborted
+			err := context.Cause(ctx)
+			if err == nil {
+				err = api.Execute(ctx, db)
+			}
+
 			if err != nil {
-				return err
+				// i.e: the migration lock is lost while executing
+				if ctx.Err() != nil {
+					err = context.Cause(ctx)
+				}
+
+				// executed sub actions are kept without transaction
+				if mongo.SessionFromContext(ctx) == nil {
+					log.Printf("Migration %s is left dirty, re-run to resume or use repair command\n", p.Migration.ID)
+				}
+
+				return fmt.Errorf("error applying migration %s at sub action %s (%s): %v",
+					p.Migration.ID, getProgressKey(api), api.SubAction.Type.ToString(), err)
 			}
 
-			_, exists := added[api.Migration.ID]
-			if !exists {
-				migrations = append(migrations, api.Migration)
-				added[api.Migration.ID] = true
+			// record the progress, so a re-run resumes from the next sub action
+			err = recordProgress(p.Migration, getProgressKey(api), ctx, db)
+			if err != nil {
+				return err
 			}
 		}
 
 		// update migration history
-		err = updateMigrationHistory(migrations, ctx, db)
+		err = MarkMigrationClean(p.Migration, time.Now(), ctx, db)
 		if err != nil {
 			return err
 		}
+	}
+
+	log.Printf("All Migration files has been migrated with IDs: %s..%s\n",
+		pending[0].Migration.ID,
+		pending[len(pending)-1].Migration.ID,
+	)
 
-		log.Printf("All Migration files has been migrated with IDs: %s..%s\n",
-			(*filteredApis)[0].Migration.ID,
-			(*filteredApis)[len(*filteredApis)-1].Migration.ID,
-		)
-	} else {
+	return nil
+}
+
+// this prints planned commands of each sub action without executing them
+func simulateSubActions(ctx context.Context, db *mongo.Database, migrations []migrator.Migration, apis []ai.SubActionApi) error {
+	pending, err := getPendingMigrationApis(ctx, db, migrations, apis)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
 		log.Printf("Nothing to migrate.\n")
+		return nil
 	}
 
+	for _, p := range pending {
+		fmt.Printf("// Migration %s: %s\n", p.Migration.ID, p.Migration.Desc)
+		for _, api := range p.Apis {
+			fmt.Printf("// %s\n", api.SubAction.Type.ToString())
+			commands, err := api.Simulate()
+			if err != nil {
+				return fmt.Errorf("migration %s: %s", p.Migration.ID, err.Error())
+			}
+
+			for _, command := range commands {
+				fmt.Println(command)
+			}
+		}
+	}
+
+	log.Printf("Dry run: nothing has been executed for migration IDs: %s..%s\n",
+		pending[0].Migration.ID,
+		pending[len(pending)-1].Migration.ID,
+	)
+
 	return nil
 }
 
-func Run(ctx *context.Context, db *mongo.Database, apis []ai.SubActionApi) error {
+func Run(ctx *context.Context, db *mongo.Database, migrations []migrator.Migration, apis []ai.SubActionApi) error {
+	if option.GetMigrationOptionFromContext(ctx).DryRun {
+		return simulateSubActions(*ctx, db, migrations, apis)
+	}
+
+	// prevent concurrent migration runs
+	migrationLock, err := lock.Acquire(*ctx, db, option.GetMigrationOptionFromContext(ctx).LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rErr := migrationLock.Release(*ctx); rErr != nil {
+			log.Printf("Error releasing the migration lock: %s\n", rErr.Error())
+		}
+	}()
+
+	// sub actions are aborted once the lock is lost, so another process cannot run concurrently
+	runCtx, cancel := migrationLock.WithContext(*ctx)
+	defer cancel()
+
 	useTransaction := option.GetMigrationOptionFromContext(ctx).UseTransaction
 	if !useTransaction {
 		// executes everything with individually
-		err := execSubActions(*ctx, db, apis)
+		err := execSubActions(runCtx, db, migrations, apis)
 		if err != nil {
 			return err
 		}
@@ -81,13 +185,13 @@ func Run(ctx *context.Context, db *mongo.Database, apis []ai.SubActionApi) error
 	defer session.EndSession(*ctx)
 
 	// bind everything in a transaction
-	return mongo.WithSession(*ctx, session, func(sc mongo.SessionContext) error {
+	return mongo.WithSession(runCtx, session, func(sc mongo.SessionContext) error {
 		if err := sc.StartTransaction(); err != nil {
 			return err
 		}
 
 		// execute sub actions
-		err := execSubActions(sc, db, apis)
+		err := execSubActions(sc, db, migrations, apis)
 		if err != nil {
 			// rollback
 			if rErr := sc.AbortTransaction(*ctx); rErr != nil {
@@ -96,6 +200,6 @@ func Run(ctx *context.Context, db *mongo.Database, apis []ai.SubActionApi) error
 			return err
 		}
 
-		return nil
+		return sc.CommitTransaction(*ctx)
 	})
 }
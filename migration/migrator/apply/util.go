@@ -9,26 +9,53 @@ package apply
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/amirkode/go-mongr8/internal/constant"
-
 	"github.com/amirkode/go-mongr8/migration/common"
 	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/option"
 	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type MigrationHistory struct {
-	MigrationID string    `bson:"_id"`
-	Desc        string    `bson:"desc"`
-	MigratedAt  time.Time `bson:"migrated_at"`
+type (
+	MigrationHistory struct {
+		MigrationID string    `bson:"_id"`
+		Desc        string    `bson:"desc"`
+		MigratedAt  time.Time `bson:"migrated_at"`
+		// empty for migrations applied before checksum was introduced
+		Checksum string `bson:"checksum,omitempty"`
+		// a dirty migration has been partially applied,
+		// `Progress` holds the keys of its executed sub actions
+		Dirty    bool     `bson:"dirty,omitempty"`
+		Progress []string `bson:"progress,omitempty"`
+	}
+
+	pendingMigration struct {
+		Migration migrator.Migration
+		// sub action apis yet to execute
+		Apis []ai.SubActionApi
+	}
+)
+
+func NewMigrationHistory(migration migrator.Migration, migratedAt time.Time) MigrationHistory {
+	return MigrationHistory{
+		MigrationID: migration.ID,
+		Desc:        migration.Desc,
+		MigratedAt:  migratedAt,
+		Checksum:    migration.Checksum(),
+	}
 }
 
-func getLatestMigrationID(ctx context.Context, db *mongo.Database) (*string, error) {
-	res := constant.MinTimevalue().Format("20060102_150405")
+// this returns all recorded migration histories mapped by the migration ID
+func GetMigrationHistories(ctx context.Context, db *mongo.Database) (map[string]MigrationHistory, error) {
+	res := map[string]MigrationHistory{}
 	coll := db.Collection(common.MigrationHistoryCollection)
 	cursor, err := coll.Find(ctx, bson.M{})
 	if err != nil {
@@ -42,43 +69,155 @@ func getLatestMigrationID(ctx context.Context, db *mongo.Database) (*string, err
 			return nil, err
 		}
 
-		if history.MigrationID > res {
-			res = history.MigrationID
-		}
+		res[history.MigrationID] = history
 	}
 
-	return &res, nil
+	return res, nil
 }
 
-func updateMigrationHistory(migrations []migrator.Migration, ctx context.Context, db *mongo.Database) error {
-	migratedAt := time.Now()
-	payload := []interface{}{}
-	for _, m := range migrations {
-		payload = append(payload, MigrationHistory{
-			MigrationID: m.ID,
-			Desc:        m.Desc,
-			MigratedAt:  migratedAt,
-		})
+// this returns the key identifying a sub action in its migration
+func getProgressKey(api ai.SubActionApi) string {
+	return fmt.Sprintf("%s.%d", api.ActionKey, api.SubActionIndex)
+}
+
+// this returns keys of executed sub actions of a dirty migration
+func getExecutedProgress(history MigrationHistory, exists bool) map[string]bool {
+	res := map[string]bool{}
+	if !exists || !history.Dirty {
+		return res
+	}
+
+	for _, key := range history.Progress {
+		res[key] = true
 	}
 
+	return res
+}
+
+// this records an executed sub action, the migration stays dirty until all sub actions are executed
+func recordProgress(migration migrator.Migration, key string, ctx context.Context, db *mongo.Database) error {
 	coll := db.Collection(common.MigrationHistoryCollection)
-	_, err := coll.InsertMany(ctx, payload)
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"_id": migration.ID},
+		bson.M{
+			"$set": bson.M{
+				"desc":     migration.Desc,
+				"checksum": migration.Checksum(),
+				"dirty":    true,
+			},
+			"$addToSet": bson.M{"progress": key},
+		},
+		options.Update().SetUpsert(true),
+	)
 
 	return err
 }
 
-func filterSubActionApi(apis []ai.SubActionApi, ctx context.Context, db *mongo.Database) (*[]ai.SubActionApi, error) {
-	res := []ai.SubActionApi{}
-	latestMigrationID, err := getLatestMigrationID(ctx, db)
-	if err != nil {
-		return nil, err
+// this marks a migration as completely applied
+func MarkMigrationClean(migration migrator.Migration, migratedAt time.Time, ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(common.MigrationHistoryCollection)
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"_id": migration.ID},
+		bson.M{
+			"$set": bson.M{
+				"desc":        migration.Desc,
+				"checksum":    migration.Checksum(),
+				"migrated_at": migratedAt,
+			},
+			"$unset": bson.M{"dirty": "", "progress": ""},
+		},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+// this returns IDs of the migrations those are not recorded in the history or still dirty,
+// and IDs of pending migrations older than the latest applied migration (out of order)
+func GetPendingMigrationIDs(migrations []migrator.Migration, histories map[string]MigrationHistory) ([]string, []string) {
+	latestAppliedID := ""
+	for id, history := range histories {
+		if !history.Dirty && id > latestAppliedID {
+			latestAppliedID = id
+		}
 	}
 
+	pendingIDs := []string{}
+	outOfOrderIDs := []string{}
+	for _, m := range migrations {
+		// a dirty migration is still pending
+		if history, exists := histories[m.ID]; exists && !history.Dirty {
+			continue
+		}
+
+		pendingIDs = append(pendingIDs, m.ID)
+		if m.ID < latestAppliedID {
+			outOfOrderIDs = append(outOfOrderIDs, m.ID)
+		}
+	}
+
+	sort.Strings(pendingIDs)
+	sort.Strings(outOfOrderIDs)
+
+	return pendingIDs, outOfOrderIDs
+}
+
+// this returns IDs of applied migrations whose content has been changed since applied
+func GetModifiedMigrationIDs(migrations []migrator.Migration, histories map[string]MigrationHistory) []string {
+	res := []string{}
+	for _, m := range migrations {
+		history, applied := histories[m.ID]
+		if !applied || history.Checksum == "" {
+			continue
+		}
+
+		if history.Checksum != m.Checksum() {
+			res = append(res, m.ID)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// this returns pending migrations with their sub action apis yet to execute,
+// executed sub actions of a dirty migration are excluded, so the migration is resumed.
+// pending migrations older than the latest applied migration are rejected unless `allowOutOfOrder` is set
+func getPendingMigrations(apis []ai.SubActionApi, migrations []migrator.Migration, histories map[string]MigrationHistory, allowOutOfOrder bool) ([]pendingMigration, error) {
+	pendingIDs, outOfOrderIDs := GetPendingMigrationIDs(migrations, histories)
+	if len(outOfOrderIDs) > 0 && !allowOutOfOrder {
+		return nil, fmt.Errorf("there are pending migrations older than the latest applied migration: %s, use --%s to apply them",
+			strings.Join(outOfOrderIDs, ", "), option.MigrationOptionArgAllowOutOfOrder)
+	}
+
+	migrationByID := map[string]migrator.Migration{}
+	for _, m := range migrations {
+		migrationByID[m.ID] = m
+	}
+
+	apisByID := map[string][]ai.SubActionApi{}
 	for _, api := range apis {
-		if api.Migration.ID > *latestMigrationID {
-			res = append(res, api)
+		apisByID[api.Migration.ID] = append(apisByID[api.Migration.ID], api)
+	}
+
+	res := []pendingMigration{}
+	for _, id := range pendingIDs {
+		history, exists := histories[id]
+		executed := getExecutedProgress(history, exists)
+		curr := pendingMigration{
+			Migration: migrationByID[id],
+			Apis:      []ai.SubActionApi{},
 		}
+
+		for _, api := range apisByID[id] {
+			if !executed[getProgressKey(api)] {
+				curr.Apis = append(curr.Apis, api)
+			}
+		}
+
+		res = append(res, curr)
 	}
 
-	return &res, nil
+	return res, nil
 }
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package apply
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func getTestMigration(id, collName string) migrator.Migration {
+	return migrator.Migration{
+		ID:   id,
+		Desc: "create " + collName,
+		Up: []si.Action{
+			{
+				ActionKey: collName,
+				SubActions: []si.SubAction{
+					*si.SubActionCreateCollection(si.SubActionSchema{
+						Collection: metadata.InitMetadata(collName),
+					}),
+				},
+			},
+		},
+	}
+}
+
+func TestGetPendingMigrationIDs(t *testing.T) {
+	migrations := []migrator.Migration{
+		getTestMigration("20240101_000000", "users"),
+		getTestMigration("20240102_000000", "orders"),
+		getTestMigration("20240103_000000", "products"),
+		getTestMigration("20240104_000000", "logs"),
+	}
+
+	Convey("Case 1: Nothing applied", t, func() {
+		pendingIDs, outOfOrderIDs := GetPendingMigrationIDs(migrations, map[string]MigrationHistory{})
+		So(len(pendingIDs), ShouldEqual, 4)
+		So(len(outOfOrderIDs), ShouldEqual, 0)
+	})
+
+	Convey("Case 2: Older migration was merged later", t, func() {
+		histories := map[string]MigrationHistory{
+			"20240101_000000": NewMigrationHistory(migrations[0], time.Now()),
+			"20240103_000000": NewMigrationHistory(migrations[2], time.Now()),
+		}
+		pendingIDs, outOfOrderIDs := GetPendingMigrationIDs(migrations, histories)
+		So(pendingIDs, ShouldResemble, []string{"20240102_000000", "20240104_000000"})
+		So(outOfOrderIDs, ShouldResemble, []string{"20240102_000000"})
+	})
+}
+
+func TestGetModifiedMigrationIDs(t *testing.T) {
+	migrations := []migrator.Migration{
+		getTestMigration("20240101_000000", "users"),
+		getTestMigration("20240102_000000", "orders"),
+		getTestMigration("20240103_000000", "products"),
+	}
+	histories := map[string]MigrationHistory{
+		"20240101_000000": NewMigrationHistory(migrations[0], time.Now()),
+		// edited after applied
+		"20240102_000000": NewMigrationHistory(getTestMigration("20240102_000000", "order"), time.Now()),
+		// applied before checksum was introduced
+		"20240103_000000": {MigrationID: "20240103_000000"},
+	}
+
+	Convey("Only the edited migration is reported", t, func() {
+		So(GetModifiedMigrationIDs(migrations, histories), ShouldResemble, []string{"20240102_000000"})
+	})
+
+	Convey("Checksum is deterministic", t, func() {
+		So(migrations[0].Checksum(), ShouldEqual, getTestMigration("20240101_000000", "users").Checksum())
+		So(migrations[0].Checksum(), ShouldNotEqual, migrations[1].Checksum())
+	})
+
+	Convey("Checksum depends on the schema, not on the declaration", t, func() {
+		withFields := func(fields ...collection.Field) migrator.Migration {
+			m := getTestMigration("20240101_000000", "users")
+			m.Up[0].SubActions[0].ActionSchema.Fields = fields
+			m.Up[0].SubActions[0].ActionSchema.Collection = metadata.InitMetadata("users").SetCollation(map[string]interface{}{
+				"locale":   "en",
+				"strength": 2,
+			})
+
+			return m
+		}
+
+		checksum := withFields(field.Int64Field("count").SetDefault(1).SetNullable()).Checksum()
+		So(withFields(field.Int64Field("count").SetNullable().SetDefault(1)).Checksum(), ShouldEqual, checksum)
+		So(withFields(field.Int64Field("count").SetNullable().SetDefault(2)).Checksum(), ShouldNotEqual, checksum)
+		So(withFields(field.Int64Field("count").SetDefault(1)).Checksum(), ShouldNotEqual, checksum)
+	})
+}
+
+func TestGetPendingMigrations(t *testing.T) {
+	migrations := []migrator.Migration{
+		getTestMigration("20240101_000000", "users"),
+		getTestMigration("20240102_000000", "orders"),
+		getTestMigration("20240103_000000", "products"),
+	}
+	apis := []ai.SubActionApi{}
+	for _, m := range migrations {
+		for idx, subAction := range m.Up[0].SubActions {
+			apis = append(apis, ai.SubActionApi{
+				Migration:      m,
+				SubAction:      subAction,
+				ActionKey:      m.Up[0].ActionKey,
+				SubActionIndex: idx,
+			})
+		}
+	}
+	histories := map[string]MigrationHistory{
+		"20240101_000000": NewMigrationHistory(migrations[0], time.Now()),
+		"20240103_000000": NewMigrationHistory(migrations[2], time.Now()),
+	}
+
+	Convey("Out of order migration is rejected by default", t, func() {
+		_, err := getPendingMigrations(apis, migrations, histories, false)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Out of order migration is applied when allowed", t, func() {
+		res, err := getPendingMigrations(apis, migrations, histories, true)
+		So(err, ShouldBeNil)
+		So(len(res), ShouldEqual, 1)
+		So(res[0].Migration.ID, ShouldEqual, "20240102_000000")
+		So(len(res[0].Apis), ShouldEqual, 1)
+	})
+}
+
+func TestGetPendingMigrationsResume(t *testing.T) {
+	migration := getTestMigration("20240101_000000", "users")
+	migration.Up[0].SubActions = append(migration.Up[0].SubActions,
+		*si.SubActionCreateIndex(si.SubActionSchema{Collection: metadata.InitMetadata("users")}),
+		*si.SubActionCreateIndex(si.SubActionSchema{Collection: metadata.InitMetadata("users")}),
+	)
+	migrations := []migrator.Migration{migration}
+	apis := []ai.SubActionApi{}
+	for idx, subAction := range migration.Up[0].SubActions {
+		apis = append(apis, ai.SubActionApi{
+			Migration:      migration,
+			SubAction:      subAction,
+			ActionKey:      "users",
+			SubActionIndex: idx,
+		})
+	}
+
+	Convey("Dirty migration is resumed from the failing sub action", t, func() {
+		histories := map[string]MigrationHistory{
+			"20240101_000000": {
+				MigrationID: "20240101_000000",
+				Dirty:       true,
+				Progress:    []string{"users.0", "users.1"},
+			},
+		}
+
+		pendingIDs, _ := GetPendingMigrationIDs(migrations, histories)
+		So(pendingIDs, ShouldResemble, []string{"20240101_000000"})
+
+		res, err := getPendingMigrations(apis, migrations, histories, false)
+		So(err, ShouldBeNil)
+		So(len(res), ShouldEqual, 1)
+		So(len(res[0].Apis), ShouldEqual, 1)
+		So(getProgressKey(res[0].Apis[0]), ShouldEqual, "users.2")
+	})
+
+	Convey("Dirty migration with every sub action executed is still pending", t, func() {
+		histories := map[string]MigrationHistory{
+			"20240101_000000": {
+				MigrationID: "20240101_000000",
+				Dirty:       true,
+				Progress:    []string{"users.0", "users.1", "users.2"},
+			},
+		}
+
+		res, err := getPendingMigrations(apis, migrations, histories, false)
+		So(err, ShouldBeNil)
+		So(len(res), ShouldEqual, 1)
+		So(len(res[0].Apis), ShouldEqual, 0)
+	})
+}
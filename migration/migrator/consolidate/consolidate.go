@@ -6,3 +6,99 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package consolidate
+
+import (
+	"context"
+	"log"
+	"time"
+
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/loader"
+	"github.com/amirkode/go-mongr8/migration/migrator/lock"
+	"github.com/amirkode/go-mongr8/migration/migrator/writer"
+	"github.com/amirkode/go-mongr8/migration/option"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const defaultDesc = "consolidation with current database schema"
+
+// This validates whether the migrations are eligible to be consolidated
+func Validate(ctx *context.Context, db *mongo.Database, migrations []migrator.Migration) error {
+	appliedIDs, err := getAppliedMigrationIDs(*ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return checkPendingMigrations(migrations, appliedIDs)
+}
+
+// This generates a migration file of the changes in the database, and marks it as applied.
+// `getActions` returns the corrective actions from the database schema.
+// The migration lock is held from reading the database schema until the migration is recorded,
+// so a concurrent apply cannot change the schema in between
+func Run(ctx *context.Context, db *mongo.Database, migrations []migrator.Migration, getActions func(dbSchemas []collection.Collection) dt.Pair[[]si.Action, []si.Action]) error {
+	migrationLock, err := lock.Acquire(*ctx, db, option.GetMigrationOptionFromContext(ctx).LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rErr := migrationLock.Release(*ctx); rErr != nil {
+			log.Printf("Error releasing the migration lock: %s\n", rErr.Error())
+		}
+	}()
+
+	// the consolidation is aborted once the lock is lost
+	runCtx, cancel := migrationLock.WithContext(*ctx)
+	defer cancel()
+
+	err = Validate(&runCtx, db, migrations)
+	if err != nil {
+		return err
+	}
+
+	dbSchemas, err := loader.GetSchemaFromDB(runCtx, db)
+	if err != nil {
+		return err
+	}
+
+	actions := getActions(dbSchemas)
+	if len(actions.First) == 0 {
+		log.Println("Migration files are already consistent with the database")
+		return nil
+	}
+
+	desc := option.GetMigrationOptionFromContext(ctx).Desc
+	if desc == "" {
+		desc = defaultDesc
+	}
+
+	migration := migrator.Migration{
+		ID:   time.Now().Format("20060102_150405"),
+		Desc: desc,
+		Up:   actions.First,
+		Down: actions.Second,
+	}
+
+	if err := context.Cause(runCtx); err != nil {
+		return err
+	}
+
+	err = writer.Write(migration)
+	if err != nil {
+		return err
+	}
+
+	err = insertMigrationHistory(migration, runCtx, db)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("A consolidation migration file has been generated and marked as applied with ID: %s\n", migration.ID)
+
+	return nil
+}
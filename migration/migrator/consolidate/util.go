@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package consolidate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/common"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func getAppliedMigrationIDs(ctx context.Context, db *mongo.Database) (map[string]bool, error) {
+	res := map[string]bool{}
+	coll := db.Collection(common.MigrationHistoryCollection)
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	for cursor.Next(ctx) {
+		var history apply.MigrationHistory
+		err = cursor.Decode(&history)
+		if err != nil {
+			return nil, err
+		}
+
+		// a dirty migration is not completely applied
+		res[history.MigrationID] = !history.Dirty
+	}
+
+	return res, nil
+}
+
+// this makes sure every migration has been applied,
+// otherwise the consolidation would revert unapplied migrations
+func checkPendingMigrations(migrations []migrator.Migration, appliedIDs map[string]bool) error {
+	pendingIDs := []string{}
+	for _, m := range migrations {
+		if !appliedIDs[m.ID] {
+			pendingIDs = append(pendingIDs, m.ID)
+		}
+	}
+
+	if len(pendingIDs) > 0 {
+		return fmt.Errorf("there are unapplied migrations: %s, please apply them before consolidation", strings.Join(pendingIDs, ", "))
+	}
+
+	return nil
+}
+
+// the corrective migration reflects current database state,
+// so it's marked as applied without any execution
+func insertMigrationHistory(migration migrator.Migration, ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(common.MigrationHistoryCollection)
+	_, err := coll.InsertOne(ctx, apply.NewMigrationHistory(migration, time.Now()))
+
+	return err
+}
@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package consolidate
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckPendingMigrations(t *testing.T) {
+	migrations := []migrator.Migration{
+		{ID: "20240101_000000"},
+		{ID: "20240102_000000"},
+	}
+
+	Convey("Case 1: All migrations are applied", t, func() {
+		err := checkPendingMigrations(migrations, map[string]bool{
+			"20240101_000000": true,
+			"20240102_000000": true,
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Case 2: Some migrations are not applied", t, func() {
+		err := checkPendingMigrations(migrations, map[string]bool{
+			"20240101_000000": true,
+		})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "20240102_000000")
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/amirkode/go-mongr8/collection"
 	dt "github.com/amirkode/go-mongr8/internal/data_type"
 
 	"github.com/amirkode/go-mongr8/migration/migrator"
@@ -42,3 +43,17 @@ func Run(ctx *context.Context, actions dt.Pair[[]si.Action, []si.Action]) error
 
 	return err
 }
+
+// This regenerates the Go models of the collections, if it's enabled
+func RunModels(ctx *context.Context, collections []collection.Collection) error {
+	if !option.GetMigrationOptionFromContext(ctx).UseModelGeneration {
+		return nil
+	}
+
+	err := writer.WriteModels(collections)
+	if err == nil {
+		log.Println("Go models have been generated")
+	}
+
+	return err
+}
@@ -8,11 +8,111 @@ Licensed under the MIT License
 package loader
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/migration/common"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func GetSchemaFromDB() []collection.Collection {
-	// TODO: implement
-	// this should call a function from migration/translator/mongodb/schema_interpreter
-	return []collection.Collection{}
+// number of documents sampled from each collection to infer the fields
+const SampleSize = 100
+
+func getCollectionIndexes(ctx context.Context, coll *mongo.Collection) ([]collection.Index, error) {
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	res := []collection.Index{}
+	for cursor.Next(ctx) {
+		var spec bson.D
+		if err := cursor.Decode(&spec); err != nil {
+			return nil, err
+		}
+
+		if curr := si.GetIndexFromSpecification(spec); curr != nil {
+			res = append(res, curr)
+		}
+	}
+
+	return res, cursor.Err()
+}
+
+func getCollectionFields(ctx context.Context, coll *mongo.Collection) ([]collection.Field, error) {
+	pipeline := bson.A{
+		bson.D{{Key: "$sample", Value: bson.D{{Key: "size", Value: SampleSize}}}},
+	}
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	docs := []bson.D{}
+	for cursor.Next(ctx) {
+		var doc bson.D
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return si.GetFieldsFromDocuments(docs), nil
+}
+
+// This reconstructs the current schema of all user collections in the database
+// fields are inferred from sampled documents, so it might not be complete
+func GetSchemaFromDB(ctx context.Context, db *mongo.Database) ([]collection.Collection, error) {
+	specs, err := db.ListCollectionSpecifications(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing collections: %s", err.Error())
+	}
+
+	res := []collection.Collection{}
+	for _, spec := range specs {
+		if spec.Name == common.MigrationHistoryCollection || spec.Name == common.MigrationLockCollection ||
+			si.IsInternalCollection(spec.Name) {
+			continue
+		}
+
+		var options bson.D
+		if len(spec.Options) > 0 {
+			if err := bson.Unmarshal(spec.Options, &options); err != nil {
+				return nil, fmt.Errorf("error reading options of collection %s: %s", spec.Name, err.Error())
+			}
+		}
+
+		meta := si.GetMetadataFromOptions(spec.Name, spec.Type, options)
+		// views have neither indexes nor own documents
+		if spec.Type == "view" {
+			res = append(res, collection.NewCollection(meta, []collection.Field{}, []collection.Index{}))
+			continue
+		}
+
+		coll := db.Collection(spec.Name)
+		indexes, err := getCollectionIndexes(ctx, coll)
+		if err != nil {
+			return nil, fmt.Errorf("error listing indexes of collection %s: %s", spec.Name, err.Error())
+		}
+
+		fields, err := getCollectionFields(ctx, coll)
+		if err != nil {
+			return nil, fmt.Errorf("error sampling documents of collection %s: %s", spec.Name, err.Error())
+		}
+
+		res = append(res, collection.NewCollection(meta, fields, indexes))
+	}
+
+	return res, nil
 }
@@ -0,0 +1,265 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/common"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+/*
+This contains a distributed lock, so concurrent migration runs
+(i.e: several replicas applying migration on startup) cannot collide.
+
+The lock is a single document in `mongr8_migration_lock`:
+- It's acquired by upserting the document, only if it's expired or owned by the same owner.
+  A held lock makes the upsert fail with a duplicate key error.
+- The owner keeps extending the expiry with heartbeats while holding the lock.
+  So, a lock of a crashed owner is released automatically after it's expired.
+  If the expiry cannot be extended, the lock is lost and the run must abort, @see Lock.WithContext.
+- The lock is released by deleting the document.
+*/
+
+const (
+	lockID = "migration"
+	// a lock without any heartbeat within this duration is considered expired
+	DefaultTTL = 30 * time.Second
+	// interval of retrying to acquire the lock while waiting
+	retryInterval = 1 * time.Second
+)
+
+// ErrLockLost is the cancellation cause of the context of a lost lock
+var ErrLockLost = errors.New("the migration lock has been lost, another process might be running migrations")
+
+type (
+	lockDocument struct {
+		ID          string    `bson:"_id"`
+		Owner       string    `bson:"owner"`
+		AcquiredAt  time.Time `bson:"acquired_at"`
+		HeartbeatAt time.Time `bson:"heartbeat_at"`
+		ExpiresAt   time.Time `bson:"expires_at"`
+	}
+
+	Lock struct {
+		db    *mongo.Database
+		owner string
+		ttl   time.Duration
+		stop  chan struct{}
+		wg    sync.WaitGroup
+		// this extends the lock expiry, it returns false if the lock is no longer owned
+		extend   func(ctx context.Context, now time.Time) (bool, error)
+		lost     chan struct{}
+		lostOnce sync.Once
+	}
+)
+
+func getCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection(common.MigrationLockCollection)
+}
+
+// this tries to acquire the lock once,
+// it returns false without any error if the lock is held by another owner
+func tryAcquire(ctx context.Context, db *mongo.Database, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	_, err := getCollection(db).UpdateOne(ctx,
+		getAcquireFilter(owner, now),
+		getAcquireUpdate(owner, now, ttl),
+		upsertOptions(),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func getCurrentOwner(ctx context.Context, db *mongo.Database) (*lockDocument, error) {
+	var doc lockDocument
+	err := getCollection(db).FindOne(ctx, bson.M{"_id": lockID}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// Acquire acquires the migration lock,
+// it waits until the lock is released or `timeout` is exceeded
+// a zero `timeout` fails immediately if the lock is held by another owner
+func Acquire(ctx context.Context, db *mongo.Database, timeout time.Duration) (*Lock, error) {
+	owner, err := newOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		acquired, err := tryAcquire(ctx, db, owner, DefaultTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		if acquired {
+			break
+		}
+
+		if !time.Now().Before(deadline) {
+			msg := "migration lock is held by another process"
+			if current, err := getCurrentOwner(ctx, db); err == nil && current != nil {
+				msg = fmt.Sprintf("migration lock is held by %s until %s", current.Owner, current.ExpiresAt.Format(time.RFC3339))
+			}
+
+			return nil, fmt.Errorf("%s, use force-unlock if the owner is no longer running", msg)
+		}
+
+		log.Println("Waiting for the migration lock to be released")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+
+	lock := newLock(db, owner, DefaultTTL)
+	lock.extend = lock.extendExpiry
+	lock.wg.Add(1)
+	go lock.heartbeat(ctx, time.Now())
+
+	return lock, nil
+}
+
+func newLock(db *mongo.Database, owner string, ttl time.Duration) *Lock {
+	return &Lock{
+		db:    db,
+		owner: owner,
+		ttl:   ttl,
+		stop:  make(chan struct{}),
+		lost:  make(chan struct{}),
+	}
+}
+
+func (l *Lock) extendExpiry(ctx context.Context, now time.Time) (bool, error) {
+	res, err := getCollection(l.db).UpdateOne(ctx,
+		bson.M{"_id": lockID, "owner": l.owner},
+		bson.M{"$set": bson.M{"heartbeat_at": now, "expires_at": now.Add(l.ttl)}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// this extends the lock expiry periodically until the lock is released or lost,
+// `extendedAt` is the time of the latest extension
+func (l *Lock) heartbeat(ctx context.Context, extendedAt time.Time) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			owned, err := l.extend(ctx, now)
+			if err != nil {
+				log.Printf("Error extending the migration lock: %s\n", err.Error())
+				// the lock is free to acquire by another process once it's expired
+				if now.Sub(extendedAt) < l.ttl {
+					continue
+				}
+			} else if owned {
+				extendedAt = now
+				continue
+			}
+
+			log.Println("Error: the migration lock has been lost")
+			l.lostOnce.Do(func() {
+				close(l.lost)
+			})
+
+			return
+		}
+	}
+}
+
+// Lost returns a channel closed once the lock is lost
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// WithContext returns a context derived from `parent`, it's cancelled with ErrLockLost once the lock is lost.
+// So, the run holding the lock aborts instead of racing with another process
+func (l *Lock) WithContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	go func() {
+		select {
+		case <-l.lost:
+			cancel(ErrLockLost)
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		cancel(context.Canceled)
+	}
+}
+
+// Release stops the heartbeat and releases the lock
+func (l *Lock) Release(ctx context.Context) error {
+	close(l.stop)
+	l.wg.Wait()
+
+	_, err := getCollection(l.db).DeleteOne(ctx, bson.M{"_id": lockID, "owner": l.owner})
+
+	return err
+}
+
+// ForceRelease releases the lock regardless of its owner,
+// it's intended for a lock left by a crashed process
+func ForceRelease(ctx context.Context, db *mongo.Database) error {
+	current, err := getCurrentOwner(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		log.Println("Migration lock is not held.")
+		return nil
+	}
+
+	_, err = getCollection(db).DeleteOne(ctx, bson.M{"_id": lockID})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Migration lock held by %s has been released\n", current.Owner)
+
+	return nil
+}
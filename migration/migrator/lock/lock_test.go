@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// this starts the heartbeat of a lock with a fake expiry extension
+func startTestHeartbeat(ttl time.Duration, extend func(ctx context.Context, now time.Time) (bool, error)) *Lock {
+	lock := newLock(nil, "owner-1", ttl)
+	lock.extend = extend
+	lock.wg.Add(1)
+	go lock.heartbeat(context.Background(), time.Now())
+
+	return lock
+}
+
+func waitDone(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+	}
+}
+
+func TestLockHeartbeat(t *testing.T) {
+	ttl := 30 * time.Millisecond
+
+	Convey("A lock taken over by another owner is lost", t, func() {
+		lock := startTestHeartbeat(ttl, func(ctx context.Context, now time.Time) (bool, error) {
+			return false, nil
+		})
+		ctx, cancel := lock.WithContext(context.Background())
+		defer cancel()
+
+		waitDone(ctx)
+		So(context.Cause(ctx), ShouldEqual, ErrLockLost)
+		// the heartbeat stops after the lock is lost
+		lock.wg.Wait()
+	})
+
+	Convey("A lock failing to be extended is lost once it's expired", t, func() {
+		startedAt := time.Now()
+		lock := startTestHeartbeat(ttl, func(ctx context.Context, now time.Time) (bool, error) {
+			return false, errors.New("connection refused")
+		})
+		ctx, cancel := lock.WithContext(context.Background())
+		defer cancel()
+
+		waitDone(ctx)
+		So(context.Cause(ctx), ShouldEqual, ErrLockLost)
+		So(time.Since(startedAt), ShouldBeGreaterThanOrEqualTo, ttl)
+	})
+
+	Convey("A lock extended successfully is kept until it's released", t, func() {
+		lock := startTestHeartbeat(ttl, func(ctx context.Context, now time.Time) (bool, error) {
+			return true, nil
+		})
+		ctx, cancel := lock.WithContext(context.Background())
+
+		time.Sleep(3 * ttl)
+		So(ctx.Err(), ShouldBeNil)
+
+		close(lock.stop)
+		lock.wg.Wait()
+		cancel()
+		So(context.Cause(ctx), ShouldEqual, context.Canceled)
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package lock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// this returns a unique owner identity of the current process
+func newOwner() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), hex.EncodeToString(suffix)), nil
+}
+
+// the lock document is matched only if it's free to acquire,
+// otherwise the upsert conflicts with the existing document
+func getAcquireFilter(owner string, now time.Time) bson.M {
+	return bson.M{
+		"_id": lockID,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lt": now}},
+			bson.M{"owner": owner},
+		},
+	}
+}
+
+func getAcquireUpdate(owner string, now time.Time, ttl time.Duration) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"owner":        owner,
+			"acquired_at":  now,
+			"heartbeat_at": now,
+			"expires_at":   now.Add(ttl),
+		},
+	}
+}
+
+func upsertOptions() *options.UpdateOptions {
+	return options.Update().SetUpsert(true)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package lock
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewOwner(t *testing.T) {
+	Convey("Owners are unique per lock attempt", t, func() {
+		owner1, err := newOwner()
+		So(err, ShouldBeNil)
+		owner2, err := newOwner()
+		So(err, ShouldBeNil)
+		So(owner1, ShouldNotEqual, owner2)
+	})
+}
+
+func TestGetAcquireFilterAndUpdate(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	Convey("Lock is only acquirable if expired or owned by the same owner", t, func() {
+		filter := getAcquireFilter("owner-1", now)
+		So(filter["_id"], ShouldEqual, lockID)
+		So(filter["$or"], ShouldResemble, bson.A{
+			bson.M{"expires_at": bson.M{"$lt": now}},
+			bson.M{"owner": "owner-1"},
+		})
+	})
+
+	Convey("Lock expiry is extended by TTL", t, func() {
+		update := getAcquireUpdate("owner-1", now, DefaultTTL)
+		set := update["$set"].(bson.M)
+		So(set["owner"], ShouldEqual, "owner-1")
+		So(set["heartbeat_at"], ShouldEqual, now)
+		So(set["expires_at"], ShouldEqual, now.Add(DefaultTTL))
+	})
+}
@@ -8,6 +8,14 @@ Licensed under the MIT License
 package migrator
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
 	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
 )
 
@@ -31,6 +39,94 @@ type (
 	}
 )
 
+// canonical content of a sub action for the checksum,
+// it only depends on the schema, not on how the migration file is written
+type checksumSubAction struct {
+	Type                 si.SubActionType
+	Collection           *metadata.Spec
+	Fields               []field.Spec
+	Indexes              []*index.Spec
+	FieldConvertFrom     *field.FieldType
+	CollectionModifyFrom *metadata.Spec
+	IndexModifyFrom      *index.Spec
+}
+
+type checksumAction struct {
+	ActionKey  string
+	SubActions []checksumSubAction
+}
+
+func toChecksumActions(actions []si.Action) []checksumAction {
+	res := []checksumAction{}
+	for _, action := range actions {
+		curr := checksumAction{
+			ActionKey:  action.ActionKey,
+			SubActions: []checksumSubAction{},
+		}
+		for _, subAction := range action.SubActions {
+			schema := subAction.ActionSchema
+			sa := checksumSubAction{
+				Type:             subAction.Type,
+				Fields:           collection.SpecsFromFields(schema.Fields),
+				Indexes:          []*index.Spec{},
+				FieldConvertFrom: schema.FieldConvertFrom,
+			}
+			if schema.Collection != nil {
+				sa.Collection = schema.Collection.Spec()
+			}
+
+			for _, idx := range schema.Indexes {
+				sa.Indexes = append(sa.Indexes, idx.Spec())
+			}
+
+			if schema.CollectionModifyFrom != nil {
+				sa.CollectionModifyFrom = schema.CollectionModifyFrom.Spec()
+			}
+
+			if schema.IndexModifyFrom != nil {
+				sa.IndexModifyFrom = schema.IndexModifyFrom.Spec()
+			}
+
+			curr.SubActions = append(curr.SubActions, sa)
+		}
+
+		res = append(res, curr)
+	}
+
+	return res
+}
+
+// Checksum returns the fingerprint of the migration content,
+// it's stored in the migration history to detect edited migration files.
+// The content is JSON encoded, so changes of the migration file generator don't change the checksum
+func (m Migration) Checksum() string {
+	content, err := json.Marshal(struct {
+		Desc string
+		Up   []checksumAction
+		Down []checksumAction
+	}{
+		Desc: m.Desc,
+		Up:   toChecksumActions(m.Up),
+		Down: toChecksumActions(m.Down),
+	})
+	if err != nil {
+		// a value JSON doesn't support (i.e: NaN), the literal is used instead
+		literal := m.Desc + "\n"
+		for _, actions := range [][]si.Action{m.Up, m.Down} {
+			for _, action := range actions {
+				literal += action.GetLiteralInstance("", false) + "\n"
+			}
+			literal += "\n"
+		}
+
+		content = []byte(literal)
+	}
+
+	hash := sha256.Sum256(content)
+
+	return hex.EncodeToString(hash[:])
+}
+
 func (m Migrator) OnError() {
 	m.Rollback()
 }
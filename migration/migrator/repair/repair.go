@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package repair
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	"github.com/amirkode/go-mongr8/migration/migrator/lock"
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// This marks a stuck migration as clean or dirty in the migration history
+// a clean migration is considered completely applied, i.e: after the failing changes are fixed manually
+// a dirty migration is resumed from its recorded progress on the next apply
+func Run(ctx *context.Context, db *mongo.Database, migrations []migrator.Migration) error {
+	opt := option.GetMigrationOptionFromContext(ctx)
+	if opt.RepairMark != MarkClean && opt.RepairMark != MarkDirty {
+		return fmt.Errorf("--%s must be either %s or %s", option.MigrationOptionArgRepairMark, MarkClean, MarkDirty)
+	}
+
+	migrationLock, err := lock.Acquire(*ctx, db, opt.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rErr := migrationLock.Release(*ctx); rErr != nil {
+			log.Printf("Error releasing the migration lock: %s\n", rErr.Error())
+		}
+	}()
+
+	histories, err := apply.GetMigrationHistories(*ctx, db)
+	if err != nil {
+		return err
+	}
+
+	migration, err := getRepairTarget(migrations, histories, opt.RepairID)
+	if err != nil {
+		return err
+	}
+
+	if opt.RepairMark == MarkClean {
+		err = apply.MarkMigrationClean(*migration, time.Now(), *ctx, db)
+	} else {
+		err = markMigrationDirty(*migration, *ctx, db)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Migration %s has been marked as %s\n", migration.ID, opt.RepairMark)
+
+	return nil
+}
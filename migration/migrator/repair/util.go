@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package repair
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/amirkode/go-mongr8/migration/common"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// the migration is considered completely applied
+	MarkClean = "clean"
+	// the migration is considered partially applied, it's resumed on the next apply
+	MarkDirty = "dirty"
+)
+
+// this returns the migration to repair
+// if `id` is not provided, the only dirty migration is selected
+func getRepairTarget(migrations []migrator.Migration, histories map[string]apply.MigrationHistory, id string) (*migrator.Migration, error) {
+	if id == "" {
+		dirtyIDs := []string{}
+		for _, history := range histories {
+			if history.Dirty {
+				dirtyIDs = append(dirtyIDs, history.MigrationID)
+			}
+		}
+
+		if len(dirtyIDs) == 0 {
+			return nil, fmt.Errorf("there is no dirty migration, please provide the migration ID with --%s", option.MigrationOptionArgRepairID)
+		}
+
+		if len(dirtyIDs) > 1 {
+			sort.Strings(dirtyIDs)
+			return nil, fmt.Errorf("there are several dirty migrations: %s, please provide the migration ID with --%s",
+				strings.Join(dirtyIDs, ", "), option.MigrationOptionArgRepairID)
+		}
+
+		id = dirtyIDs[0]
+	}
+
+	for _, m := range migrations {
+		if m.ID == id {
+			return &m, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Migration %s was not found in migration files", id)
+}
+
+func markMigrationDirty(migration migrator.Migration, ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(common.MigrationHistoryCollection)
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"_id": migration.ID},
+		bson.M{
+			"$set": bson.M{
+				"desc":     migration.Desc,
+				"checksum": migration.Checksum(),
+				"dirty":    true,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package repair
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetRepairTarget(t *testing.T) {
+	migrations := []migrator.Migration{
+		{ID: "20240101_000000", Desc: "create users"},
+		{ID: "20240102_000000", Desc: "add age"},
+		{ID: "20240103_000000", Desc: "add email"},
+	}
+
+	Convey("Case 1: The only dirty migration is selected", t, func() {
+		histories := map[string]apply.MigrationHistory{
+			"20240101_000000": {MigrationID: "20240101_000000"},
+			"20240102_000000": {MigrationID: "20240102_000000", Dirty: true},
+		}
+
+		m, err := getRepairTarget(migrations, histories, "")
+		So(err, ShouldBeNil)
+		So(m.ID, ShouldEqual, "20240102_000000")
+	})
+
+	Convey("Case 2: Ambiguous or missing dirty migration", t, func() {
+		_, err := getRepairTarget(migrations, map[string]apply.MigrationHistory{}, "")
+		So(err, ShouldNotBeNil)
+
+		histories := map[string]apply.MigrationHistory{
+			"20240102_000000": {MigrationID: "20240102_000000", Dirty: true},
+			"20240103_000000": {MigrationID: "20240103_000000", Dirty: true},
+		}
+		_, err = getRepairTarget(migrations, histories, "")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Case 3: Explicit migration ID", t, func() {
+		m, err := getRepairTarget(migrations, map[string]apply.MigrationHistory{}, "20240103_000000")
+		So(err, ShouldBeNil)
+		So(m.Desc, ShouldEqual, "add email")
+
+		_, err = getRepairTarget(migrations, map[string]apply.MigrationHistory{}, "20240104_000000")
+		So(err, ShouldNotBeNil)
+	})
+}
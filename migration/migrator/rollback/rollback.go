@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/lock"
+	"github.com/amirkode/go-mongr8/migration/option"
+	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func execSubActions(ctx context.Context, db *mongo.Database, migrations []migrator.Migration, apis []ai.SubActionApi) error {
+	opt := option.GetMigrationOptionFromContext(&ctx)
+	appliedIDs, err := getAppliedMigrationIDs(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	migrationIDs, err := getRollbackMigrationIDs(appliedIDs, opt.RollbackSteps, opt.RollbackTo)
+	if err != nil {
+		return err
+	}
+
+	if len(migrationIDs) == 0 {
+		log.Printf("Nothing to rollback.\n")
+		return nil
+	}
+
+	groupedApis, err := groupSubActionApi(apis, migrations, migrationIDs)
+	if err != nil {
+		return err
+	}
+
+	// revert from the latest migration
+	for _, id := range migrationIDs {
+		for _, api := range groupedApis[id] {
+			// execute the action api synchronously, unless the run is aborted
+			err := context.Cause(ctx)
+			if err == nil {
+				err = api.Execute(ctx, db)
+			}
+
+			if err != nil {
+				// i.e: the migration lock is lost while executing
+				if ctx.Err() != nil {
+					err = context.Cause(ctx)
+				}
+
+				return fmt.Errorf("error rolling back migration %s: %v", id, err)
+			}
+		}
+
+		// the migration is no longer applied
+		err = deleteMigrationHistory(id, ctx, db)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("All Migration files has been rolled back with IDs: %s..%s\n",
+		migrationIDs[0],
+		migrationIDs[len(migrationIDs)-1],
+	)
+
+	return nil
+}
+
+func Run(ctx *context.Context, db *mongo.Database, migrations []migrator.Migration, apis []ai.SubActionApi) error {
+	// prevent concurrent migration runs
+	migrationLock, err := lock.Acquire(*ctx, db, option.GetMigrationOptionFromContext(ctx).LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rErr := migrationLock.Release(*ctx); rErr != nil {
+			log.Printf("Error releasing the migration lock: %s\n", rErr.Error())
+		}
+	}()
+
+	// sub actions are aborted once the lock is lost, so another process cannot run concurrently
+	runCtx, cancel := migrationLock.WithContext(*ctx)
+	defer cancel()
+
+	useTransaction := option.GetMigrationOptionFromContext(ctx).UseTransaction
+	if !useTransaction {
+		// executes everything with individually
+		return execSubActions(runCtx, db, migrations, apis)
+	}
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(*ctx)
+
+	// bind everything in a transaction
+	return mongo.WithSession(runCtx, session, func(sc mongo.SessionContext) error {
+		if err := sc.StartTransaction(); err != nil {
+			return err
+		}
+
+		// execute sub actions
+		err := execSubActions(sc, db, migrations, apis)
+		if err != nil {
+			// rollback
+			if rErr := sc.AbortTransaction(*ctx); rErr != nil {
+				return fmt.Errorf("error rolling back transaction: %v (caused by original error: %v)", rErr, err)
+			}
+			return err
+		}
+
+		return sc.CommitTransaction(*ctx)
+	})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/amirkode/go-mongr8/migration/common"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// this returns all applied migration IDs sorted from the latest one
+func getAppliedMigrationIDs(ctx context.Context, db *mongo.Database) ([]string, error) {
+	res := []string{}
+	coll := db.Collection(common.MigrationHistoryCollection)
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	for cursor.Next(ctx) {
+		var history apply.MigrationHistory
+		err = cursor.Decode(&history)
+		if err != nil {
+			return nil, err
+		}
+
+		// Down actions expect the whole migration was applied
+		if history.Dirty {
+			return nil, fmt.Errorf("Migration %s is dirty, please repair it before rollback", history.MigrationID)
+		}
+
+		res = append(res, history.MigrationID)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] > res[j]
+	})
+
+	return res, nil
+}
+
+func deleteMigrationHistory(migrationID string, ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(common.MigrationHistoryCollection)
+	_, err := coll.DeleteOne(ctx, bson.M{"_id": migrationID})
+
+	return err
+}
+
+// this returns migration IDs must be reverted
+// `appliedIDs` must be sorted from the latest migration
+// `steps` is the number of latest migrations to revert
+// `target` is the migration ID to rollback to, the target itself is kept
+// if neither `steps` nor `target` is provided, only the latest migration is reverted
+func getRollbackMigrationIDs(appliedIDs []string, steps int, target string) ([]string, error) {
+	if steps < 0 {
+		return nil, fmt.Errorf("Rollback steps must not be negative")
+	}
+
+	if steps > 0 && target != "" {
+		return nil, fmt.Errorf("Rollback steps and target cannot be used together")
+	}
+
+	if target != "" {
+		found := false
+		res := []string{}
+		for _, id := range appliedIDs {
+			if id == target {
+				found = true
+				break
+			}
+
+			res = append(res, id)
+		}
+
+		if !found {
+			return nil, fmt.Errorf("Target migration %s has not been applied", target)
+		}
+
+		return res, nil
+	}
+
+	if steps == 0 {
+		steps = 1
+	}
+
+	if steps > len(appliedIDs) {
+		steps = len(appliedIDs)
+	}
+
+	return appliedIDs[:steps], nil
+}
+
+// this groups sub action apis by the migration ID
+// any migration ID in `migrationIDs` must be found in `migrations`
+func groupSubActionApi(apis []ai.SubActionApi, migrations []migrator.Migration, migrationIDs []string) (map[string][]ai.SubActionApi, error) {
+	known := map[string]bool{}
+	for _, m := range migrations {
+		known[m.ID] = true
+	}
+
+	for _, id := range migrationIDs {
+		if !known[id] {
+			return nil, fmt.Errorf("Migration %s was applied, but not found in migration files", id)
+		}
+	}
+
+	res := map[string][]ai.SubActionApi{}
+	for _, api := range apis {
+		res[api.Migration.ID] = append(res[api.Migration.ID], api)
+	}
+
+	return res, nil
+}
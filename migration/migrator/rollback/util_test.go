@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package rollback
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetRollbackMigrationIDs(t *testing.T) {
+	appliedIDs := []string{
+		"20240103_000000",
+		"20240102_000000",
+		"20240101_000000",
+	}
+
+	// case 1: default
+	Convey("Case 1: Default", t, func() {
+		Convey("Only the latest migration is reverted", func() {
+			ids, err := getRollbackMigrationIDs(appliedIDs, 0, "")
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{"20240103_000000"})
+		})
+
+		Convey("Nothing applied", func() {
+			ids, err := getRollbackMigrationIDs([]string{}, 0, "")
+			So(err, ShouldBeNil)
+			So(len(ids), ShouldEqual, 0)
+		})
+	})
+
+	// case 2: with steps
+	Convey("Case 2: With steps", t, func() {
+		Convey("Latest two migrations are reverted", func() {
+			ids, err := getRollbackMigrationIDs(appliedIDs, 2, "")
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{"20240103_000000", "20240102_000000"})
+		})
+
+		Convey("Steps exceed applied migrations", func() {
+			ids, err := getRollbackMigrationIDs(appliedIDs, 10, "")
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, appliedIDs)
+		})
+
+		Convey("Negative steps", func() {
+			_, err := getRollbackMigrationIDs(appliedIDs, -1, "")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	// case 3: with target
+	Convey("Case 3: With target", t, func() {
+		Convey("Migrations after target are reverted", func() {
+			ids, err := getRollbackMigrationIDs(appliedIDs, 0, "20240101_000000")
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{"20240103_000000", "20240102_000000"})
+		})
+
+		Convey("Target is the latest migration", func() {
+			ids, err := getRollbackMigrationIDs(appliedIDs, 0, "20240103_000000")
+			So(err, ShouldBeNil)
+			So(len(ids), ShouldEqual, 0)
+		})
+
+		Convey("Target has not been applied", func() {
+			_, err := getRollbackMigrationIDs(appliedIDs, 0, "20230101_000000")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Steps and target together", func() {
+			_, err := getRollbackMigrationIDs(appliedIDs, 1, "20240101_000000")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGroupSubActionApi(t *testing.T) {
+	migrations := []migrator.Migration{
+		{ID: "20240101_000000"},
+		{ID: "20240102_000000"},
+	}
+	apis := []ai.SubActionApi{
+		{Migration: migrations[1]},
+		{Migration: migrations[1]},
+		{Migration: migrations[0]},
+	}
+
+	// case 1: default
+	Convey("Case 1: Default", t, func() {
+		grouped, err := groupSubActionApi(apis, migrations, []string{"20240102_000000", "20240101_000000"})
+		So(err, ShouldBeNil)
+		So(len(grouped["20240102_000000"]), ShouldEqual, 2)
+		So(len(grouped["20240101_000000"]), ShouldEqual, 1)
+	})
+
+	// case 2: applied migration without migration file
+	Convey("Case 2: Missing migration file", t, func() {
+		_, err := groupSubActionApi(apis, migrations, []string{"20240103_000000"})
+		So(err, ShouldNotBeNil)
+	})
+}
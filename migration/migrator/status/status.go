@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package status
+
+import (
+	"context"
+	"os"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// This returns the state of every migration file against the migration history
+func GetStatuses(ctx context.Context, db *mongo.Database, migrations []migrator.Migration) ([]MigrationStatus, error) {
+	histories, err := apply.GetMigrationHistories(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return getMigrationStatuses(migrations, histories), nil
+}
+
+func Run(ctx *context.Context, db *mongo.Database, migrations []migrator.Migration) error {
+	statuses, err := GetStatuses(*ctx, db, migrations)
+	if err != nil {
+		return err
+	}
+
+	if option.GetMigrationOptionFromContext(ctx).UseJson {
+		return writeJson(os.Stdout, statuses)
+	}
+
+	return writeTable(os.Stdout, statuses)
+}
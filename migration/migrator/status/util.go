@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+)
+
+const (
+	StateApplied = "applied"
+	StatePending = "pending"
+	// partially applied
+	StateDirty = "dirty"
+)
+
+type (
+	SubActionSummary struct {
+		Type  si.SubActionType `json:"type"`
+		Count int              `json:"count"`
+	}
+
+	MigrationStatus struct {
+		ID        string     `json:"id"`
+		Desc      string     `json:"desc"`
+		State     string     `json:"state"`
+		AppliedAt *time.Time `json:"applied_at,omitempty"`
+		// applied migration whose content has been changed since applied
+		Modified bool `json:"modified"`
+		// pending migration older than the latest applied migration
+		OutOfOrder   bool               `json:"out_of_order"`
+		UpSubActions []SubActionSummary `json:"up_sub_actions"`
+	}
+)
+
+// this counts sub action types of the actions, ordered by the first appearance
+func getSubActionSummaries(actions []si.Action) []SubActionSummary {
+	res := []SubActionSummary{}
+	indexes := map[si.SubActionType]int{}
+	for _, action := range actions {
+		for _, subAction := range action.SubActions {
+			index, ok := indexes[subAction.Type]
+			if !ok {
+				index = len(res)
+				indexes[subAction.Type] = index
+				res = append(res, SubActionSummary{Type: subAction.Type})
+			}
+
+			res[index].Count++
+		}
+	}
+
+	return res
+}
+
+func getMigrationStatuses(migrations []migrator.Migration, histories map[string]apply.MigrationHistory) []MigrationStatus {
+	_, outOfOrderIDs := apply.GetPendingMigrationIDs(migrations, histories)
+	outOfOrder := map[string]bool{}
+	for _, id := range outOfOrderIDs {
+		outOfOrder[id] = true
+	}
+
+	modified := map[string]bool{}
+	for _, id := range apply.GetModifiedMigrationIDs(migrations, histories) {
+		modified[id] = true
+	}
+
+	res := []MigrationStatus{}
+	for _, m := range migrations {
+		curr := MigrationStatus{
+			ID:           m.ID,
+			Desc:         m.Desc,
+			State:        StatePending,
+			Modified:     modified[m.ID],
+			OutOfOrder:   outOfOrder[m.ID],
+			UpSubActions: getSubActionSummaries(m.Up),
+		}
+
+		if history, ok := histories[m.ID]; ok {
+			if history.Dirty {
+				curr.State = StateDirty
+			} else {
+				migratedAt := history.MigratedAt
+				curr.State = StateApplied
+				curr.AppliedAt = &migratedAt
+			}
+		}
+
+		res = append(res, curr)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
+
+func writeJson(w io.Writer, statuses []MigrationStatus) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(statuses)
+}
+
+func writeTable(w io.Writer, statuses []MigrationStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATE\tAPPLIED AT\tDESCRIPTION\tUP SUB ACTIONS")
+	for _, s := range statuses {
+		state := s.State
+		if s.Modified {
+			state += " (modified)"
+		}
+		if s.OutOfOrder {
+			state += " (out of order)"
+		}
+
+		appliedAt := "-"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+
+		summaries := []string{}
+		for _, summary := range s.UpSubActions {
+			summaries = append(summaries, fmt.Sprintf("%s(%d)", strings.TrimPrefix(summary.Type.ToString(), "SubActionType"), summary.Count))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.ID, state, appliedAt, s.Desc, strings.Join(summaries, ", "))
+	}
+
+	return tw.Flush()
+}
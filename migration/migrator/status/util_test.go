@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/migrator/apply"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func getTestMigrations() []migrator.Migration {
+	return []migrator.Migration{
+		{
+			ID:   "20240102_000000",
+			Desc: "add age",
+			Up: []si.Action{
+				{
+					ActionKey: "users",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateField(si.SubActionSchema{
+							Collection: metadata.InitMetadata("users"),
+							Fields:     []collection.Field{field.Int32Field("age")},
+						}),
+						*si.SubActionCreateField(si.SubActionSchema{
+							Collection: metadata.InitMetadata("users"),
+							Fields:     []collection.Field{field.StringField("email")},
+						}),
+					},
+				},
+			},
+		},
+		{
+			ID:   "20240101_000000",
+			Desc: "create users",
+			Up: []si.Action{
+				{
+					ActionKey: "users",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateCollection(si.SubActionSchema{
+							Collection: metadata.InitMetadata("users"),
+						}),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetMigrationStatuses(t *testing.T) {
+	migrations := getTestMigrations()
+	migratedAt := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+	histories := map[string]apply.MigrationHistory{
+		"20240101_000000": apply.NewMigrationHistory(migrations[1], migratedAt),
+	}
+
+	Convey("Statuses are sorted by migration ID", t, func() {
+		statuses := getMigrationStatuses(migrations, histories)
+		So(len(statuses), ShouldEqual, 2)
+
+		So(statuses[0].ID, ShouldEqual, "20240101_000000")
+		So(statuses[0].State, ShouldEqual, StateApplied)
+		So(*statuses[0].AppliedAt, ShouldEqual, migratedAt)
+		So(statuses[0].UpSubActions, ShouldResemble, []SubActionSummary{
+			{Type: si.SubActionTypeCreateCollection, Count: 1},
+		})
+
+		So(statuses[1].ID, ShouldEqual, "20240102_000000")
+		So(statuses[1].State, ShouldEqual, StatePending)
+		So(statuses[1].AppliedAt, ShouldBeNil)
+		So(statuses[1].UpSubActions, ShouldResemble, []SubActionSummary{
+			{Type: si.SubActionTypeCreateField, Count: 2},
+		})
+	})
+
+	Convey("Dirty migration", t, func() {
+		dirtyHistories := map[string]apply.MigrationHistory{
+			"20240101_000000": apply.NewMigrationHistory(migrations[1], migratedAt),
+			"20240102_000000": {MigrationID: "20240102_000000", Dirty: true, Progress: []string{"users.0"}},
+		}
+		statuses := getMigrationStatuses(migrations, dirtyHistories)
+		So(statuses[1].State, ShouldEqual, StateDirty)
+		So(statuses[1].AppliedAt, ShouldBeNil)
+	})
+
+	Convey("Output formats", t, func() {
+		statuses := getMigrationStatuses(migrations, histories)
+
+		Convey("JSON", func() {
+			var buf bytes.Buffer
+			So(writeJson(&buf, statuses), ShouldBeNil)
+
+			var decoded []map[string]interface{}
+			So(json.Unmarshal(buf.Bytes(), &decoded), ShouldBeNil)
+			So(decoded[0]["state"], ShouldEqual, StateApplied)
+			So(decoded[1]["state"], ShouldEqual, StatePending)
+			_, hasAppliedAt := decoded[1]["applied_at"]
+			So(hasAppliedAt, ShouldBeFalse)
+		})
+
+		Convey("Table", func() {
+			var buf bytes.Buffer
+			So(writeTable(&buf, statuses), ShouldBeNil)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			So(len(lines), ShouldEqual, 3)
+			So(lines[1], ShouldContainSubstring, "CreateCollection(1)")
+			So(lines[2], ShouldContainSubstring, "CreateField(2)")
+		})
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/amirkode/go-mongr8/internal/config"
 	"github.com/amirkode/go-mongr8/internal/util"
 	"github.com/amirkode/go-mongr8/migration/migrator"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
 )
 
 func getMigrationLiteral(migration migrator.Migration) string {
@@ -56,9 +57,12 @@ func Write(migration migrator.Migration) error {
 	}
 
 	// check whether current migration uses field or/and index
+	// both Up and Down actions are declared in the same file
 	useField := false
 	useIndex := false
-	for _, action := range migration.Up {
+	actions := append([]si.Action{}, migration.Up...)
+	actions = append(actions, migration.Down...)
+	for _, action := range actions {
 		for _, subAction := range action.SubActions {
 			if !useField {
 				useField = len(subAction.ActionSchema.Fields) > 0
@@ -0,0 +1,323 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package writer
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/internal/config"
+	"github.com/amirkode/go-mongr8/internal/util"
+)
+
+const (
+	baseModelPath = "mongr8/model"
+	// suffix of the field path types, i.e: UsersFieldPaths
+	fieldPathsSuffix = "FieldPaths"
+	// name of the path of an object in its field path type
+	fieldPathSelf = "Path"
+)
+
+// modelBuilder declares the Go structs of collections
+// and their field path constants
+type modelBuilder struct {
+	// declared type names, to avoid duplicates
+	typeNames map[string]bool
+	models    []string
+	paths     []string
+	vars      []string
+
+	useTime      bool
+	usePrimitive bool
+	useBson      bool
+}
+
+func newModelBuilder() *modelBuilder {
+	return &modelBuilder{
+		typeNames: map[string]bool{},
+	}
+}
+
+// This returns a Go identifier of a field or a collection name,
+// i.e: "created_at" to "CreatedAt"
+func toGoName(name string) string {
+	if name == "_id" {
+		return "ID"
+	}
+
+	res := ""
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		res += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+
+	if res == "" || unicode.IsDigit([]rune(res)[0]) {
+		res = "F" + res
+	}
+
+	return res
+}
+
+// This returns an unused name by appending a number suffix
+func uniqueName(name string, used map[string]bool) string {
+	res := name
+	for i := 2; used[res]; i++ {
+		res = fmt.Sprintf("%s%d", name, i)
+	}
+
+	used[res] = true
+
+	return res
+}
+
+// This declares the struct of `fields` and returns its type name
+func (b *modelBuilder) declareStruct(name string, fields []field.Spec) string {
+	name = uniqueName(name, b.typeNames)
+	// reserve the position, so the parent is declared before its children
+	pos := len(b.models)
+	b.models = append(b.models, "")
+
+	used := map[string]bool{}
+	body := ""
+	for _, spec := range fields {
+		goName := uniqueName(toGoName(spec.Name), used)
+		body += fmt.Sprintf("%s %s `bson:\"%s\" json:\"%s\"`\n", goName, b.goType(name+goName, spec), spec.Name, spec.Name)
+	}
+
+	b.models[pos] = fmt.Sprintf("type %s struct {\n%s}\n", name, body)
+
+	return name
+}
+
+// This returns the Go type of a field,
+// `name` is used for the struct declared by an object field
+func (b *modelBuilder) goType(name string, spec field.Spec) string {
+	res := ""
+	switch spec.Type {
+	case field.TypeString:
+		res = "string"
+	case field.TypeInt32:
+		res = "int32"
+	case field.TypeInt64:
+		res = "int64"
+	case field.TypeDouble:
+		res = "float64"
+	case field.TypeBoolean:
+		res = "bool"
+	case field.TypeTimestamp, field.TypeDate:
+		b.useTime = true
+		res = "time.Time"
+	case field.TypeObjectID:
+		b.usePrimitive = true
+		res = "primitive.ObjectID"
+	case field.TypeDecimal128:
+		b.usePrimitive = true
+		res = "primitive.Decimal128"
+	case field.TypeBinary:
+		res = "[]byte"
+	case field.TypeUUID:
+		// keeps the UUID subtype
+		b.usePrimitive = true
+		res = "primitive.Binary"
+	case field.TypeArray:
+		// an array of mixed types has no specific item type
+		if spec.ArrayFields == nil || len(*spec.ArrayFields) != 1 {
+			return "[]interface{}"
+		}
+
+		return "[]" + b.goType(name, (*spec.ArrayFields)[0])
+	case field.TypeObject:
+		if spec.Object == nil || len(*spec.Object) == 0 {
+			return "map[string]interface{}"
+		}
+
+		res = b.declareStruct(name, *spec.Object)
+	case field.TypeMap:
+		value := spec.GetMapValue()
+		if value == nil {
+			return "map[string]interface{}"
+		}
+
+		return "map[string]" + b.goType(name, *value)
+	case field.TypeOneOf:
+		// each variant is declared separately,
+		// the value is decoded into a variant by the discriminator
+		discriminator := *field.StringField(spec.GetDiscriminator()).Spec()
+		for _, variant := range spec.GetVariants() {
+			variantFields := []field.Spec{discriminator}
+			if variant.Object != nil {
+				variantFields = append(variantFields, *variant.Object...)
+			}
+
+			b.declareStruct(name+toGoName(variant.Name), variantFields)
+		}
+
+		b.useBson = true
+		return "bson.Raw"
+	case field.TypeLegacyCoordinateArray:
+		res = "[]float64"
+	default:
+		// geo json and legacy coordinate embedded document
+		return "map[string]interface{}"
+	}
+
+	if spec.Nullable {
+		res = "*" + res
+	}
+
+	return res
+}
+
+// This declares the field path type of `fields` and returns its type name and value literal,
+// `path` is the path of the object, empty for the document root
+func (b *modelBuilder) declarePaths(baseName, path string, fields []field.Spec) (string, string) {
+	name := uniqueName(baseName+fieldPathsSuffix, b.typeNames)
+	pos := len(b.paths)
+	b.paths = append(b.paths, "")
+
+	used := map[string]bool{}
+	body := ""
+	value := ""
+	if path != "" {
+		used[fieldPathSelf] = true
+		body += fmt.Sprintf("%s string\n", fieldPathSelf)
+		value += fmt.Sprintf("%s: %q,\n", fieldPathSelf, path)
+	}
+
+	for _, spec := range fields {
+		goName := uniqueName(toGoName(spec.Name), used)
+		fieldPath := spec.Name
+		if path != "" {
+			fieldPath = path + "." + spec.Name
+		}
+
+		children := getPathChildren(spec)
+		if len(children) == 0 {
+			body += fmt.Sprintf("%s string\n", goName)
+			value += fmt.Sprintf("%s: %q,\n", goName, fieldPath)
+			continue
+		}
+
+		childType, childValue := b.declarePaths(baseName+goName, fieldPath, children)
+		body += fmt.Sprintf("%s %s\n", goName, childType)
+		value += fmt.Sprintf("%s: %s,\n", goName, childValue)
+	}
+
+	b.paths[pos] = fmt.Sprintf("type %s struct {\n%s}\n", name, body)
+
+	return name, fmt.Sprintf("%s{\n%s}", name, value)
+}
+
+// This returns the fields addressable by a dot notation under a field,
+// fields of an array of object are addressed without the item position,
+// and fields of all variants of a one of are merged
+func getPathChildren(spec field.Spec) []field.Spec {
+	switch spec.Type {
+	case field.TypeObject:
+		if spec.Object != nil {
+			return *spec.Object
+		}
+	case field.TypeArray:
+		if spec.ArrayFields != nil && len(*spec.ArrayFields) == 1 {
+			return getPathChildren((*spec.ArrayFields)[0])
+		}
+	case field.TypeOneOf:
+		res := []field.Spec{*field.StringField(spec.GetDiscriminator()).Spec()}
+		names := map[string]bool{spec.GetDiscriminator(): true}
+		for _, variant := range spec.GetVariants() {
+			if variant.Object == nil {
+				continue
+			}
+
+			for _, child := range *variant.Object {
+				if !names[child.Name] {
+					names[child.Name] = true
+					res = append(res, child)
+				}
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+func (b *modelBuilder) addCollection(coll collection.Collection) {
+	name := toGoName(coll.Collection().Spec().Name)
+	specs := collection.SpecsFromFields(coll.Fields())
+	b.declareStruct(name, specs)
+
+	_, pathsValue := b.declarePaths(name, "", specs)
+	varName := uniqueName(name+"Fields", b.typeNames)
+	b.vars = append(b.vars, fmt.Sprintf("%s = %s", varName, pathsValue))
+}
+
+func getModelsLiteral(b *modelBuilder) string {
+	res := strings.Join(b.models, "\n")
+	res += "\n" + strings.Join(b.paths, "\n")
+	if len(b.vars) > 0 {
+		res += fmt.Sprintf("\nvar (\n%s\n)\n", strings.Join(b.vars, "\n"))
+	}
+
+	return res
+}
+
+// WriteModels generates the Go structs of the collections with bson and json tags,
+// and the field path constants of each collection, i.e: UsersFields.Address.City is "address.city"
+func WriteModels(collections []collection.Collection) error {
+	projectPath, err := config.GetProjectRootDir()
+	if err != nil {
+		return err
+	}
+
+	sorted := append([]collection.Collection{}, collections...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Collection().Spec().Name < sorted[j].Collection().Spec().Name
+	})
+
+	builder := newModelBuilder()
+	for _, coll := range sorted {
+		builder.addCollection(coll)
+	}
+
+	tplVar := struct {
+		CreateDate   string
+		Models       string
+		UseTime      bool
+		UsePrimitive bool
+		UseBson      bool
+	}{
+		CreateDate:   time.Now().Format("2006-01-02"),
+		Models:       getModelsLiteral(builder),
+		UseTime:      builder.useTime,
+		UsePrimitive: builder.usePrimitive,
+		UseBson:      builder.useBson,
+	}
+
+	tplPath, err := config.GetTemplatePath("model", "template.tpl")
+	if err != nil {
+		return err
+	}
+
+	modelPath := fmt.Sprintf("%s/%s", *projectPath, baseModelPath)
+	err = os.MkdirAll(modelPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	return util.GenerateTemplate("models", *tplPath, fmt.Sprintf("%s/models.go", modelPath), tplVar, true)
+}
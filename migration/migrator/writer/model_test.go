@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package writer
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func getTestModelsLiteral(collections ...collection.Collection) (*modelBuilder, string) {
+	builder := newModelBuilder()
+	for _, coll := range collections {
+		builder.addCollection(coll)
+	}
+
+	literal := getModelsLiteral(builder)
+	formatted, err := format.Source([]byte("package model\n" + literal))
+	So(err, ShouldBeNil)
+
+	return builder, string(formatted)
+}
+
+// normalizes the spaces, so the expected declarations don't depend on the alignment
+func normalizeSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func TestToGoName(t *testing.T) {
+	Convey("To Go Name", t, func() {
+		So(toGoName("_id"), ShouldEqual, "ID")
+		So(toGoName("created_at"), ShouldEqual, "CreatedAt")
+		So(toGoName("createdAt"), ShouldEqual, "CreatedAt")
+		So(toGoName("zip-code"), ShouldEqual, "ZipCode")
+		So(toGoName("2fa"), ShouldEqual, "F2fa")
+	})
+}
+
+func TestGetModelsLiteral(t *testing.T) {
+	users := collection.NewCollection(
+		metadata.InitMetadata("users"),
+		[]collection.Field{
+			field.ObjectIDField("_id"),
+			field.StringField("name"),
+			field.TimestampField("deleted_at").SetNullable(),
+			field.ObjectField("address",
+				field.StringField("city"),
+				field.StringField("path"),
+			),
+			field.ArrayField("orders",
+				field.ObjectField("",
+					field.StringField("sku"),
+					field.Decimal128Field("amount"),
+				),
+			),
+			field.MapField("labels", field.Int64Field("")),
+			field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+				"click": field.ObjectField("", field.Int32Field("x")),
+				"view":  field.ObjectField("", field.StringField("page")),
+			}),
+		},
+		nil,
+	)
+
+	Convey("Get Models Literal", t, func() {
+		builder, literal := getTestModelsLiteral(users)
+		normalized := normalizeSpaces(literal)
+
+		So(builder.useTime, ShouldBeTrue)
+		So(builder.usePrimitive, ShouldBeTrue)
+		So(builder.useBson, ShouldBeTrue)
+
+		// models
+		So(normalized, ShouldContainSubstring, normalizeSpaces("type Users struct {\n"+
+			"ID primitive.ObjectID `bson:\"_id\" json:\"_id\"`\n"+
+			"Name string `bson:\"name\" json:\"name\"`\n"+
+			"DeletedAt *time.Time `bson:\"deleted_at\" json:\"deleted_at\"`\n"+
+			"Address UsersAddress `bson:\"address\" json:\"address\"`\n"+
+			"Orders []UsersOrders `bson:\"orders\" json:\"orders\"`\n"+
+			"Labels map[string]int64 `bson:\"labels\" json:\"labels\"`\n"+
+			"Payload bson.Raw `bson:\"payload\" json:\"payload\"`\n"+
+			"}"))
+		So(normalized, ShouldContainSubstring, normalizeSpaces("type UsersOrders struct {\n"+
+			"Sku string `bson:\"sku\" json:\"sku\"`\n"+
+			"Amount primitive.Decimal128 `bson:\"amount\" json:\"amount\"`\n"+
+			"}"))
+		So(normalized, ShouldContainSubstring, normalizeSpaces("type UsersPayloadClick struct {\n"+
+			"Kind string `bson:\"kind\" json:\"kind\"`\n"+
+			"X int32 `bson:\"x\" json:\"x\"`\n"+
+			"}"))
+		So(normalized, ShouldContainSubstring, "type UsersPayloadView struct")
+
+		// field paths
+		So(normalized, ShouldContainSubstring, normalizeSpaces("Address: UsersAddressFieldPaths{\n"+
+			"Path: \"address\",\n"+
+			"City: \"address.city\",\n"+
+			"Path2: \"address.path\",\n"+
+			"}"))
+		So(normalized, ShouldContainSubstring, normalizeSpaces("Orders: UsersOrdersFieldPaths{\n"+
+			"Path: \"orders\",\n"+
+			"Sku: \"orders.sku\",\n"+
+			"Amount: \"orders.amount\",\n"+
+			"}"))
+		So(normalized, ShouldContainSubstring, normalizeSpaces("Payload: UsersPayloadFieldPaths{\n"+
+			"Path: \"payload\",\n"+
+			"Kind: \"payload.kind\",\n"+
+			"X: \"payload.x\",\n"+
+			"Page: \"payload.page\",\n"+
+			"}"))
+		So(normalized, ShouldContainSubstring, "UsersFields = UsersFieldPaths{")
+		So(normalized, ShouldContainSubstring, `Labels: "labels",`)
+	})
+
+	Convey("Get Models Literal With Duplicate Names", t, func() {
+		usersAddress := collection.NewCollection(
+			metadata.InitMetadata("users_address"),
+			[]collection.Field{
+				field.StringField("city"),
+			},
+			nil,
+		)
+
+		builder, literal := getTestModelsLiteral(users, usersAddress)
+
+		So(builder.typeNames["UsersAddress2"], ShouldBeTrue)
+		So(literal, ShouldContainSubstring, "UsersAddressFields = UsersAddressFieldPaths2{")
+	})
+}
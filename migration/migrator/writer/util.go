@@ -9,7 +9,12 @@ package writer
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/amirkode/go-mongr8/internal/config"
 	"github.com/amirkode/go-mongr8/internal/validation"
@@ -20,29 +25,56 @@ const (
 )
 
 func getMigrationVarNames() ([]string, error) {
-	res := []string{}
 	rootPath, err := config.GetProjectRootDir()
 	if err != nil {
-		return res, err
+		return []string{}, err
 	}
 
-	path := fmt.Sprintf("%s/%s", *rootPath, baseMigrationPath)
-	collectionFileNames := config.GetAllFileNames(path)
-	for _, name := range collectionFileNames {
-		if !validation.ValidateWithRegex(name, `^\d{8}_\d{6}.go$`) {
+	return getDeclaredMigrationVarNames(fmt.Sprintf("%s/%s", *rootPath, baseMigrationPath))
+}
+
+// This returns the migration variables, i.e: Migration1,
+// declared on the top level of the migration package files in `path`
+func getDeclaredMigrationVarNames(path string) ([]string, error) {
+	res := []string{}
+	fset := token.NewFileSet()
+	migrationFileNames := config.GetAllFileNames(path)
+	for _, name := range migrationFileNames {
+		if strings.HasSuffix(name, "_test.go") {
 			continue
 		}
 
 		filePath := fmt.Sprintf("%s/%s", path, name)
-		codeStr := config.GetFileContent(filePath)
-		matchedStr := validation.FindWithRegex(codeStr, `Migration[0-9]+`)
-		if matchedStr == "" {
-			continue
+		file, err := parser.ParseFile(fset, filePath, nil, parser.SkipObjectResolution)
+		if err != nil {
+			// the error contains the position, i.e: 20240101_000000.go:10:2: expected ...
+			return nil, fmt.Errorf("cannot parse migration file: %w", err)
 		}
 
-		res = append(res, matchedStr)
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				for _, ident := range spec.(*ast.ValueSpec).Names {
+					if validation.ValidateWithRegex(ident.Name, `^Migration[0-9]+$`) {
+						res = append(res, ident.Name)
+					}
+				}
+			}
+		}
 	}
 
+	// the files are not listed in order
+	sort.SliceStable(res, func(i, j int) bool {
+		suffixI, _ := strconv.Atoi(res[i][9:])
+		suffixJ, _ := strconv.Atoi(res[j][9:])
+
+		return suffixI < suffixJ
+	})
+
 	return res, nil
 }
 
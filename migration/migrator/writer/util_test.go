@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDeclaredMigrationVarNames(t *testing.T) {
+	writeFile := func(dir, name, source string) {
+		So(os.WriteFile(filepath.Join(dir, name), []byte(source), 0644), ShouldBeNil)
+	}
+
+	Convey("Get Declared Migration Var Names", t, func() {
+		dir := t.TempDir()
+		writeFile(dir, "20240101_000000.go", "package migration\n\nvar Migration1 = migrator.Migration{ID: \"Migration9\"}\n")
+		writeFile(dir, "20240102_000000.go", "package migration\n\n// Migration3 is renamed\nvar (\n\tMigration2 = migrator.Migration{}\n\tmigrationHelper = 1\n)\n")
+		writeFile(dir, "base.go", "package migration\n\nfunc GetAllMigrations() []migrator.Migration {\n\treturn []migrator.Migration{Migration1, Migration2}\n}\n")
+
+		names, err := getDeclaredMigrationVarNames(dir)
+
+		So(err, ShouldBeNil)
+		So(names, ShouldResemble, []string{"Migration1", "Migration2"})
+	})
+
+	Convey("Get Declared Migration Var Names With Unparseable File", t, func() {
+		dir := t.TempDir()
+		writeFile(dir, "20240101_000000.go", "package migration\n\nvar Migration1 = migrator.Migration{\n")
+
+		_, err := getDeclaredMigrationVarNames(dir)
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "20240101_000000.go:3:")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"time"
 )
 
 const (
@@ -20,7 +21,18 @@ const (
 	MigrationOptionArgUseForceConversion  = "use-force-conversion"
 	MigrationOptionArgUseSchemaValidation = "use-schema-validation"
 	MigrationOptionArgUseTransaction      = "use-transaction"
+	MigrationOptionArgUseModelGeneration  = "use-model-generation"
 	MigrationOptionArgDesc                = "desc"
+	MigrationOptionArgRollbackSteps       = "steps"
+	MigrationOptionArgRollbackTo          = "to"
+	MigrationOptionArgDryRun              = "dry-run"
+	MigrationOptionArgAllowOutOfOrder     = "allow-out-of-order"
+	MigrationOptionArgUseJson             = "json"
+	MigrationOptionArgLockTimeout         = "lock-timeout"
+	MigrationOptionArgRepairID            = "id"
+	MigrationOptionArgRepairMark          = "mark"
+	MigrationOptionArgDuplicateReport     = "duplicate-report"
+	MigrationOptionArgAllowIndexDrop      = "allow-index-drop"
 )
 
 type (
@@ -29,7 +41,28 @@ type (
 		UseForceConversion  bool
 		UseSchemaValidation bool
 		UseTransaction      bool
+		UseModelGeneration  bool
 		Desc                string
+		// number of latest applied migrations to revert
+		RollbackSteps int
+		// revert every applied migration newer than this migration ID
+		RollbackTo string
+		// print planned commands without executing them
+		DryRun bool
+		// apply pending migrations older than the latest applied migration
+		AllowOutOfOrder bool
+		// print the output in JSON format
+		UseJson bool
+		// maximum duration to wait for the migration lock held by another process
+		LockTimeout time.Duration
+		// migration ID to repair, the only dirty migration if empty
+		RepairID string
+		// repaired state, either "clean" or "dirty"
+		RepairMark string
+		// file path to write the duplicate key report, when a unique index cannot be created
+		DuplicateReport string
+		// drop an index conflicting with its rebuilt index before creating it
+		AllowIndexDrop bool
 	}
 )
 
@@ -42,7 +75,18 @@ func GetMigrationOptionFromArgs() MigrationOption {
 	flag.BoolVar(&opt.UseForceConversion, MigrationOptionArgUseForceConversion, false, "Define option for forced conversion on migration")
 	flag.BoolVar(&opt.UseSchemaValidation, MigrationOptionArgUseSchemaValidation, false, "Define option for Schema Validation on migration")
 	flag.BoolVar(&opt.UseTransaction, MigrationOptionArgUseTransaction, false, "Define option for Transaction Usage on migration")
+	flag.BoolVar(&opt.UseModelGeneration, MigrationOptionArgUseModelGeneration, false, "Define option for Go models generation of the collections")
 	flag.StringVar(&opt.Desc, MigrationOptionArgDesc, "", "Define option for Schema Validation on migration")
+	flag.IntVar(&opt.RollbackSteps, MigrationOptionArgRollbackSteps, 0, "Define option for number of migrations to rollback")
+	flag.StringVar(&opt.RollbackTo, MigrationOptionArgRollbackTo, "", "Define option for target migration ID to rollback to")
+	flag.BoolVar(&opt.DryRun, MigrationOptionArgDryRun, false, "Define option for simulating migration without executing it")
+	flag.BoolVar(&opt.AllowOutOfOrder, MigrationOptionArgAllowOutOfOrder, false, "Define option for applying migrations older than the latest applied migration")
+	flag.BoolVar(&opt.UseJson, MigrationOptionArgUseJson, false, "Define option for JSON output")
+	flag.DurationVar(&opt.LockTimeout, MigrationOptionArgLockTimeout, 0, "Define option for migration lock waiting timeout")
+	flag.StringVar(&opt.RepairID, MigrationOptionArgRepairID, "", "Define option for migration ID to repair")
+	flag.StringVar(&opt.RepairMark, MigrationOptionArgRepairMark, "", "Define option for repaired migration state")
+	flag.StringVar(&opt.DuplicateReport, MigrationOptionArgDuplicateReport, "", "Define option for duplicate key report file path of unique indexes")
+	flag.BoolVar(&opt.AllowIndexDrop, MigrationOptionArgAllowIndexDrop, false, "Define option for dropping an index conflicting with its rebuilt index first")
 	flag.Parse()
 
 	return opt
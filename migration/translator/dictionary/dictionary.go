@@ -12,16 +12,22 @@ import (
 )
 
 type (
+	// @see schema_validation_impl.go for implementation
 	SchemaValidationIf interface {
 		// serialize current schema to string
-		toJsonString()
+		toJsonString() string
 		// collection schema represented as a map doc
 		// the schema validation generated from collection.Fields()
 		getCollectionDoc() map[string]interface{}
+		// get validator document of a collection, i.e: {"$jsonSchema": {...}}
+		GetValidator() map[string]interface{}
+		// check whether both validators are identical
+		Equal(other SchemaValidationIf) bool
 	}
 
 	SchemaValidation struct {
 		SchemaValidationIf
+		fields []collection.Field
 	}
 
 	// translated field to bson.M doc
@@ -8,10 +8,17 @@ Licensed under the MIT License
 package dictionary
 
 import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/internal/util"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type (
@@ -48,10 +55,42 @@ type (
 		TranslatedField
 	}
 
+	translatedMap struct {
+		TranslatedField
+	}
+
+	translatedOneOf struct {
+		TranslatedField
+	}
+
 	translatedTimestamp struct {
 		TranslatedField
 	}
 
+	translatedDefault struct {
+		TranslatedField
+	}
+
+	translatedObjectID struct {
+		TranslatedField
+	}
+
+	translatedDecimal128 struct {
+		TranslatedField
+	}
+
+	translatedBinary struct {
+		TranslatedField
+	}
+
+	translatedUUID struct {
+		TranslatedField
+	}
+
+	translatedDate struct {
+		TranslatedField
+	}
+
 	// base geo json
 	translatedGeoJSON struct {
 		TranslatedField
@@ -276,6 +315,69 @@ func (t translatedObject) GetArray() []interface{} {
 	return Array()
 }
 
+// translation for map field type
+func newTranslatedMap(field collection.Field) translatedMap {
+	return translatedMap{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedMap) GetObject() map[string]interface{} {
+	// the keys are arbitrary, so the empty format of a map has no key
+	key := t.field.Spec().Name
+	return map[string]interface{}{
+		key: map[string]interface{}{},
+	}
+}
+
+func (t translatedMap) GetArray() []interface{} {
+	return Array()
+}
+
+// translation for one of field type
+func newTranslatedOneOf(field collection.Field) translatedOneOf {
+	return translatedOneOf{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedOneOf) GetObject() map[string]interface{} {
+	res := map[string]interface{}{}
+	dropField := false
+	if t.field.Spec().Extra != nil {
+		if val, ok := t.field.Spec().Extra[field.ExtraDrop]; ok {
+			dropField, ok = val.(bool)
+			if !ok {
+				panic("ExtraDrop must be a boolean")
+			}
+		}
+	}
+	if variants := t.field.Spec().GetVariants(); !dropField && len(variants) > 0 {
+		// the empty format of a one of is its first variant,
+		// along with the discriminator value of the variant
+		variant := variants[0]
+		variantObj := GetTranslatedField(field.FromFieldSpec(&variant)).GetObject()
+		for key, value := range variantObj[variant.Name].(map[string]interface{}) {
+			res[key] = value
+		}
+		res[t.field.Spec().GetDiscriminator()] = String(variant.Name)
+	}
+
+	key := t.field.Spec().Name
+
+	return map[string]interface{}{
+		key: res,
+	}
+}
+
+func (t translatedOneOf) GetArray() []interface{} {
+	return Array()
+}
+
 // translation for timestamp field type
 func newTranslatedTimestamp(field collection.Field) translatedTimestamp {
 	return translatedTimestamp{
@@ -296,6 +398,269 @@ func (t translatedTimestamp) GetArray() []interface{} {
 	return Array()
 }
 
+// This returns a copy of typed maps and slices as map[string]interface{} and []interface{}
+func toGenericValue(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice:
+		res := []interface{}{}
+		for i := 0; i < rv.Len(); i++ {
+			res = append(res, toGenericValue(rv.Index(i).Interface()))
+		}
+
+		return res
+	case reflect.Map:
+		res := map[string]interface{}{}
+		for _, key := range rv.MapKeys() {
+			res[fmt.Sprintf("%v", key.Interface())] = toGenericValue(rv.MapIndex(key).Interface())
+		}
+
+		return res
+	}
+
+	return value
+}
+
+// This returns the default value of a field converted to the field type,
+// so the stored value matches the field type regardless of the declared Go type, i.e:
+// - an int is stored as an int64 on an int64 field
+// - a hex string is stored as an ObjectID on an ObjectID field
+func getDefaultValue(_field collection.Field) (interface{}, error) {
+	value, ok := _field.Spec().GetDefault()
+	if !ok {
+		return nil, fmt.Errorf("Default value is not declared on field: %s", _field.Spec().Name)
+	}
+
+	return toFieldValue(_field, "Default value", value)
+}
+
+// This returns the allowed values of a field converted to the field type
+func getEnumValues(_field collection.Field) ([]interface{}, error) {
+	res := []interface{}{}
+	for _, value := range _field.Spec().GetEnum() {
+		converted, err := toFieldValue(_field, "Enum value", value)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, converted)
+	}
+
+	return res, nil
+}
+
+// This converts a declared value to the field type,
+// `label` describes the value on the returned error
+func toFieldValue(_field collection.Field, label string, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, fmt.Errorf("%s must not be nil on field: %s", label, _field.Spec().Name)
+	}
+
+	invalid := fmt.Errorf("%s %v (%T) is invalid for %s field: %s", label, value, value, _field.Spec().Type, _field.Spec().Name)
+	isInteger := util.InListEq(reflect.TypeOf(value).Kind(), []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64})
+	switch _field.Spec().Type {
+	case field.TypeString:
+		if v, ok := value.(string); ok {
+			return String(v), nil
+		}
+	case field.TypeBoolean:
+		if v, ok := value.(bool); ok {
+			return Boolean(v), nil
+		}
+	case field.TypeInt32:
+		if isInteger {
+			v := reflect.ValueOf(value).Int()
+			if v >= math.MinInt32 && v <= math.MaxInt32 {
+				return Int32(int32(v)), nil
+			}
+		}
+	case field.TypeInt64:
+		if isInteger {
+			return Int64(reflect.ValueOf(value).Int()), nil
+		}
+	case field.TypeDouble:
+		if isInteger {
+			return Float64(float64(reflect.ValueOf(value).Int())), nil
+		}
+
+		if reflect.TypeOf(value).Kind() == reflect.Float32 || reflect.TypeOf(value).Kind() == reflect.Float64 {
+			return Float64(reflect.ValueOf(value).Float()), nil
+		}
+	case field.TypeDecimal128:
+		str := ""
+		switch v := value.(type) {
+		case primitive.Decimal128:
+			return Decimal128(v), nil
+		case string:
+			str = v
+		case float32, float64:
+			str = strconv.FormatFloat(reflect.ValueOf(v).Float(), 'f', -1, 64)
+		default:
+			if isInteger {
+				str = strconv.FormatInt(reflect.ValueOf(v).Int(), 10)
+			}
+		}
+
+		if dec, err := primitive.ParseDecimal128(str); err == nil {
+			return Decimal128(dec), nil
+		}
+	case field.TypeTimestamp, field.TypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return Time(v), nil
+		case string:
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return Time(t), nil
+			}
+		}
+	case field.TypeObjectID:
+		switch v := value.(type) {
+		case primitive.ObjectID:
+			return ObjectID(v), nil
+		case string:
+			if id, err := primitive.ObjectIDFromHex(v); err == nil {
+				return ObjectID(id), nil
+			}
+		}
+	case field.TypeBinary, field.TypeUUID:
+		return nil, fmt.Errorf("%s is not available for %s field: %s", label, _field.Spec().Type, _field.Spec().Name)
+	default:
+		// geo json and legacy coordinate are declared as a map or an array
+		kind := reflect.TypeOf(value).Kind()
+		if kind == reflect.Map || kind == reflect.Slice {
+			return ConvertAnyToValueType(toGenericValue(value)), nil
+		}
+	}
+
+	return nil, invalid
+}
+
+// translation for field with default value
+func newTranslatedDefault(field collection.Field) translatedDefault {
+	return translatedDefault{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedDefault) GetObject() map[string]interface{} {
+	value, err := getDefaultValue(t.field)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	key := t.field.Spec().Name
+	return map[string]interface{}{
+		key: value,
+	}
+}
+
+func (t translatedDefault) GetArray() []interface{} {
+	return Array()
+}
+
+// translation for object id field type
+func newTranslatedObjectID(field collection.Field) translatedObjectID {
+	return translatedObjectID{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedObjectID) GetObject() map[string]interface{} {
+	key := t.field.Spec().Name
+	return map[string]interface{}{
+		key: ObjectID(primitive.NilObjectID),
+	}
+}
+
+func (t translatedObjectID) GetArray() []interface{} {
+	return Array()
+}
+
+// translation for decimal128 field type
+func newTranslatedDecimal128(field collection.Field) translatedDecimal128 {
+	return translatedDecimal128{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedDecimal128) GetObject() map[string]interface{} {
+	key := t.field.Spec().Name
+	return map[string]interface{}{
+		key: Decimal128(primitive.NewDecimal128(0, 0)),
+	}
+}
+
+func (t translatedDecimal128) GetArray() []interface{} {
+	return Array()
+}
+
+// translation for binary field type
+func newTranslatedBinary(field collection.Field) translatedBinary {
+	return translatedBinary{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedBinary) GetObject() map[string]interface{} {
+	key := t.field.Spec().Name
+	return map[string]interface{}{
+		key: Binary(primitive.Binary{Subtype: 0x00, Data: []byte{}}),
+	}
+}
+
+func (t translatedBinary) GetArray() []interface{} {
+	return Array()
+}
+
+// translation for uuid field type
+func newTranslatedUUID(field collection.Field) translatedUUID {
+	return translatedUUID{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedUUID) GetObject() map[string]interface{} {
+	key := t.field.Spec().Name
+	// nil UUID with the standard UUID subtype
+	return map[string]interface{}{
+		key: Binary(primitive.Binary{Subtype: 0x04, Data: make([]byte, 16)}),
+	}
+}
+
+func (t translatedUUID) GetArray() []interface{} {
+	return Array()
+}
+
+// translation for date field type
+func newTranslatedDate(field collection.Field) translatedDate {
+	return translatedDate{
+		TranslatedField{
+			field: field,
+		},
+	}
+}
+
+func (t translatedDate) GetObject() map[string]interface{} {
+	key := t.field.Spec().Name
+	return map[string]interface{}{
+		key: Time(time.Now()),
+	}
+}
+
+func (t translatedDate) GetArray() []interface{} {
+	return Array()
+}
+
 // Geo JSON Section
 func (t translatedGeoJSON) getCoordinateObject(key, _type string, child interface{}) map[string]interface{} {
 	return map[string]interface{}{
@@ -496,6 +861,11 @@ func (t translatedLegacyCoordinate) GetArray() []interface{} {
 
 // map field to correct translated field
 func GetTranslatedField(_field collection.Field) TranslatedFieldIf {
+	// a declared default value takes place of the empty value
+	if _, ok := _field.Spec().GetDefault(); ok {
+		return newTranslatedDefault(_field)
+	}
+
 	switch _field.Spec().Type {
 	case field.TypeString:
 		return newTranslatedString(_field)
@@ -511,8 +881,22 @@ func GetTranslatedField(_field collection.Field) TranslatedFieldIf {
 		return newTranslatedArray(_field)
 	case field.TypeObject:
 		return newTranslatedObject(_field)
+	case field.TypeMap:
+		return newTranslatedMap(_field)
+	case field.TypeOneOf:
+		return newTranslatedOneOf(_field)
 	case field.TypeTimestamp:
 		return newTranslatedTimestamp(_field)
+	case field.TypeObjectID:
+		return newTranslatedObjectID(_field)
+	case field.TypeDecimal128:
+		return newTranslatedDecimal128(_field)
+	case field.TypeBinary:
+		return newTranslatedBinary(_field)
+	case field.TypeUUID:
+		return newTranslatedUUID(_field)
+	case field.TypeDate:
+		return newTranslatedDate(_field)
 	case field.TypeGeoJSONPoint:
 		return newTranslatedGeoJSONPoint(_field)
 	case field.TypeGeoJSONLineString:
@@ -6,3 +6,97 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package dictionary
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/internal/test"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetDefaultValue(t *testing.T) {
+	id := primitive.NewObjectID()
+	price, _ := primitive.ParseDecimal128("10.5")
+	for i, c := range []struct {
+		field    collection.Field
+		expected interface{}
+	}{
+		{field.StringField("status").SetDefault("active"), String("active")},
+		{field.Int32Field("count").SetDefault(1), Int32(1)},
+		{field.Int64Field("count").SetDefault(1), Int64(1)},
+		{field.DoubleField("score").SetDefault(1), Float64(1)},
+		{field.Decimal128Field("price").SetDefault(10.5), Decimal128(price)},
+		{field.Decimal128Field("price").SetDefault("10.5"), Decimal128(price)},
+		{field.DateField("created_at").SetDefault("2024-01-01T00:00:00Z"), Time(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))},
+		{field.ObjectIDField("owner_id").SetDefault(id.Hex()), ObjectID(id)},
+		{field.LegacyCoordinateArrayField("location").SetDefault([]float64{0, 0}), []interface{}{Float64(0), Float64(0)}},
+	} {
+		actual, err := getDefaultValue(c.field)
+
+		test.AssertTrue(t, err == nil && reflect.DeepEqual(actual, c.expected), fmt.Sprintf("Case %d: Unexpected default value %v", i+1, actual))
+	}
+
+	// invalid values
+	for i, f := range []collection.Field{
+		field.Int32Field("count").SetDefault(int64(1) << 40),
+		field.BooleanField("active").SetDefault("true"),
+		field.ObjectIDField("owner_id").SetDefault("not a hex"),
+		field.UUIDField("token").SetDefault("00000000-0000-0000-0000-000000000000"),
+	} {
+		_, err := getDefaultValue(f)
+
+		test.AssertTrue(t, err != nil, fmt.Sprintf("Invalid case %d: Error expected", i+1))
+	}
+}
+
+func TestGetTranslatedFieldWithDefault(t *testing.T) {
+	translated := GetTranslatedField(field.ArrayField("tx_history",
+		field.ObjectField("",
+			field.StringField("status").SetDefault("active"),
+			field.Int32Field("retry"),
+		),
+	))
+
+	test.AssertTrue(t, reflect.DeepEqual(translated.GetObject(), map[string]interface{}{
+		"tx_history": []interface{}{
+			map[string]interface{}{
+				"status": String("active"),
+				"retry":  Int32(0),
+			},
+		},
+	}), "Case 1: Unexpected translated object")
+}
+
+func TestGetTranslatedMapField(t *testing.T) {
+	// a map is created without any key, since the keys are arbitrary
+	translated := GetTranslatedField(field.ObjectField("profile",
+		field.MapField("attributes", field.StringField("")),
+	))
+
+	test.AssertTrue(t, reflect.DeepEqual(translated.GetObject(), map[string]interface{}{
+		"profile": map[string]interface{}{
+			"attributes": map[string]interface{}{},
+		},
+	}), "Case 1: Unexpected translated object")
+}
+
+func TestGetTranslatedOneOfField(t *testing.T) {
+	// a one of is created as its first variant, along with the discriminator value
+	translated := GetTranslatedField(field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"view":  field.ObjectField("", field.StringField("page")),
+		"click": field.ObjectField("", field.Int32Field("x")),
+	}))
+
+	test.AssertTrue(t, reflect.DeepEqual(translated.GetObject(), map[string]interface{}{
+		"payload": map[string]interface{}{
+			"kind": String("click"),
+			"x":    Int32(0),
+		},
+	}), "Case 1: Unexpected translated object")
+}
@@ -40,6 +40,14 @@ type (
 		TranslatedIndex
 	}
 
+	translatedWildcard struct {
+		TranslatedIndex
+	}
+
+	translatedGeospatial2d struct {
+		TranslatedIndex
+	}
+
 	translatedRaw struct {
 		TranslatedIndex
 	}
@@ -123,10 +131,17 @@ func newTranslatedTextIndex(index collection.Index) translatedText {
 
 func (t translatedText) GetObject() map[string]interface{} {
 	t.hasAtLeastFieldsLengthValidation(1)
-	field := t.index.Spec().Fields[0]
-	return map[string]interface{}{
-		field.Key: String("text"),
+	// prefix and suffix keys of a compound text index keep their values
+	res := map[string]interface{}{}
+	for _, field := range t.index.Spec().Fields {
+		if field.Value == nil || field.Value == index.KeyText {
+			res[field.Key] = String(index.KeyText)
+		} else {
+			res[field.Key] = ConvertAnyToValueType(field.Value)
+		}
 	}
+
+	return res
 }
 
 func (t translatedText) GetRules() *map[string]interface{} {
@@ -175,6 +190,48 @@ func (t translatedHashed) GetRules() *map[string]interface{} {
 	return t.getRules()
 }
 
+// translation for wildcard index
+func newTranslatedWildcardIndex(index collection.Index) translatedWildcard {
+	return translatedWildcard{
+		TranslatedIndex{
+			index: index,
+		},
+	}
+}
+
+func (t translatedWildcard) GetObject() map[string]interface{} {
+	t.hasAtLeastFieldsLengthValidation(1)
+	field := t.index.Spec().Fields[0]
+	return map[string]interface{}{
+		field.Key: Int(1),
+	}
+}
+
+func (t translatedWildcard) GetRules() *map[string]interface{} {
+	return t.getRules()
+}
+
+// translation for geospatial: 2d index
+func newTranslatedGeospatial2dIndex(index collection.Index) translatedGeospatial2d {
+	return translatedGeospatial2d{
+		TranslatedIndex{
+			index: index,
+		},
+	}
+}
+
+func (t translatedGeospatial2d) GetObject() map[string]interface{} {
+	t.hasAtLeastFieldsLengthValidation(1)
+	field := t.index.Spec().Fields[0]
+	return map[string]interface{}{
+		field.Key: String(index.Key2d),
+	}
+}
+
+func (t translatedGeospatial2d) GetRules() *map[string]interface{} {
+	return t.getRules()
+}
+
 // translation for raw definition index
 func newTranslatedRawIndex(index collection.Index) translatedRaw {
 	return translatedRaw{
@@ -206,6 +263,10 @@ func GetTranslatedIndex(_index collection.Index) TranslatedIndexIf {
 		return newTranslatedGeospatial2dsphereIndex(_index)
 	case index.TypeHashed:
 		return newTranslatedHashedIndex(_index)
+	case index.TypeWildcard:
+		return newTranslatedWildcardIndex(_index)
+	case index.TypeGeospatial2d:
+		return newTranslatedGeospatial2dIndex(_index)
 	case index.TypeRaw:
 		return newTranslatedRawIndex(_index)
 	}
@@ -6,3 +6,41 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package dictionary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/internal/test"
+)
+
+func TestGetTranslatedIndex(t *testing.T) {
+	// Case 1: wildcard index
+	case1Object := GetTranslatedIndex(index.WildcardIndex("attributes")).GetObject()
+
+	test.AssertTrue(t, reflect.DeepEqual(case1Object, map[string]interface{}{"attributes.$**": Int(1)}), "Case 1: Unexpected object")
+
+	// Case 2: 2d index
+	case2Translated := GetTranslatedIndex(index.Geospatial2dIndex(index.Field("location")).SetBits(20))
+
+	test.AssertTrue(t, reflect.DeepEqual(case2Translated.GetObject(), map[string]interface{}{"location": String("2d")}), "Case 2: Unexpected object")
+	test.AssertTrue(t, reflect.DeepEqual(*case2Translated.GetRules(), map[string]interface{}{"bits": Int32(20)}), "Case 2: Unexpected rules")
+
+	// Case 3: compound text index
+	case3Object := GetTranslatedIndex(index.TextIndex(index.Field("category", 1), index.Field("title"), index.Field("body"))).GetObject()
+
+	test.AssertTrue(t, reflect.DeepEqual(case3Object, map[string]interface{}{
+		"category": Int(1),
+		"title":    String("text"),
+		"body":     String("text"),
+	}), "Case 3: Unexpected object")
+
+	// Case 4: compound index mixing 2dsphere and hashed keys
+	case4Object := GetTranslatedIndex(index.CompoundIndex(index.Field("location").As2dsphere(), index.Field("category").AsHashed())).GetObject()
+
+	test.AssertTrue(t, reflect.DeepEqual(case4Object, map[string]interface{}{
+		"location": String("2dsphere"),
+		"category": String("hashed"),
+	}), "Case 4: Unexpected object")
+}
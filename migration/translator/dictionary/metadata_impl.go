@@ -7,4 +7,72 @@ Licensed under the MIT License
 */
 package dictionary
 
-// some other operations in the future
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+)
+
+func getOption(meta collection.Metadata, option metadata.CollectionOption) (interface{}, bool) {
+	if meta.Spec().Options == nil {
+		return nil, false
+	}
+
+	value, ok := (*meta.Spec().Options)[option]
+
+	return value, ok
+}
+
+// This validates whether options of an existing collection can be modified from `from` to `to`
+// the modifiable options are:
+// - capped size, or capping a non-capped collection
+// - expiration (TTL)
+// - time series granularity or bucket span (increase only)
+func ValidateMetadataModification(from, to collection.Metadata) error {
+	name := to.Spec().Name
+	if from.Spec().Type != to.Spec().Type {
+		return fmt.Errorf("%s: Collection type cannot be modified from %s to %s", name, from.Spec().Type, to.Spec().Type)
+	}
+
+	_, fromCapped := getOption(from, metadata.CollectionOptionCapped)
+	_, toCapped := getOption(to, metadata.CollectionOptionCapped)
+	if fromCapped && !toCapped {
+		return fmt.Errorf("%s: Capped collection cannot be uncapped", name)
+	}
+
+	// these options are only defined on collection creation
+	for _, option := range []metadata.CollectionOption{
+		metadata.CollectionOptionTimeField,
+		metadata.CollectionOptionMetaField,
+		metadata.CollectionOptionCollation,
+		metadata.CollectionOptionViewOn,
+		metadata.CollectionOptionPipeline,
+	} {
+		fromValue, _ := getOption(from, option)
+		toValue, _ := getOption(to, option)
+		if !reflect.DeepEqual(fromValue, toValue) {
+			return fmt.Errorf("%s: Collection option %s cannot be modified", name, option)
+		}
+	}
+
+	fromGranularity, fromHasGranularity := getOption(from, metadata.CollectionOptionGranularity)
+	toGranularity, toHasGranularity := getOption(to, metadata.CollectionOptionGranularity)
+	fromMaxSpan, fromHasBucketSpan := getOption(from, metadata.CollectionOptionBucketMaxSpanSeconds)
+	toMaxSpan, toHasBucketSpan := getOption(to, metadata.CollectionOptionBucketMaxSpanSeconds)
+	if fromHasGranularity != toHasGranularity || fromHasBucketSpan != toHasBucketSpan {
+		return fmt.Errorf("%s: Time series bucketing cannot be switched between granularity and bucket span", name)
+	}
+
+	// granularity of time series collection can only be increased
+	if fromHasGranularity && toGranularity.(metadata.TimeSeriesGranularity).CompareTo(fromGranularity.(metadata.TimeSeriesGranularity)) < 0 {
+		return fmt.Errorf("%s: Time series granularity cannot be decreased from %s to %s", name, fromGranularity, toGranularity)
+	}
+
+	if fromHasBucketSpan && toMaxSpan.(int64) < fromMaxSpan.(int64) {
+		return fmt.Errorf("%s: Time series bucket span cannot be decreased from %d to %d", name, fromMaxSpan, toMaxSpan)
+	}
+
+	return nil
+}
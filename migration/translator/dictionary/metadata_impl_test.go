@@ -6,3 +6,57 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package dictionary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/internal/test"
+)
+
+func TestValidateMetadataModification(t *testing.T) {
+	// Case 1: capped size and TTL can be modified, and a collection can be capped
+	case1Err := ValidateMetadataModification(
+		metadata.InitMetadata("logs").Capped(1024).TTL(60),
+		metadata.InitMetadata("logs").Capped(2048).TTL(120),
+	)
+
+	test.AssertTrue(t, case1Err == nil, "Case 1: Unexpected error")
+
+	case1CapErr := ValidateMetadataModification(metadata.InitMetadata("logs"), metadata.InitMetadata("logs").Capped(1024))
+
+	test.AssertTrue(t, case1CapErr == nil, "Case 1: Unexpected error")
+
+	// Case 2: uncapping
+	case2Err := ValidateMetadataModification(metadata.InitMetadata("logs").Capped(1024), metadata.InitMetadata("logs"))
+
+	test.AssertTrue(t, case2Err != nil && strings.Contains(case2Err.Error(), "uncapped"), "Case 2: Unexpected error")
+
+	// Case 3: time series granularity
+	case3Err := ValidateMetadataModification(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularitySeconds),
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularityHours),
+	)
+
+	test.AssertTrue(t, case3Err == nil, "Case 3: Unexpected error")
+
+	case3DecreaseErr := ValidateMetadataModification(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularityHours),
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularityMinutes),
+	)
+
+	test.AssertTrue(t, case3DecreaseErr != nil && strings.Contains(case3DecreaseErr.Error(), "decreased"), "Case 3: Unexpected error")
+
+	// Case 4: options defined on creation
+	case4Err := ValidateMetadataModification(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp"),
+		metadata.InitMetadata("metrics").TimeSeries("created_at"),
+	)
+
+	test.AssertTrue(t, case4Err != nil && strings.Contains(case4Err.Error(), "timeField"), "Case 4: Unexpected error")
+
+	case4TypeErr := ValidateMetadataModification(metadata.InitMetadata("users"), metadata.InitMetadata("users").AsView())
+
+	test.AssertTrue(t, case4TypeErr != nil && strings.Contains(case4TypeErr.Error(), "type"), "Case 4: Unexpected error")
+}
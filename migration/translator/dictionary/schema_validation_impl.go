@@ -0,0 +1,299 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package dictionary
+
+import (
+	"encoding/json"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+)
+
+// based on https://www.mongodb.com/docs/manual/reference/operator/query/jsonSchema/
+func getBsonType(fieldType field.FieldType) string {
+	switch fieldType {
+	case field.TypeString:
+		return "string"
+	case field.TypeInt32:
+		return "int"
+	case field.TypeInt64:
+		return "long"
+	case field.TypeDouble:
+		return "double"
+	case field.TypeBoolean:
+		return "bool"
+	case field.TypeArray, field.TypeLegacyCoordinateArray:
+		return "array"
+	case field.TypeTimestamp, field.TypeDate:
+		// timestamp field is stored as a BSON date
+		return "date"
+	case field.TypeObjectID:
+		return "objectId"
+	case field.TypeDecimal128:
+		return "decimal"
+	case field.TypeBinary, field.TypeUUID:
+		return "binData"
+	}
+
+	// objects, embedded documents, and geo json shapes
+	return "object"
+}
+
+func getGeoJSONTypeName(fieldType field.FieldType) string {
+	switch fieldType {
+	case field.TypeGeoJSONPoint:
+		return "Point"
+	case field.TypeGeoJSONLineString:
+		return "LineString"
+	case field.TypeGeoJSONPolygonSingleRing, field.TypeGeoJSONPolygonMultipleRing:
+		return "Polygon"
+	case field.TypeGeoJSONMultiPoint:
+		return "MultiPoint"
+	case field.TypeGeoJSONMultiLineString:
+		return "MultiLineString"
+	case field.TypeGeoJSONMultiPolygon:
+		return "MultiPolygon"
+	case field.TypeGeoJSONGeometryCollection:
+		return "GeometryCollection"
+	}
+
+	return ""
+}
+
+// this returns properties and required field names of an object
+func getObjectSchema(fields []collection.Field) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+	for _, f := range fields {
+		properties[f.Spec().Name] = getFieldSchema(f)
+		if !f.Spec().Nullable {
+			required = append(required, f.Spec().Name)
+		}
+	}
+
+	res := map[string]interface{}{
+		"bsonType":   "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		res["required"] = required
+	}
+
+	return res
+}
+
+// this returns the schema of a one of field,
+// a document must match exactly one variant, which is picked by the discriminator value
+func getOneOfSchema(f collection.Field) map[string]interface{} {
+	discriminator := f.Spec().GetDiscriminator()
+	values := []interface{}{}
+	variants := []interface{}{}
+	for _, variant := range f.Spec().GetVariants() {
+		fields := []collection.Field{}
+		if variant.Object != nil {
+			fields = collection.FieldsFromSpecs(variant.Object)
+		}
+
+		schema := getObjectSchema(fields)
+		schema["properties"].(map[string]interface{})[discriminator] = map[string]interface{}{
+			"enum": []interface{}{variant.Name},
+		}
+		required, _ := schema["required"].([]interface{})
+		schema["required"] = append([]interface{}{discriminator}, required...)
+
+		values = append(values, variant.Name)
+		variants = append(variants, schema)
+	}
+
+	// a variant only accepts an object, so null needs its own entry
+	if f.Spec().Nullable {
+		variants = append(variants, map[string]interface{}{"bsonType": "null"})
+	}
+
+	return map[string]interface{}{
+		"bsonType": "object",
+		"required": []interface{}{discriminator},
+		"properties": map[string]interface{}{
+			discriminator: map[string]interface{}{"enum": values},
+		},
+		"oneOf": variants,
+	}
+}
+
+func getFieldSchema(f collection.Field) map[string]interface{} {
+	var res map[string]interface{}
+	switch f.Spec().Type {
+	case field.TypeArray:
+		res = map[string]interface{}{
+			"bsonType": "array",
+		}
+
+		items := []interface{}{}
+		if f.Spec().ArrayFields != nil {
+			for _, item := range *f.Spec().ArrayFields {
+				items = append(items, getFieldSchema(field.FromFieldSpec(&item)))
+			}
+		}
+
+		if len(items) == 1 {
+			res["items"] = items[0]
+		} else if len(items) > 1 {
+			res["items"] = map[string]interface{}{
+				"anyOf": items,
+			}
+		}
+	case field.TypeObject, field.TypeLegacyCoordinateEmbeddedDoc:
+		res = getObjectSchema(collection.FieldsFromSpecs(f.Spec().Object))
+	case field.TypeMap:
+		res = map[string]interface{}{
+			"bsonType": "object",
+		}
+
+		// any key is allowed, but its value must follow the map value
+		if value := f.Spec().GetMapValue(); value != nil {
+			res["additionalProperties"] = getFieldSchema(field.FromFieldSpec(value))
+		}
+	case field.TypeOneOf:
+		res = getOneOfSchema(f)
+	case field.TypeLegacyCoordinateArray:
+		res = map[string]interface{}{
+			"bsonType": "array",
+			"items": map[string]interface{}{
+				"bsonType": []interface{}{"double", "int", "long"},
+			},
+		}
+	case field.TypeGeoJSONGeometryCollection:
+		res = map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"type", "geometries"},
+			"properties": map[string]interface{}{
+				"type":       map[string]interface{}{"enum": []interface{}{getGeoJSONTypeName(f.Spec().Type)}},
+				"geometries": map[string]interface{}{"bsonType": "array"},
+			},
+		}
+	case field.TypeGeoJSONPoint,
+		field.TypeGeoJSONLineString,
+		field.TypeGeoJSONPolygonSingleRing,
+		field.TypeGeoJSONPolygonMultipleRing,
+		field.TypeGeoJSONMultiPoint,
+		field.TypeGeoJSONMultiLineString,
+		field.TypeGeoJSONMultiPolygon:
+		res = map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"type", "coordinates"},
+			"properties": map[string]interface{}{
+				"type":        map[string]interface{}{"enum": []interface{}{getGeoJSONTypeName(f.Spec().Type)}},
+				"coordinates": map[string]interface{}{"bsonType": "array"},
+			},
+		}
+	default:
+		res = map[string]interface{}{
+			"bsonType": getBsonType(f.Spec().Type),
+		}
+	}
+
+	setFieldConstraints(f, res)
+
+	// nullable field accepts null on top of its own type
+	if f.Spec().Nullable {
+		res["bsonType"] = []interface{}{res["bsonType"], "null"}
+	}
+
+	return res
+}
+
+// This sets the value constraints of a field to its schema
+// reference: https://www.mongodb.com/docs/manual/reference/operator/query/jsonSchema/#available-keywords
+func setFieldConstraints(f collection.Field, schema map[string]interface{}) {
+	spec := f.Spec()
+	if !spec.HasConstraints() {
+		return
+	}
+
+	if len(spec.GetEnum()) > 0 {
+		values, err := getEnumValues(f)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		enum := []interface{}{}
+		for _, value := range values {
+			enum = append(enum, value.(ValueType).Value)
+		}
+
+		// enum is checked regardless of the bson type
+		if spec.Nullable {
+			enum = append(enum, nil)
+		}
+
+		schema["enum"] = enum
+	}
+
+	if value, ok := spec.GetMin(); ok {
+		schema["minimum"] = value
+	}
+
+	if value, ok := spec.GetMax(); ok {
+		schema["maximum"] = value
+	}
+
+	if value, ok := spec.GetMinLength(); ok {
+		schema["minLength"] = value
+	}
+
+	if value, ok := spec.GetMaxLength(); ok {
+		schema["maxLength"] = value
+	}
+
+	if value, ok := spec.GetPattern(); ok {
+		schema["pattern"] = value
+	}
+
+	if value, ok := spec.GetMinItems(); ok {
+		schema["minItems"] = value
+	}
+
+	if value, ok := spec.GetMaxItems(); ok {
+		schema["maxItems"] = value
+	}
+
+	if spec.IsUniqueItems() {
+		schema["uniqueItems"] = true
+	}
+}
+
+func NewSchemaValidation(fields []collection.Field) SchemaValidation {
+	return SchemaValidation{
+		fields: fields,
+	}
+}
+
+func (v SchemaValidation) getCollectionDoc() map[string]interface{} {
+	return getObjectSchema(v.fields)
+}
+
+func (v SchemaValidation) toJsonString() string {
+	// map keys are sorted on serialization
+	res, err := json.Marshal(v.getCollectionDoc())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return string(res)
+}
+
+func (v SchemaValidation) GetValidator() map[string]interface{} {
+	return map[string]interface{}{
+		"$jsonSchema": v.getCollectionDoc(),
+	}
+}
+
+func (v SchemaValidation) Equal(other SchemaValidationIf) bool {
+	return v.toJsonString() == other.toJsonString()
+}
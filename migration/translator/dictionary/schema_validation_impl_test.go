@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSchemaValidation(t *testing.T) {
+	fields := []collection.Field{
+		field.StringField("name"),
+		field.Int32Field("age").SetNullable(),
+		field.ObjectField("address",
+			field.StringField("city"),
+			field.GeoJSONPointField("location"),
+		),
+		field.ArrayField("scores",
+			field.DoubleField("score"),
+		),
+		field.TimestampField("created_at"),
+	}
+
+	Convey("Get Validator", t, func() {
+		validator := NewSchemaValidation(fields).GetValidator()
+		schema := validator["$jsonSchema"].(map[string]interface{})
+		So(schema["bsonType"], ShouldEqual, "object")
+		So(schema["required"], ShouldResemble, []interface{}{"name", "address", "scores", "created_at"})
+
+		properties := schema["properties"].(map[string]interface{})
+		So(properties["name"], ShouldResemble, map[string]interface{}{"bsonType": "string"})
+		So(properties["age"], ShouldResemble, map[string]interface{}{"bsonType": []interface{}{"int", "null"}})
+		So(properties["created_at"], ShouldResemble, map[string]interface{}{"bsonType": "date"})
+		So(properties["scores"], ShouldResemble, map[string]interface{}{
+			"bsonType": "array",
+			"items":    map[string]interface{}{"bsonType": "double"},
+		})
+
+		address := properties["address"].(map[string]interface{})
+		So(address["required"], ShouldResemble, []interface{}{"city", "location"})
+		location := address["properties"].(map[string]interface{})["location"].(map[string]interface{})
+		So(location["required"], ShouldResemble, []interface{}{"type", "coordinates"})
+		So(location["properties"].(map[string]interface{})["type"], ShouldResemble, map[string]interface{}{
+			"enum": []interface{}{"Point"},
+		})
+	})
+
+	Convey("Get Validator With BSON Types", t, func() {
+		validator := NewSchemaValidation([]collection.Field{
+			field.ObjectIDField("_id"),
+			field.Decimal128Field("price"),
+			field.UUIDField("token"),
+			field.BinaryField("thumbnail").SetNullable(),
+			field.DateField("paid_at"),
+		}).GetValidator()
+		properties := validator["$jsonSchema"].(map[string]interface{})["properties"].(map[string]interface{})
+		So(properties["_id"], ShouldResemble, map[string]interface{}{"bsonType": "objectId"})
+		So(properties["price"], ShouldResemble, map[string]interface{}{"bsonType": "decimal"})
+		So(properties["token"], ShouldResemble, map[string]interface{}{"bsonType": "binData"})
+		So(properties["thumbnail"], ShouldResemble, map[string]interface{}{"bsonType": []interface{}{"binData", "null"}})
+		So(properties["paid_at"], ShouldResemble, map[string]interface{}{"bsonType": "date"})
+	})
+
+	Convey("Get Validator With Constraints", t, func() {
+		validator := NewSchemaValidation([]collection.Field{
+			field.StringField("status").Enum("active", "inactive").SetNullable(),
+			field.StringField("code").MinLength(2).MaxLength(4).Pattern("^[A-Z]+$"),
+			field.Int64Field("age").Enum(1, 2).Min(0).Max(150),
+			field.ArrayField("tags", field.StringField("")).MinItems(1).MaxItems(5).UniqueItems(),
+		}).GetValidator()
+		properties := validator["$jsonSchema"].(map[string]interface{})["properties"].(map[string]interface{})
+		So(properties["status"], ShouldResemble, map[string]interface{}{
+			"bsonType": []interface{}{"string", "null"},
+			"enum":     []interface{}{"active", "inactive", nil},
+		})
+		So(properties["code"], ShouldResemble, map[string]interface{}{
+			"bsonType":  "string",
+			"minLength": 2,
+			"maxLength": 4,
+			"pattern":   "^[A-Z]+$",
+		})
+		So(properties["age"], ShouldResemble, map[string]interface{}{
+			"bsonType": "long",
+			"enum":     []interface{}{int64(1), int64(2)},
+			"minimum":  float64(0),
+			"maximum":  float64(150),
+		})
+		So(properties["tags"], ShouldResemble, map[string]interface{}{
+			"bsonType":    "array",
+			"items":       map[string]interface{}{"bsonType": "string"},
+			"minItems":    1,
+			"maxItems":    5,
+			"uniqueItems": true,
+		})
+	})
+
+	Convey("Get Validator With Map", t, func() {
+		validator := NewSchemaValidation([]collection.Field{
+			field.MapField("attributes", field.ObjectField("", field.StringField("label"))),
+		}).GetValidator()
+		properties := validator["$jsonSchema"].(map[string]interface{})["properties"].(map[string]interface{})
+		So(properties["attributes"], ShouldResemble, map[string]interface{}{
+			"bsonType": "object",
+			"additionalProperties": map[string]interface{}{
+				"bsonType": "object",
+				"required": []interface{}{"label"},
+				"properties": map[string]interface{}{
+					"label": map[string]interface{}{"bsonType": "string"},
+				},
+			},
+		})
+	})
+
+	Convey("Get Validator With One Of", t, func() {
+		validator := NewSchemaValidation([]collection.Field{
+			field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+				"click": field.ObjectField("", field.Int32Field("x")),
+				"view":  field.ObjectField("", field.StringField("page").SetNullable()),
+			}).SetNullable(),
+		}).GetValidator()
+		properties := validator["$jsonSchema"].(map[string]interface{})["properties"].(map[string]interface{})
+		So(properties["payload"], ShouldResemble, map[string]interface{}{
+			"bsonType": []interface{}{"object", "null"},
+			"required": []interface{}{"kind"},
+			"properties": map[string]interface{}{
+				"kind": map[string]interface{}{"enum": []interface{}{"click", "view"}},
+			},
+			"oneOf": []interface{}{
+				map[string]interface{}{
+					"bsonType": "object",
+					"required": []interface{}{"kind", "x"},
+					"properties": map[string]interface{}{
+						"kind": map[string]interface{}{"enum": []interface{}{"click"}},
+						"x":    map[string]interface{}{"bsonType": "int"},
+					},
+				},
+				map[string]interface{}{
+					"bsonType": "object",
+					"required": []interface{}{"kind"},
+					"properties": map[string]interface{}{
+						"kind": map[string]interface{}{"enum": []interface{}{"view"}},
+						"page": map[string]interface{}{"bsonType": []interface{}{"string", "null"}},
+					},
+				},
+				map[string]interface{}{"bsonType": "null"},
+			},
+		})
+	})
+
+	Convey("Equal", t, func() {
+		So(NewSchemaValidation(fields).Equal(NewSchemaValidation(fields)), ShouldBeTrue)
+		So(NewSchemaValidation(fields).Equal(NewSchemaValidation(fields[:1])), ShouldBeFalse)
+		// a changed constraint must produce a different validator
+		So(NewSchemaValidation([]collection.Field{field.Int32Field("age").Max(100)}).Equal(
+			NewSchemaValidation([]collection.Field{field.Int32Field("age").Max(150)}),
+		), ShouldBeFalse)
+	})
+}
@@ -9,13 +9,20 @@ package dictionary
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
 	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
 	"github.com/amirkode/go-mongr8/internal/util"
 	"github.com/amirkode/go-mongr8/migration/common"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (v *Validation) Validate() error {
@@ -35,6 +42,9 @@ func (v *Validation) initValidationFunctions() {
 		func() error {
 			return validateCollections(v.Collections)
 		},
+		func() error {
+			return validateViews(v.Collections)
+		},
 	}
 
 	for _, coll := range v.Collections {
@@ -47,6 +57,9 @@ func (v *Validation) initValidationFunctions() {
 		v.validationFuncs = append(v.validationFuncs, func() error {
 			return validateIndexes(coll.Collection().Spec().Name, coll.Fields(), coll.Indexes())
 		})
+		v.validationFuncs = append(v.validationFuncs, func() error {
+			return validateTimeSeries(coll)
+		})
 	}
 }
 
@@ -59,6 +72,11 @@ func validateCollections(collections []collection.Collection) error {
 			return fmt.Errorf("Collection name cannot be %s", common.MigrationHistoryCollection)
 		}
 
+		// neither the migration lock collection name
+		if coll.Collection().Spec().Name == common.MigrationLockCollection {
+			return fmt.Errorf("Collection name cannot be %s", common.MigrationLockCollection)
+		}
+
 		_, ok := dup[coll.Collection().Spec().Name]
 		if ok {
 			return fmt.Errorf("Duplicate collection found with name: %s", coll.Collection().Spec().Name)
@@ -70,21 +88,125 @@ func validateCollections(collections []collection.Collection) error {
 	return nil
 }
 
+func validateViews(collections []collection.Collection) error {
+	// map of view name and its source
+	views := map[string]string{}
+	for _, coll := range collections {
+		spec := coll.Collection().Spec()
+		if spec.Type != metadata.TypeViewCollection {
+			continue
+		}
+
+		if spec.GetViewOn() == "" {
+			return fmt.Errorf("%s: View must declare its source collection", spec.Name)
+		}
+
+		if len(coll.Indexes()) > 0 {
+			return fmt.Errorf("%s: View cannot have any index", spec.Name)
+		}
+
+		for _, opt := range []metadata.CollectionOption{
+			metadata.CollectionOptionCapped,
+			metadata.CollectionOptionExpiredAfterSeconds,
+			metadata.CollectionOptionTimeField,
+		} {
+			if _, ok := (*spec.Options)[opt]; ok {
+				return fmt.Errorf("%s: View cannot have %s option", spec.Name, opt)
+			}
+		}
+
+		views[spec.Name] = spec.GetViewOn()
+	}
+
+	// a view cannot be defined on itself, either directly or through other views
+	for name, source := range views {
+		visited := map[string]bool{name: true}
+		for curr := source; ; {
+			if visited[curr] {
+				return fmt.Errorf("%s: Circular view definition found on %s", name, curr)
+			}
+
+			next, ok := views[curr]
+			if !ok {
+				break
+			}
+
+			visited[curr] = true
+			curr = next
+		}
+	}
+
+	return nil
+}
+
+func validateTimeSeries(coll collection.Collection) error {
+	spec := coll.Collection().Spec()
+	if !spec.IsTimeSeries() {
+		return nil
+	}
+
+	opts := *spec.Options
+	if _, ok := opts[metadata.CollectionOptionCapped]; ok {
+		return fmt.Errorf("%s: Time series collection cannot be capped", spec.Name)
+	}
+
+	_, hasGranularity := opts[metadata.CollectionOptionGranularity]
+	maxSpan, hasBucketSpan := opts[metadata.CollectionOptionBucketMaxSpanSeconds]
+	if hasGranularity && hasBucketSpan {
+		return fmt.Errorf("%s: Time series collection cannot have both granularity and bucket span", spec.Name)
+	}
+
+	if hasBucketSpan && maxSpan != opts[metadata.CollectionOptionBucketRoundingSeconds] {
+		return fmt.Errorf("%s: Bucket max span and rounding of time series collection must be equal", spec.Name)
+	}
+
+	// time and meta fields must be declared on the top level
+	fields := map[string]collection.Field{}
+	for _, f := range coll.Fields() {
+		fields[f.Spec().Name] = f
+	}
+
+	timeField, ok := fields[spec.GetTimeField()]
+	if !ok || !timeField.Spec().Type.IsDate() {
+		return fmt.Errorf("%s: Time field %s must be declared as a timestamp or date field", spec.Name, spec.GetTimeField())
+	}
+
+	if metaField := spec.GetMetaField(); metaField != "" {
+		if _, ok := fields[metaField]; !ok || metaField == spec.GetTimeField() || metaField == "_id" {
+			return fmt.Errorf("%s: Meta field %s must be declared as other field than the time field and _id", spec.Name, metaField)
+		}
+	}
+
+	for _, idx := range coll.Indexes() {
+		if idx.Spec().HasRule(index.OptionUnique) && (*idx.Spec().Rules)[index.OptionUnique] == true {
+			return fmt.Errorf("%s: Time series collection cannot have unique index %s", spec.Name, idx.Spec().GetName())
+		}
+	}
+
+	return nil
+}
+
 func validateID(collectionName string, fields []collection.Field) error {
 	// allowed _id field types:
 	// - default Object ID
 	// - integers
 	// - double/float
+	// - decimal
 	// - string
+	// - binary/UUID
 	for _, _field := range fields {
 		if _field.Spec().Name == "_id" {
 			if !util.InListEq(_field.Spec().Type, []field.FieldType{
+				field.TypeObjectID,
 				field.TypeInt32,
 				field.TypeInt64,
 				field.TypeDouble,
+				field.TypeDecimal128,
 				field.TypeString,
+				field.TypeBinary,
+				field.TypeUUID,
 			}) {
-				return fmt.Errorf("%s: ID field type invalid. Allowed types: integers, double, and string", collectionName)
+				return fmt.Errorf("%s: ID field type invalid. Allowed types: ObjectID, integers, double, decimal, string, binary, and UUID", collectionName)
 			}
 		}
 	}
@@ -138,6 +260,18 @@ func validateIndividualField(collectionName, path string, _field collection.Fiel
 		}
 	}
 
+	// default value must be compatible with the field type
+	if _, ok := _field.Spec().GetDefault(); ok {
+		if _, err := getDefaultValue(_field); err != nil {
+			return fmt.Errorf("%s: %s, path: %s", collectionName, err.Error(), path)
+		}
+	}
+
+	// value constraints must be consistent with the field type and each other
+	if err := validateFieldConstraints(_field); err != nil {
+		return fmt.Errorf("%s: %s, path: %s", collectionName, err.Error(), path)
+	}
+
 	if path != "" {
 		path += "."
 	}
@@ -158,6 +292,20 @@ func validateIndividualField(collectionName, path string, _field collection.Fiel
 				return err
 			}
 		}
+	case field.TypeMap:
+		if _field.Spec().GetMapValue() == nil {
+			return fmt.Errorf("%s: Map value must not be empty for path: %s, type: %s", collectionName, path, _field.Spec().Type.ToString())
+		}
+
+		if len(*_field.Spec().ArrayFields) != 1 {
+			return fmt.Errorf("%s: Map must have exactly 1 value for path: %s, type: %s", collectionName, path, _field.Spec().Type.ToString())
+		}
+
+		// the value has no name, since the keys are arbitrary
+		err := validateIndividualField(collectionName, path+_field.Spec().Name, collection.FieldFromSpec(_field.Spec().GetMapValue()), true)
+		if err != nil {
+			return err
+		}
 	case field.TypeObject:
 		if _field.Spec().Object == nil {
 			return fmt.Errorf("%s: Object must not be empty for path: %s, type: %s", collectionName, path, _field.Spec().Type.ToString())
@@ -169,11 +317,168 @@ func validateIndividualField(collectionName, path string, _field collection.Fiel
 				return err
 			}
 		}
+	case field.TypeOneOf:
+		discriminator := _field.Spec().GetDiscriminator()
+		if discriminator == "" {
+			return fmt.Errorf("%s: Discriminator key must not be empty for path: %s, type: %s", collectionName, path, _field.Spec().Type.ToString())
+		}
+
+		if _field.Spec().Object == nil || len(*_field.Spec().Object) == 0 {
+			return fmt.Errorf("%s: One of must have at least 1 variant for path: %s, type: %s", collectionName, path, _field.Spec().Type.ToString())
+		}
+
+		for _, variant := range *_field.Spec().Object {
+			if variant.Name == "" {
+				return fmt.Errorf("%s: Variant value must not be empty for path: %s%s", collectionName, path, _field.Spec().Name)
+			}
+
+			if variant.Type != field.TypeObject {
+				return fmt.Errorf("%s: Variant %s must be an object for path: %s%s", collectionName, variant.Name, path, _field.Spec().Name)
+			}
+
+			// the variant itself is not a key of the document, so only its children are validated
+			if variant.Object == nil {
+				continue
+			}
+
+			for _, child := range *variant.Object {
+				if child.Name == discriminator {
+					return fmt.Errorf("%s: Variant %s must not declare the discriminator key %s for path: %s%s", collectionName, variant.Name, discriminator, path, _field.Spec().Name)
+				}
+
+				err := validateIndividualField(collectionName, path+_field.Spec().Name, collection.FieldFromSpec(&child), false)
+				if err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
+// This validates the value constraints of a field,
+// including the default value against the declared constraints
+func validateFieldConstraints(_field collection.Field) error {
+	spec := _field.Spec()
+	name := spec.Name
+	isString := spec.Type == field.TypeString
+	isArray := spec.Type == field.TypeArray
+
+	// the constraints might be set directly via extra, so the type is checked here as well
+	available := []dt.Pair[field.FieldExtra, bool]{
+		dt.NewPair(field.ExtraEnum, isString || spec.Type.IsNumeric()),
+		dt.NewPair(field.ExtraMin, spec.Type.IsNumeric()),
+		dt.NewPair(field.ExtraMax, spec.Type.IsNumeric()),
+		dt.NewPair(field.ExtraMinLength, isString),
+		dt.NewPair(field.ExtraMaxLength, isString),
+		dt.NewPair(field.ExtraPattern, isString),
+		dt.NewPair(field.ExtraMinItems, isArray),
+		dt.NewPair(field.ExtraMaxItems, isArray),
+		dt.NewPair(field.ExtraUniqueItems, isArray),
+	}
+	for _, constraint := range available {
+		if _, ok := spec.Extra[constraint.First]; ok && !constraint.Second {
+			return fmt.Errorf("Constraint %s is not available for %s field: %s", constraint.First, spec.Type, name)
+		}
+	}
+
+	enum, err := getEnumValues(_field)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := spec.Extra[field.ExtraEnum]; ok && len(enum) == 0 {
+		return fmt.Errorf("Enum must have at least a value on field: %s", name)
+	}
+
+	minValue, hasMin := spec.GetMin()
+	maxValue, hasMax := spec.GetMax()
+	if hasMin && hasMax && minValue > maxValue {
+		return fmt.Errorf("Min %v must not be greater than Max %v on field: %s", minValue, maxValue, name)
+	}
+
+	minLength, hasMinLength := spec.GetMinLength()
+	maxLength, hasMaxLength := spec.GetMaxLength()
+	if (hasMinLength && minLength < 0) || (hasMaxLength && maxLength < 0) {
+		return fmt.Errorf("MinLength and MaxLength must not be negative on field: %s", name)
+	}
+
+	if hasMinLength && hasMaxLength && minLength > maxLength {
+		return fmt.Errorf("MinLength %d must not be greater than MaxLength %d on field: %s", minLength, maxLength, name)
+	}
+
+	minItems, hasMinItems := spec.GetMinItems()
+	maxItems, hasMaxItems := spec.GetMaxItems()
+	if (hasMinItems && minItems < 0) || (hasMaxItems && maxItems < 0) {
+		return fmt.Errorf("MinItems and MaxItems must not be negative on field: %s", name)
+	}
+
+	if hasMinItems && hasMaxItems && minItems > maxItems {
+		return fmt.Errorf("MinItems %d must not be greater than MaxItems %d on field: %s", minItems, maxItems, name)
+	}
+
+	// the pattern is evaluated by MongoDB with PCRE,
+	// here it's only ensured that the expression is well formed
+	var pattern *regexp.Regexp
+	if expr, ok := spec.GetPattern(); ok {
+		if pattern, err = regexp.Compile(expr); err != nil {
+			return fmt.Errorf("Pattern %s is invalid on field: %s, %s", expr, name, err.Error())
+		}
+	}
+
+	// the default value is set on existing documents, so it must satisfy the constraints
+	if _, ok := spec.GetDefault(); !ok || !spec.HasConstraints() {
+		return nil
+	}
+
+	defaultValue, err := getDefaultValue(_field)
+	if err != nil {
+		return err
+	}
+
+	value := defaultValue.(ValueType).Value
+	if len(enum) > 0 && !util.InListEq(defaultValue, enum) {
+		return fmt.Errorf("Default value %v is not one of the Enum values on field: %s", value, name)
+	}
+
+	if number, ok := toFloat64(value); ok {
+		if (hasMin && number < minValue) || (hasMax && number > maxValue) {
+			return fmt.Errorf("Default value %v is out of the Min and Max bounds on field: %s", value, name)
+		}
+	}
+
+	if str, ok := value.(string); ok {
+		length := utf8.RuneCountInString(str)
+		if (hasMinLength && length < minLength) || (hasMaxLength && length > maxLength) {
+			return fmt.Errorf("Default value %s is out of the MinLength and MaxLength bounds on field: %s", str, name)
+		}
+
+		if pattern != nil && !pattern.MatchString(str) {
+			return fmt.Errorf("Default value %s does not match the Pattern on field: %s", str, name)
+		}
+	}
+
+	return nil
+}
+
+// converts a numeric value to float64 for bounds comparison
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case primitive.Decimal128:
+		res, err := strconv.ParseFloat(v.String(), 64)
+		return res, err == nil
+	}
+
+	return 0, false
+}
+
 func validateFields(collectionName string, fields []collection.Field) error {
 	err := validateFieldDuplication(collectionName, fields)
 	if err != nil {
@@ -205,6 +510,37 @@ func validateIndexDuplication(collectionName string, indexes []collection.Index)
 	return nil
 }
 
+// This returns the value of a map field named by `key`,
+// so a path through arbitrary keys can be resolved as a fixed path
+func mapValueOfKey(_field collection.Field, key string) collection.Field {
+	value := _field.Spec().GetMapValue()
+	if value == nil {
+		return nil
+	}
+
+	spec := *value
+	spec.Name = key
+
+	return collection.FieldFromSpec(&spec)
+}
+
+// This returns the discriminator and the fields of all variants of a one of field,
+// so a path through any variant can be resolved as an object path
+func oneOfChildren(_field collection.Field) []collection.Field {
+	res := []collection.Field{field.StringField(_field.Spec().GetDiscriminator())}
+	if _field.Spec().Object == nil {
+		return res
+	}
+
+	for _, variant := range *_field.Spec().Object {
+		if variant.Object != nil {
+			res = append(res, collection.FieldsFromSpecs(variant.Object)...)
+		}
+	}
+
+	return res
+}
+
 func validateIndexWithFields(collectionName string, fields []collection.Field, _index collection.Index) error {
 	// Index Fields cannot be empty
 	if len(_index.Spec().Fields) == 0 {
@@ -241,6 +577,18 @@ func validateIndexWithFields(collectionName string, fields []collection.Field, _
 						}
 					}
 				}
+			case field.TypeMap:
+				// any key matches the map value
+				if value := mapValueOfKey(_field, path[1]); value != nil {
+					res = fieldExists(path[1:], value)
+				}
+			case field.TypeOneOf:
+				for _, child := range oneOfChildren(_field) {
+					res = fieldExists(path[1:], child)
+					if res {
+						break
+					}
+				}
 			}
 		} else {
 			res = true
@@ -277,6 +625,17 @@ func validateIndexWithFields(collectionName string, fields []collection.Field, _
 						}
 					}
 				}
+			case field.TypeMap:
+				// any key matches the map value
+				if value := mapValueOfKey(_field, path[1]); value != nil {
+					return checkFieldType(path[1:], value, expectedType)
+				}
+			case field.TypeOneOf:
+				for _, child := range oneOfChildren(_field) {
+					if checkFieldType(path[1:], child, expectedType) {
+						return true
+					}
+				}
 			}
 		}
 
@@ -307,9 +666,28 @@ func validateIndexWithFields(collectionName string, fields []collection.Field, _
 		return ok
 	}
 
+	pathHasAnyType := func(path []string, expectedTypes []field.FieldType) bool {
+		for _, expectedType := range expectedTypes {
+			if pathHasType(path, expectedType) {
+				return true
+			}
+		}
+
+		return false
+	}
+
 	// cross checking index keys x fields
 	for _, indexField := range _index.Spec().Fields {
-		path := strings.Split(indexField.Key, ".")
+		key := indexField.Key
+		if _index.Spec().Type == index.TypeWildcard {
+			// a wildcard index on all fields has no path to check
+			key = index.GetWildcardPath(key)
+			if key == "" {
+				continue
+			}
+		}
+
+		path := strings.Split(key, ".")
 		if !pathExists(path) {
 			return fmt.Errorf("%s: index key is invalid: %s", collectionName, indexField.Key)
 		}
@@ -317,6 +695,77 @@ func validateIndexWithFields(collectionName string, fields []collection.Field, _
 
 	// validate by index type
 	switch _index.Spec().Type {
+	case index.TypeWildcard:
+		if _index.Spec().HasRule(index.OptionUnique) || _index.Spec().HasRule(index.OptionTTL) {
+			return fmt.Errorf("%s: Wildcard index cannot be unique or TTL: %s", collectionName, _index.Spec().GetName())
+		}
+
+		if _index.Spec().HasRule(index.OptionWildcardProjection) {
+			if _index.Spec().Fields[0].Key != index.KeyWildcard {
+				return fmt.Errorf("%s: Wildcard projection is only available for wildcard index on all fields: %s", collectionName, _index.Spec().GetName())
+			}
+
+			projection := (*_index.Spec().Rules)[index.OptionWildcardProjection].(map[string]interface{})
+			for key := range projection {
+				if key != "_id" && !pathExists(strings.Split(key, ".")) {
+					return fmt.Errorf("%s: Wildcard projection key is invalid: %s", collectionName, key)
+				}
+			}
+		}
+	case index.TypeGeospatial2d:
+		path := strings.Split(_index.Spec().Fields[0].Key, ".")
+		if !pathHasAnyType(path, []field.FieldType{field.TypeLegacyCoordinateArray, field.TypeLegacyCoordinateEmbeddedDoc}) {
+			return fmt.Errorf("%s: 2d index key must be a legacy coordinate field: %s", collectionName, _index.Spec().Fields[0].Key)
+		}
+
+		if _index.Spec().HasRule(index.OptionMin) && _index.Spec().HasRule(index.OptionMax) &&
+			(*_index.Spec().Rules)[index.OptionMin].(float64) >= (*_index.Spec().Rules)[index.OptionMax].(float64) {
+			return fmt.Errorf("%s: 2d index min bound must be less than max bound: %s", collectionName, _index.Spec().GetName())
+		}
+
+		if _index.Spec().HasRule(index.OptionBits) {
+			bits := (*_index.Spec().Rules)[index.OptionBits].(int32)
+			if bits < 1 || bits > 32 {
+				return fmt.Errorf("%s: 2d index bits must be between 1 and 32: %s", collectionName, _index.Spec().GetName())
+			}
+		}
+	case index.TypeText:
+		textKeys := map[string]bool{}
+		for _, indexField := range _index.Spec().Fields {
+			if indexField.Value == index.KeyText {
+				textKeys[indexField.Key] = true
+			}
+		}
+
+		if _index.Spec().HasRule(index.OptionWeights) {
+			weights := (*_index.Spec().Rules)[index.OptionWeights].(map[string]interface{})
+			for key := range weights {
+				if !textKeys[key] {
+					return fmt.Errorf("%s: Text index weight key is not a text key: %s", collectionName, key)
+				}
+			}
+		}
+	case index.TypeCompound:
+		hashedCount := 0
+		for _, indexField := range _index.Spec().Fields {
+			path := strings.Split(indexField.Key, ".")
+			switch indexField.Value {
+			case index.KeyHashed:
+				hashedCount++
+			case index.Key2dsphere:
+				if !pathHasAnyType(path, field.GetGeoTypes()) {
+					return fmt.Errorf("%s: 2dsphere index key must be a GeoJSON or legacy coordinate field: %s", collectionName, indexField.Key)
+				}
+			}
+		}
+
+		if hashedCount > 1 {
+			return fmt.Errorf("%s: Compound index can only have a hashed key: %s", collectionName, _index.Spec().GetName())
+		}
+
+		if hashedCount > 0 && _index.Spec().HasRule(index.OptionUnique) {
+			return fmt.Errorf("%s: Compound index with a hashed key cannot be unique: %s", collectionName, _index.Spec().GetName())
+		}
 		// TODO: complete if the usecase is clear
 		// related to this commit, migt be moved here:
 		// https://github.com/amirkode/go-mongr8/commit/45060b493e03b7631b5c81b2684f760d10305d09
@@ -347,7 +796,7 @@ func validateIndexWithFields(collectionName string, fields []collection.Field, _
 			ok := false
 			for _, indexField := range _index.Spec().Fields {
 				path := strings.Split(indexField.Key, ".")
-				ok = pathHasType(path, field.TypeTimestamp)
+				ok = pathHasAnyType(path, []field.FieldType{field.TypeTimestamp, field.TypeDate})
 				if ok {
 					break
 				}
@@ -368,10 +817,20 @@ func validateIndexes(collectionName string, fields []collection.Field, indexes [
 		return err
 	}
 
+	textIndexCount := 0
 	for _, _index := range indexes {
 		if err = validateIndexWithFields(collectionName, fields, _index); err != nil {
 			return err
 		}
+
+		if _index.Spec().Type == index.TypeText {
+			textIndexCount++
+		}
+	}
+
+	// https://www.mongodb.com/docs/manual/core/indexes/index-types/index-text/text-index-restrictions/
+	if textIndexCount > 1 {
+		return fmt.Errorf("%s: A collection can only have a text index", collectionName)
 	}
 
 	return nil
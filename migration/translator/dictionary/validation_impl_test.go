@@ -27,6 +27,12 @@ func TestValidateCollections(t *testing.T) {
 
 	test.AssertTrue(t, case1Err != nil && strings.Contains(case1Err.Error(), common.MigrationHistoryCollection), "Case 1: Unxpected error")
 
+	case1LockErr := validateCollections([]collection.Collection{
+		collection.NewCollection(metadata.InitMetadata(common.MigrationLockCollection), []collection.Field{}, []collection.Index{}),
+	})
+
+	test.AssertTrue(t, case1LockErr != nil && strings.Contains(case1LockErr.Error(), common.MigrationLockCollection), "Case 1: Unxpected error")
+
 	// Case 2: duplicate collection names
 	case2Err := validateCollections([]collection.Collection{
 		collection.NewCollection(metadata.InitMetadata("collection1"), []collection.Field{}, []collection.Index{}),
@@ -44,6 +50,97 @@ func TestValidateCollections(t *testing.T) {
 	test.AssertTrue(t, case3Err == nil, "Case 1: Unxpected error")
 }
 
+func TestValidateViews(t *testing.T) {
+	users := collection.NewCollection(metadata.InitMetadata("users"), []collection.Field{field.StringField("status")}, []collection.Index{})
+
+	// Case 1: view without source
+	case1Err := validateViews([]collection.Collection{
+		collection.NewCollection(metadata.InitMetadata("active_users").AsView(), []collection.Field{}, []collection.Index{}),
+	})
+
+	test.AssertTrue(t, case1Err != nil && strings.Contains(case1Err.Error(), "source collection"), "Case 1: Unexpected error")
+
+	// Case 2: view with an index
+	case2Err := validateViews([]collection.Collection{
+		users,
+		collection.NewCollection(
+			metadata.InitMetadata("active_users").ViewOn("users", nil),
+			[]collection.Field{field.StringField("status")},
+			[]collection.Index{index.SingleFieldIndex(index.Field("status", 1))},
+		),
+	})
+
+	test.AssertTrue(t, case2Err != nil && strings.Contains(case2Err.Error(), "index"), "Case 2: Unexpected error")
+
+	// Case 3: circular views
+	case3Err := validateViews([]collection.Collection{
+		collection.NewCollection(metadata.InitMetadata("view1").ViewOn("view2", nil), []collection.Field{}, []collection.Index{}),
+		collection.NewCollection(metadata.InitMetadata("view2").ViewOn("view1", nil), []collection.Field{}, []collection.Index{}),
+	})
+
+	test.AssertTrue(t, case3Err != nil && strings.Contains(case3Err.Error(), "Circular"), "Case 3: Unexpected error")
+
+	// Case 4: views are valid, including a view of another view
+	case4Err := validateViews([]collection.Collection{
+		users,
+		collection.NewCollection(metadata.InitMetadata("active_users").ViewOn("users", []map[string]interface{}{
+			{"$match": map[string]interface{}{"status": "active"}},
+		}), []collection.Field{}, []collection.Index{}),
+		collection.NewCollection(metadata.InitMetadata("active_user_names").ViewOn("active_users", nil), []collection.Field{}, []collection.Index{}),
+	})
+
+	test.AssertTrue(t, case4Err == nil, "Case 4: Unexpected error")
+}
+
+func TestValidateTimeSeries(t *testing.T) {
+	fields := []collection.Field{
+		field.TimestampField("timestamp"),
+		field.StringField("device"),
+		field.DoubleField("value"),
+	}
+
+	// Case 1: capped time series collection
+	case1Err := validateTimeSeries(collection.NewCollection(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").Capped(1024), fields, []collection.Index{},
+	))
+
+	test.AssertTrue(t, case1Err != nil && strings.Contains(case1Err.Error(), "capped"), "Case 1: Unexpected error")
+
+	// Case 2: unique index
+	case2Err := validateTimeSeries(collection.NewCollection(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp"),
+		fields,
+		[]collection.Index{index.SingleFieldIndex(index.Field("device", 1)).AsUnique()},
+	))
+
+	test.AssertTrue(t, case2Err != nil && strings.Contains(case2Err.Error(), "unique index"), "Case 2: Unexpected error")
+
+	// Case 3: time field is not a timestamp
+	case3Err := validateTimeSeries(collection.NewCollection(
+		metadata.InitMetadata("metrics").TimeSeries("device"), fields, []collection.Index{},
+	))
+
+	test.AssertTrue(t, case3Err != nil && strings.Contains(case3Err.Error(), "Time field"), "Case 3: Unexpected error")
+
+	// Case 4: both granularity and bucket span
+	case4Err := validateTimeSeries(collection.NewCollection(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularityHours).SetBucketSpan(60, 60),
+		fields,
+		[]collection.Index{},
+	))
+
+	test.AssertTrue(t, case4Err != nil && strings.Contains(case4Err.Error(), "granularity"), "Case 4: Unexpected error")
+
+	// Case 5: valid time series collection
+	case5Err := validateTimeSeries(collection.NewCollection(
+		metadata.InitMetadata("metrics").TimeSeries("timestamp").SetMetaField("device").SetGranularity(metadata.GranularityMinutes).TTL(3600),
+		fields,
+		[]collection.Index{index.SingleFieldIndex(index.Field("device", 1))},
+	))
+
+	test.AssertTrue(t, case5Err == nil, "Case 5: Unexpected error")
+}
+
 func TestValidateID(t *testing.T) {
 	// Case 1: Unallowed type object
 	case1Err := validateID("collection_name", []collection.Field{field.ObjectField("_id")})
@@ -64,6 +161,21 @@ func TestValidateID(t *testing.T) {
 	case4Err := validateID("collection_name", []collection.Field{field.DoubleField("_id")})
 
 	test.AssertTrue(t, case4Err == nil, "Case 4: Unexpected error")
+
+	// Case 5: Allowed field object id
+	case5Err := validateID("collection_name", []collection.Field{field.ObjectIDField("_id")})
+
+	test.AssertTrue(t, case5Err == nil, "Case 5: Unexpected error")
+
+	// Case 6: Allowed field UUID
+	case6Err := validateID("collection_name", []collection.Field{field.UUIDField("_id")})
+
+	test.AssertTrue(t, case6Err == nil, "Case 6: Unexpected error")
+
+	// Case 7: Unallowed type date
+	case7Err := validateID("collection_name", []collection.Field{field.DateField("_id")})
+
+	test.AssertTrue(t, case7Err != nil && strings.Contains(case7Err.Error(), "ID field type invalid"), "Case 7: Unexpected error")
 }
 
 func TestValidateFieldDuplication(t *testing.T) {
@@ -148,6 +260,59 @@ func TestValidateIndividualField(t *testing.T) {
 	), false)
 
 	test.AssertTrue(t, case6Err == nil, "Case 6: Unexpected error")
+
+	// Case 7: Map field without value
+	case7Err := validateIndividualField("collection_name", "", field.MapField("attributes", nil), false)
+
+	test.AssertTrue(t, case7Err != nil && strings.Contains(case7Err.Error(), "Map value must not be empty"), "Case 7: Unexpected error")
+
+	// Case 8: Map of object field with empty name
+	case8Err := validateIndividualField("collection_name", "", field.MapField("attributes",
+		field.ObjectField("", field.StringField("")),
+	), false)
+
+	test.AssertTrue(t, case8Err != nil && strings.Contains(case8Err.Error(), "Field name must not be empty"), "Case 8: Unexpected error")
+
+	// Case 9: Map of object field
+	case9Err := validateIndividualField("collection_name", "", field.MapField("attributes",
+		field.ObjectField("", field.StringField("label")),
+	), false)
+
+	test.AssertTrue(t, case9Err == nil, "Case 9: Unexpected error")
+
+	// Case 10: One of field without discriminator key
+	case10Err := validateIndividualField("collection_name", "", field.OneOf("payload", "", map[string]*field.FieldSpec{
+		"click": field.ObjectField("", field.Int32Field("x")),
+	}), false)
+
+	test.AssertTrue(t, case10Err != nil && strings.Contains(case10Err.Error(), "Discriminator key must not be empty"), "Case 10: Unexpected error")
+
+	// Case 11: One of field without variant
+	case11Err := validateIndividualField("collection_name", "", field.OneOf("payload", "kind", nil), false)
+
+	test.AssertTrue(t, case11Err != nil && strings.Contains(case11Err.Error(), "must have at least 1 variant"), "Case 11: Unexpected error")
+
+	// Case 12: Variant declaring the discriminator key
+	case12Err := validateIndividualField("collection_name", "", field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"click": field.ObjectField("", field.StringField("kind"), field.Int32Field("x")),
+	}), false)
+
+	test.AssertTrue(t, case12Err != nil && strings.Contains(case12Err.Error(), "must not declare the discriminator key"), "Case 12: Unexpected error")
+
+	// Case 13: Variant field with empty name
+	case13Err := validateIndividualField("collection_name", "", field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"click": field.ObjectField("", field.Int32Field("")),
+	}), false)
+
+	test.AssertTrue(t, case13Err != nil && strings.Contains(case13Err.Error(), "Field name must not be empty"), "Case 13: Unexpected error")
+
+	// Case 14: One of field
+	case14Err := validateIndividualField("collection_name", "", field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"click": field.ObjectField("", field.Int32Field("x")),
+		"view":  field.ObjectField("", field.StringField("page")),
+	}), false)
+
+	test.AssertTrue(t, case14Err == nil, "Case 14: Unexpected error")
 }
 
 func TestValidateFields(t *testing.T) {
@@ -170,6 +335,72 @@ func TestValidateFields(t *testing.T) {
 	)})
 
 	test.AssertTrue(t, case3Err == nil, "Case 6: Unexpected error")
+
+	// Case 4: Default values inside an array of object
+	case4Err := validateFields("collection_name", []collection.Field{field.ArrayField("arr",
+		field.ObjectField("",
+			field.StringField("status").SetDefault("active"),
+			field.Int64Field("count").SetDefault(1),
+			field.DateField("created_at").SetDefault("2024-01-01T00:00:00Z"),
+		),
+	)})
+
+	test.AssertTrue(t, case4Err == nil, "Case 4: Unexpected error")
+
+	// Case 5: Default value incompatible with the field type
+	case5Err := validateFields("collection_name", []collection.Field{field.ObjectField("info",
+		field.Int32Field("count").SetDefault("one"),
+	)})
+
+	test.AssertTrue(t, case5Err != nil && strings.Contains(case5Err.Error(), "Default value one (string) is invalid"), "Case 5: Unexpected error")
+
+	// Case 6: Valid constraints with a default value satisfying them
+	case6Err := validateFields("collection_name", []collection.Field{
+		field.StringField("status").Enum("active", "inactive").SetDefault("active"),
+		field.StringField("code").MinLength(2).MaxLength(4).Pattern("^[A-Z]+$").SetDefault("ID"),
+		field.Int32Field("age").Enum(1, 2, 3).Min(1).Max(3).SetDefault(2),
+		field.ArrayField("tags", field.StringField("")).MinItems(1).MaxItems(5).UniqueItems(),
+	})
+
+	test.AssertTrue(t, case6Err == nil, "Case 6: Unexpected error")
+
+	// Case 7: Enum value incompatible with the field type
+	case7Err := validateFields("collection_name", []collection.Field{field.Int32Field("age").Enum(1, "two")})
+
+	test.AssertTrue(t, case7Err != nil && strings.Contains(case7Err.Error(), "Enum value two (string) is invalid"), "Case 7: Unexpected error")
+
+	// Case 8: Min greater than Max
+	case8Err := validateFields("collection_name", []collection.Field{field.DoubleField("score").Min(10).Max(1)})
+
+	test.AssertTrue(t, case8Err != nil && strings.Contains(case8Err.Error(), "must not be greater than Max"), "Case 8: Unexpected error")
+
+	// Case 9: Negative length and invalid pattern
+	case9Err := validateFields("collection_name", []collection.Field{field.StringField("code").MinLength(-1)})
+	case9PatternErr := validateFields("collection_name", []collection.Field{field.StringField("code").Pattern("[A-Z")})
+
+	test.AssertTrue(t, case9Err != nil && strings.Contains(case9Err.Error(), "must not be negative"), "Case 9: Unexpected error")
+	test.AssertTrue(t, case9PatternErr != nil && strings.Contains(case9PatternErr.Error(), "Pattern [A-Z is invalid"), "Case 9: Unexpected pattern error")
+
+	// Case 10: MinItems greater than MaxItems inside an object
+	case10Err := validateFields("collection_name", []collection.Field{field.ObjectField("info",
+		field.ArrayField("tags", field.StringField("")).MinItems(3).MaxItems(1),
+	)})
+
+	test.AssertTrue(t, case10Err != nil && strings.Contains(case10Err.Error(), "MinItems 3 must not be greater than MaxItems 1"), "Case 10: Unexpected error")
+
+	// Case 11: Default value violating the constraints
+	case11EnumErr := validateFields("collection_name", []collection.Field{field.StringField("status").Enum("active").SetDefault("deleted")})
+	case11BoundErr := validateFields("collection_name", []collection.Field{field.Int64Field("age").Min(18).SetDefault(0)})
+	case11PatternErr := validateFields("collection_name", []collection.Field{field.StringField("code").Pattern("^[A-Z]+$").SetDefault("id")})
+
+	test.AssertTrue(t, case11EnumErr != nil && strings.Contains(case11EnumErr.Error(), "is not one of the Enum values"), "Case 11: Unexpected enum error")
+	test.AssertTrue(t, case11BoundErr != nil && strings.Contains(case11BoundErr.Error(), "out of the Min and Max bounds"), "Case 11: Unexpected bound error")
+	test.AssertTrue(t, case11PatternErr != nil && strings.Contains(case11PatternErr.Error(), "does not match the Pattern"), "Case 11: Unexpected pattern error")
+
+	// Case 12: Constraint set directly on an unsupported field type
+	case12Err := validateFields("collection_name", []collection.Field{field.BooleanField("active").SetExtra(field.ExtraPattern, "^t")})
+
+	test.AssertTrue(t, case12Err != nil && strings.Contains(case12Err.Error(), "Constraint pattern is not available"), "Case 12: Unexpected error")
 }
 
 func TestValidateIndexDuplication(t *testing.T) {
@@ -299,8 +530,120 @@ func TestValidateIndexWithFields(t *testing.T) {
 	)
 
 	test.AssertTrue(t, case11Err == nil, "Case 11: Unexpected error")
+
+	// Case 12: wildcard index on a path and on all fields with projection
+	case12Fields := []collection.Field{field.StringField("name"), field.ObjectField("attributes", field.StringField("color"))}
+	case12Err := validateIndexWithFields("collection_name", case12Fields, index.WildcardIndex("attributes"))
+
+	test.AssertTrue(t, case12Err == nil, "Case 12: Unexpected error")
+
+	case12ProjectionErr := validateIndexWithFields("collection_name", case12Fields,
+		index.WildcardIndex().SetWildcardProjection(map[string]interface{}{"attributes.size": 1}),
+	)
+
+	test.AssertTrue(t, case12ProjectionErr != nil && strings.Contains(case12ProjectionErr.Error(), "Wildcard projection key is invalid"), "Case 12: Unexpected error")
+
+	case12PathErr := validateIndexWithFields("collection_name", case12Fields, index.WildcardIndex("tags"))
+
+	test.AssertTrue(t, case12PathErr != nil && strings.Contains(case12PathErr.Error(), "index key is invalid"), "Case 12: Unexpected error")
+
+	// Case 13: 2d index on legacy coordinate
+	case13Err := validateIndexWithFields("collection_name",
+		[]collection.Field{field.LegacyCoordinateArrayField("location")},
+		index.Geospatial2dIndex(index.Field("location")).SetBounds(-90, 90).SetBits(26),
+	)
+
+	test.AssertTrue(t, case13Err == nil, "Case 13: Unexpected error")
+
+	case13TypeErr := validateIndexWithFields("collection_name",
+		[]collection.Field{field.StringField("location")},
+		index.Geospatial2dIndex(index.Field("location")),
+	)
+
+	test.AssertTrue(t, case13TypeErr != nil && strings.Contains(case13TypeErr.Error(), "legacy coordinate"), "Case 13: Unexpected error")
+
+	case13BoundsErr := validateIndexWithFields("collection_name",
+		[]collection.Field{field.LegacyCoordinateArrayField("location")},
+		index.Geospatial2dIndex(index.Field("location")).SetBounds(90, -90),
+	)
+
+	test.AssertTrue(t, case13BoundsErr != nil && strings.Contains(case13BoundsErr.Error(), "min bound"), "Case 13: Unexpected error")
+
+	// Case 14: compound text index with weights
+	case14Fields := []collection.Field{field.StringField("title"), field.StringField("body"), field.StringField("category")}
+	case14Err := validateIndexWithFields("collection_name", case14Fields,
+		index.TextIndex(index.Field("category", 1), index.Field("title"), index.Field("body")).SetWeights(map[string]int32{"title": 10}),
+	)
+
+	test.AssertTrue(t, case14Err == nil, "Case 14: Unexpected error")
+
+	case14WeightErr := validateIndexWithFields("collection_name", case14Fields,
+		index.TextIndex(index.Field("category", 1), index.Field("title")).SetWeights(map[string]int32{"category": 10}),
+	)
+
+	test.AssertTrue(t, case14WeightErr != nil && strings.Contains(case14WeightErr.Error(), "not a text key"), "Case 14: Unexpected error")
+
+	// Case 15: compound index mixing 2dsphere and hashed keys
+	case15Fields := []collection.Field{field.GeoJSONPointField("location"), field.StringField("category"), field.StringField("name")}
+	case15Err := validateIndexWithFields("collection_name", case15Fields,
+		index.CompoundIndex(index.Field("location").As2dsphere(), index.Field("category").AsHashed(), index.Field("name", 1)),
+	)
+
+	test.AssertTrue(t, case15Err == nil, "Case 15: Unexpected error")
+
+	case15HashedErr := validateIndexWithFields("collection_name", case15Fields,
+		index.CompoundIndex(index.Field("category").AsHashed(), index.Field("name").AsHashed()),
+	)
+
+	test.AssertTrue(t, case15HashedErr != nil && strings.Contains(case15HashedErr.Error(), "only have a hashed key"), "Case 15: Unexpected error")
+
+	case15GeoErr := validateIndexWithFields("collection_name", case15Fields,
+		index.CompoundIndex(index.Field("name").As2dsphere(), index.Field("category", 1)),
+	)
+
+	test.AssertTrue(t, case15GeoErr != nil && strings.Contains(case15GeoErr.Error(), "2dsphere index key"), "Case 15: Unexpected error")
+
+	// Case 16: index field presents in the values of a map field
+	case16Fields := []collection.Field{field.MapField("attributes", field.ObjectField("", field.StringField("label")))}
+	case16Err := validateIndexWithFields("collection_name", case16Fields, index.SingleFieldIndex(index.Field("attributes.color.label", 1)))
+
+	test.AssertTrue(t, case16Err == nil, "Case 16: Unexpected error")
+
+	// Case 17: index field does not present in the values of a map field
+	case17Err := validateIndexWithFields("collection_name", case16Fields, index.SingleFieldIndex(index.Field("attributes.color.name", 1)))
+
+	test.AssertTrue(t, case17Err != nil && strings.Contains(case17Err.Error(), "index key is invalid"), "Case 17: Unexpected error")
+
+	// Case 18: index fields present in a variant, or the discriminator of a one of field
+	case18Fields := []collection.Field{field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"click": field.ObjectField("", field.Int32Field("x")),
+		"view":  field.ObjectField("", field.StringField("page")),
+	})}
+	case18Err := validateIndexWithFields("collection_name", case18Fields, index.CompoundIndex(index.Field("payload.kind", 1), index.Field("payload.page", 1)))
+
+	test.AssertTrue(t, case18Err == nil, "Case 18: Unexpected error")
+
+	// Case 19: index field does not present in any variant
+	case19Err := validateIndexWithFields("collection_name", case18Fields, index.SingleFieldIndex(index.Field("payload.y", 1)))
+
+	test.AssertTrue(t, case19Err != nil && strings.Contains(case19Err.Error(), "index key is invalid"), "Case 19: Unexpected error")
 }
 
 func TestValidateIndexes(t *testing.T) {
-	// TODO: implement this
+	fields := []collection.Field{field.StringField("title"), field.StringField("body")}
+
+	// Case 1: a text index
+	case1Err := validateIndexes("collection_name", fields, []collection.Index{
+		index.TextIndex(index.Field("title"), index.Field("body")),
+	})
+
+	test.AssertTrue(t, case1Err == nil, "Case 1: Unexpected error")
+
+	// Case 2: multiple text indexes
+	case2Err := validateIndexes("collection_name", fields, []collection.Index{
+		index.TextIndex(index.Field("title")),
+		index.TextIndex(index.Field("body")),
+	})
+
+	test.AssertTrue(t, case2Err != nil && strings.Contains(case2Err.Error(), "only have a text index"), "Case 2: Unexpected error")
 }
@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"reflect"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type (
@@ -33,6 +35,10 @@ const (
 	DataTypeString  = "string"
 	DataTypeBoolean = "boolean"
 	DataTypeTime    = "time"
+	// BSON specific types
+	DataTypeObjectID   = "objectId"
+	DataTypeDecimal128 = "decimal128"
+	DataTypeBinary     = "binary"
 	// TODO: add more types
 	// but, so far, not all data types are needed to initialize the mongodb field
 )
@@ -107,6 +113,27 @@ func Time(value time.Time) ValueType {
 	}
 }
 
+func ObjectID(value primitive.ObjectID) ValueType {
+	return ValueType{
+		Type:  DataTypeObjectID,
+		Value: value,
+	}
+}
+
+func Decimal128(value primitive.Decimal128) ValueType {
+	return ValueType{
+		Type:  DataTypeDecimal128,
+		Value: value,
+	}
+}
+
+func Binary(value primitive.Binary) ValueType {
+	return ValueType{
+		Type:  DataTypeBinary,
+		Value: value,
+	}
+}
+
 // helpers
 func Array(children ...interface{}) []interface{} {
 	return []interface{}{children}
@@ -164,6 +191,12 @@ func ConvertAnyToValueType(value interface{}) interface{} {
 	switch reflect.TypeOf(value) {
 	case reflect.TypeOf(time.Time{}):
 		return Time(value.(time.Time))
+	case reflect.TypeOf(primitive.ObjectID{}):
+		return ObjectID(value.(primitive.ObjectID))
+	case reflect.TypeOf(primitive.Decimal128{}):
+		return Decimal128(value.(primitive.Decimal128))
+	case reflect.TypeOf(primitive.Binary{}):
+		return Binary(value.(primitive.Binary))
 	}
 
 	// if none of type is recognized, just return as a string ValueType
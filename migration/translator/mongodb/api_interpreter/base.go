@@ -10,7 +10,6 @@ package api_interpreter
 import (
 	"context"
 
-	"github.com/amirkode/go-mongr8/collection"
 	dt "github.com/amirkode/go-mongr8/internal/data_type"
 	"github.com/amirkode/go-mongr8/migration/migrator"
 	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
@@ -23,14 +22,19 @@ type (
 		Migration migrator.Migration
 		// TODO: decide whether SubAction is always attached to SubActionApi (?), since not direct usage required
 		SubAction si.SubAction
-		Execute   func(ctx context.Context, db *mongo.Database) error
+		// position of the sub action in the migration, it identifies the progress of a migration
+		ActionKey      string
+		SubActionIndex int
+		Execute        func(ctx context.Context, db *mongo.Database) error
+		// this returns the commands would be issued by `Execute` in mongo shell syntax,
+		// or the error `Execute` would return before issuing any command
+		Simulate func() ([]string, error)
 	}
 )
 
 // This returns the list of SubActionApi(s)
 // `subActions` retrieved from migration files
-// `dbSchemas` is current schema from database formatted in Collection manner
-func GetSubActionApis(subActions []dt.Pair[migrator.Migration, si.SubAction], dbSchemas []collection.Collection) []SubActionApi {
+func GetSubActionApis(subActions []dt.Pair[migrator.Migration, si.SubAction]) []SubActionApi {
 	// TODO: for now, we are assuming that all subactions are valid.
 	// in fact, schema might changed dynamically on database in real case
 	// we will have to implement the validation eventually
@@ -45,10 +49,22 @@ func GetSubActionApis(subActions []dt.Pair[migrator.Migration, si.SubAction], db
 			res = append(res, SubActionApiCreateField(subAction))
 		case si.SubActionTypeConvertField:
 			res = append(res, SubActionApiConvertField(subAction))
-		case si.SubActionTypeDropIndex:
+		case si.SubActionTypeDropCollection:
 			res = append(res, SubActionApiDropCollection(subAction))
+		case si.SubActionTypeDropIndex:
+			res = append(res, SubActionApiDropIndex(subAction))
 		case si.SubActionTypeDropField:
 			res = append(res, SubActionApiDropField(subAction))
+		case si.SubActionTypeRenameField:
+			res = append(res, SubActionApiRenameField(subAction))
+		case si.SubActionTypeSetValidator:
+			res = append(res, SubActionApiSetValidator(subAction))
+		case si.SubActionTypeUnsetValidator:
+			res = append(res, SubActionApiUnsetValidator(subAction))
+		case si.SubActionTypeModifyCollection:
+			res = append(res, SubActionApiModifyCollection(subAction))
+		case si.SubActionTypeModifyIndex:
+			res = append(res, SubActionApiModifyIndex(subAction))
 		}
 	}
 
@@ -6,3 +6,38 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package api_interpreter
+
+import (
+	"testing"
+
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetSubActionApis(t *testing.T) {
+	migration := migrator.Migration{ID: "20240101_000000"}
+	schema := si.SubActionSchema{
+		Collection: metadata.InitMetadata("logs"),
+		Indexes: []collection.Index{
+			index.SingleFieldIndex(index.Field("message", 1)),
+		},
+	}
+
+	Convey("Each drop sub action is executed by its own api", t, func() {
+		apis := GetSubActionApis([]dt.Pair[migrator.Migration, si.SubAction]{
+			dt.NewPair(migration, *si.SubActionDropIndex(schema)),
+			dt.NewPair(migration, *si.SubActionDropCollection(schema)),
+		})
+
+		So(len(apis), ShouldEqual, 2)
+		So(mustSimulate(apis[0]), ShouldResemble, []string{`db.getCollection("logs").dropIndex("message_1")`})
+		So(mustSimulate(apis[1]), ShouldResemble, []string{`db.getCollection("logs").drop()`})
+	})
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package api_interpreter
+
+// pre-flight check of existing documents before building a unique index
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// maximum number of reported duplicate key groups of an index
+	duplicateGroupLimit = 100
+	// maximum number of sample `_id`s of each duplicate key group
+	duplicateSampleLimit = 5
+)
+
+type DuplicateKeyGroup struct {
+	// values of the index keys, ordered as the index keys
+	Values    []interface{}
+	Count     int64
+	SampleIDs []interface{}
+}
+
+// check whether an index is declared with unique option
+func isUniqueIndexRules(rules bson.D) bool {
+	for _, rule := range rules {
+		if rule.Key == index.OptionUnique {
+			unique, ok := rule.Value.(bool)
+			return ok && unique
+		}
+	}
+
+	return false
+}
+
+// This returns an aggregation pipeline grouping documents by the index keys
+// the groups having more than a document are the duplicates preventing the unique index.
+// Documents filtered out by the partial filter expression, or missing all keys of a sparse index
+// are not indexed, so those are excluded
+func duplicateKeyPipeline(keys bson.D, rules bson.D) bson.A {
+	pipeline := bson.A{}
+	for _, rule := range rules {
+		switch rule.Key {
+		case index.OptionPartialFilterExp:
+			pipeline = append(pipeline, bson.D{{Key: "$match", Value: rule.Value}})
+		case index.OptionSparse:
+			if sparse, ok := rule.Value.(bool); ok && sparse {
+				exists := bson.A{}
+				for _, key := range keys {
+					exists = append(exists, bson.D{{Key: key.Key, Value: bson.D{{Key: "$exists", Value: true}}}})
+				}
+				pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "$or", Value: exists}}}})
+			}
+		}
+	}
+
+	// the keys are aliased, since a field path cannot be used as a key of the group
+	groupID := bson.D{}
+	for i, key := range keys {
+		groupID = append(groupID, bson.E{Key: fmt.Sprintf("k%d", i), Value: "$" + key.Key})
+	}
+
+	return append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: groupID},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "ids", Value: bson.D{{Key: "$push", Value: "$_id"}}},
+		}}},
+		bson.D{{Key: "$match", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}}}}},
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "count", Value: 1},
+			{Key: "ids", Value: bson.D{{Key: "$slice", Value: bson.A{"$ids", duplicateSampleLimit}}}},
+		}}},
+		bson.D{{Key: "$limit", Value: duplicateGroupLimit}},
+	)
+}
+
+// This returns the duplicate key groups of existing documents those violate the unique index
+// the keys are compared with the collation of the index if declared
+func findDuplicateKeys(ctx context.Context, coll *mongo.Collection, keys bson.D, rules bson.D) ([]DuplicateKeyGroup, error) {
+	opt := options.Aggregate().SetAllowDiskUse(true)
+	for _, rule := range rules {
+		if rule.Key == index.OptionCollation {
+			collation := toCollation(rule.Value.(bson.D))
+			opt.SetCollation(&collation)
+		}
+	}
+
+	cursor, err := coll.Aggregate(ctx, duplicateKeyPipeline(keys, rules), opt)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	res := []DuplicateKeyGroup{}
+	for cursor.Next(ctx) {
+		group, err := decodeDuplicateKeyGroup(cursor.Current, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, group)
+	}
+
+	return res, cursor.Err()
+}
+
+// This decodes a group of the duplicate key pipeline result
+func decodeDuplicateKeyGroup(raw bson.Raw, keys bson.D) (DuplicateKeyGroup, error) {
+	var doc struct {
+		ID    bson.M        `bson:"_id"`
+		Count int64         `bson:"count"`
+		IDs   []interface{} `bson:"ids"`
+	}
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return DuplicateKeyGroup{}, err
+	}
+
+	res := DuplicateKeyGroup{
+		Count:     doc.Count,
+		SampleIDs: doc.IDs,
+	}
+	for i := range keys {
+		// a missing key is indexed as null
+		res.Values = append(res.Values, doc.ID[fmt.Sprintf("k%d", i)])
+	}
+
+	return res, nil
+}
+
+// This returns a readable report of duplicate key groups of a unique index
+func getDuplicateKeyReport(collName, indexName string, keys bson.D, groups []DuplicateKeyGroup) string {
+	res := fmt.Sprintf("Duplicate keys found in collection %s for unique index %s:\n", collName, indexName)
+	for _, group := range groups {
+		values := bson.D{}
+		for i, key := range keys {
+			values = append(values, bson.E{Key: key.Key, Value: group.Values[i]})
+		}
+
+		ids := []string{}
+		for _, id := range group.SampleIDs {
+			ids = append(ids, toShellSyntax(id))
+		}
+
+		res += fmt.Sprintf("- %s: %d documents, sample _id(s): %s\n", toShellSyntax(values), group.Count, strings.Join(ids, ", "))
+	}
+
+	if len(groups) == duplicateGroupLimit {
+		res += fmt.Sprintf("Only the first %d groups are reported\n", duplicateGroupLimit)
+	}
+
+	return res
+}
+
+// This checks existing documents against the unique indexes before creating them
+// it returns an error with the duplicate key report if any of them would fail,
+// and writes the report to the file set in the migration option
+func checkUniqueIndexes(ctx context.Context, db *mongo.Database, collName string, indexes []dt.Pair[string, dt.Pair[bson.D, bson.D]]) error {
+	report := ""
+	for _, idx := range indexes {
+		keys := idx.Second.First
+		rules := idx.Second.Second
+		if !isUniqueIndexRules(rules) {
+			continue
+		}
+
+		groups, err := findDuplicateKeys(ctx, db.Collection(collName), keys, rules)
+		if err != nil {
+			return err
+		}
+
+		if len(groups) > 0 {
+			report += getDuplicateKeyReport(collName, idx.First, keys, groups)
+		}
+	}
+
+	if report == "" {
+		return nil
+	}
+
+	return duplicateKeyError(ctx, report)
+}
+
+// This returns the error of a duplicate key report,
+// the report is written to the file set in the migration option if any
+func duplicateKeyError(ctx context.Context, report string) error {
+	if opt, ok := ctx.Value(option.MigrationOptionKey).(option.MigrationOption); ok && opt.DuplicateReport != "" {
+		err := os.WriteFile(opt.DuplicateReport, []byte(report), 0644)
+		if err != nil {
+			return fmt.Errorf("error writing the duplicate key report: %v\n%s", err, report)
+		}
+
+		return fmt.Errorf("unique index cannot be created, the duplicate key report is written to %s", opt.DuplicateReport)
+	}
+
+	return fmt.Errorf("unique index cannot be created\n%s", report)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package api_interpreter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amirkode/go-mongr8/migration/option"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsUniqueIndexRules(t *testing.T) {
+	Convey("Is Unique Index Rules", t, func() {
+		So(isUniqueIndexRules(bson.D{{Key: "unique", Value: true}}), ShouldBeTrue)
+		So(isUniqueIndexRules(bson.D{{Key: "unique", Value: false}}), ShouldBeFalse)
+		So(isUniqueIndexRules(bson.D{{Key: "sparse", Value: true}}), ShouldBeFalse)
+		So(isUniqueIndexRules(bson.D{}), ShouldBeFalse)
+	})
+}
+
+func TestDuplicateKeyPipeline(t *testing.T) {
+	keys := bson.D{{Key: "email", Value: 1}, {Key: "profile.tenant", Value: -1}}
+
+	Convey("Duplicate Key Pipeline", t, func() {
+		pipeline := duplicateKeyPipeline(keys, bson.D{{Key: "unique", Value: true}})
+
+		So(toShellSyntax(pipeline), ShouldEqual,
+			`[{"$group":{"_id":{"k0":"$email","k1":"$profile.tenant"},"count":{"$sum":1},"ids":{"$push":"$_id"}}},`+
+				`{"$match":{"count":{"$gt":1}}},`+
+				`{"$project":{"count":1,"ids":{"$slice":["$ids",5]}}},`+
+				`{"$limit":100}]`,
+		)
+	})
+
+	Convey("Duplicate Key Pipeline With Partial Filter And Sparse", t, func() {
+		pipeline := duplicateKeyPipeline(keys, bson.D{
+			{Key: "unique", Value: true},
+			{Key: "partialFilterExpression", Value: bson.D{{Key: "active", Value: true}}},
+			{Key: "sparse", Value: true},
+		})
+
+		So(toShellSyntax(pipeline[0]), ShouldEqual, `{"$match":{"active":true}}`)
+		So(toShellSyntax(pipeline[1]), ShouldEqual, `{"$match":{"$or":[{"email":{"$exists":true}},{"profile.tenant":{"$exists":true}}]}}`)
+		So(len(pipeline), ShouldEqual, 6)
+	})
+}
+
+func TestGetDuplicateKeyReport(t *testing.T) {
+	Convey("Get Duplicate Key Report", t, func() {
+		report := getDuplicateKeyReport("users", "email_1_true_unique", bson.D{{Key: "email", Value: 1}}, []DuplicateKeyGroup{
+			{
+				Values:    []interface{}{"john@mail.com"},
+				Count:     3,
+				SampleIDs: []interface{}{int32(1), int32(2), int32(3)},
+			},
+			{
+				Values:    []interface{}{nil},
+				Count:     2,
+				SampleIDs: []interface{}{int32(4), int32(5)},
+			},
+		})
+
+		So(report, ShouldEqual, "Duplicate keys found in collection users for unique index email_1_true_unique:\n"+
+			"- {\"email\":\"john@mail.com\"}: 3 documents, sample _id(s): 1, 2, 3\n"+
+			"- {\"email\":null}: 2 documents, sample _id(s): 4, 5\n",
+		)
+	})
+}
+
+func TestDecodeDuplicateKeyGroup(t *testing.T) {
+	keys := bson.D{{Key: "email", Value: 1}, {Key: "tenant", Value: 1}}
+
+	Convey("Decode Duplicate Key Group", t, func() {
+		// a group of the duplicate key pipeline result, the missing tenant is grouped as null
+		raw, err := bson.Marshal(bson.D{
+			{Key: "_id", Value: bson.D{{Key: "k0", Value: "john@mail.com"}}},
+			{Key: "count", Value: int32(2)},
+			{Key: "ids", Value: bson.A{int32(1), int32(2)}},
+		})
+		So(err, ShouldBeNil)
+
+		group, err := decodeDuplicateKeyGroup(raw, keys)
+		So(err, ShouldBeNil)
+		So(group.Values, ShouldResemble, []interface{}{"john@mail.com", nil})
+		So(group.Count, ShouldEqual, 2)
+		So(group.SampleIDs, ShouldResemble, []interface{}{int32(1), int32(2)})
+
+		So(getDuplicateKeyReport("users", "email_1_tenant_1", keys, []DuplicateKeyGroup{group}), ShouldEqual,
+			"Duplicate keys found in collection users for unique index email_1_tenant_1:\n"+
+				"- {\"email\":\"john@mail.com\",\"tenant\":null}: 2 documents, sample _id(s): 1, 2\n",
+		)
+	})
+}
+
+func TestDuplicateKeyError(t *testing.T) {
+	report := "Duplicate keys found in collection users for unique index email_1_true_unique:\n"
+
+	Convey("Duplicate Key Error", t, func() {
+		err := duplicateKeyError(context.Background(), report)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "unique index cannot be created\n"+report)
+	})
+
+	Convey("Duplicate Key Error With Report File", t, func() {
+		path := filepath.Join(t.TempDir(), "duplicates.txt")
+		ctx := context.WithValue(context.Background(), option.MigrationOptionKey, option.MigrationOption{
+			DuplicateReport: path,
+		})
+
+		err := duplicateKeyError(ctx, report)
+		So(err, ShouldNotBeNil)
+		So(strings.HasSuffix(err.Error(), path), ShouldBeTrue)
+
+		content, err := os.ReadFile(path)
+		So(err, ShouldBeNil)
+		So(string(content), ShouldEqual, report)
+	})
+
+	Convey("Duplicate Key Report Limit", t, func() {
+		groups := make([]DuplicateKeyGroup, duplicateGroupLimit)
+		for i := range groups {
+			groups[i] = DuplicateKeyGroup{Values: []interface{}{i}, Count: 2}
+		}
+
+		report := getDuplicateKeyReport("users", "email_1_true_unique", bson.D{{Key: "email", Value: 1}}, groups)
+		So(report, ShouldEndWith, "Only the first 100 groups are reported\n")
+	})
+}
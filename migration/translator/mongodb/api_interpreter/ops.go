@@ -11,7 +11,11 @@ package api_interpreter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"time"
 
 	dt "github.com/amirkode/go-mongr8/internal/data_type"
 
@@ -20,6 +24,8 @@ import (
 	"github.com/amirkode/go-mongr8/collection/index"
 	"github.com/amirkode/go-mongr8/collection/metadata"
 	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/option"
+	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
 	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -27,51 +33,74 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// This will check the path if exists or create the new one if does not
-// if those conditions are not available, it expects to return an error
-func checkOrCreatePath(ctx context.Context, collection *mongo.Collection, payload bson.D) error {
+// a parent path of a field payload, created if it's missing
+type createPathStep struct {
+	path string
+	// filter of documents having the path
+	check  bson.D
+	update bson.M
+}
+
+// This returns the steps creating all parent paths of the payload, sorted from higher level
+func getCreatePathSteps(payload bson.D) []createPathStep {
 	// all possible path to check, sorted from higher level
 	availablePaths := []bson.D{}
 	checkPathExistPayloads(payload, "", &availablePaths)
+
+	res := []createPathStep{}
+	for _, path := range availablePaths {
+		upsertPath := convertToUpsertPath(path[0].Key)
+		currPath := path[0].Key
+		isArray := path[1].Value.(bool)
+		wantsArray := path[2].Value.(bool)
+		var value interface{}
+		if wantsArray {
+			value = bson.A{}
+		} else {
+			value = bson.M{}
+		}
+
+		createPayload := bson.M{
+			"$set": bson.M{
+				upsertPath: value,
+			},
+		}
+		// if current path is an array, then it must be empty and a new item needs to be pushed
+		if isArray {
+			parentPath := getParentPath(currPath)
+			createPayload = bson.M{
+				"$push": bson.M{
+					parentPath: value,
+				},
+			}
+		}
+
+		res = append(res, createPathStep{
+			path:   currPath,
+			check:  bson.D{path[0]},
+			update: createPayload,
+		})
+	}
+
+	return res
+}
+
+// This will check the path if exists or create the new one if does not
+// if those conditions are not available, it expects to return an error
+func checkOrCreatePath(ctx context.Context, collection *mongo.Collection, payload bson.D) error {
 	startCreate := false
 
 	// TODO: optimize this, since it gradually creates the parent path by one level
-	for _, path := range availablePaths {
+	for _, step := range getCreatePathSteps(payload) {
 		if !startCreate {
-			checkPayload := bson.D{path[0]}
-			count, _ := collection.CountDocuments(ctx, checkPayload)
+			count, _ := collection.CountDocuments(ctx, step.check)
 			startCreate = count == 0
 		}
 
 		if startCreate {
-			upsertPath := convertToUpsertPath(path[0].Key)
-			currPath := path[0].Key
-			isArray := path[1].Value.(bool)
-			wantsArray := path[2].Value.(bool)
-			var value interface{}
-			if wantsArray {
-				value = bson.A{}
-			} else {
-				value = bson.M{}
-			}
-
-			createPayload := bson.M{
-				"$set": bson.M{
-					upsertPath: value,
-				},
-			}
-			// if current path is an array, then it must be empty and a new item needs to be pushed
-			if isArray {
-				parentPath := getParentPath(currPath)
-				createPayload = bson.M{
-					"$push": bson.M{
-						parentPath: value,
-					},
-				}
-			}
-			_, err := collection.UpdateMany(ctx, bson.M{}, createPayload)
+			_, err := collection.UpdateMany(ctx, bson.M{}, step.update, bypassValidationUpdateOptions())
 			if err != nil {
-				return fmt.Errorf("error while creating path %s: %s", currPath, err.Error())
+				return fmt.Errorf("error while creating path %s: %s", step.path, err.Error())
 			}
 		}
 	}
@@ -84,11 +113,315 @@ func sanatizePayload(ctx context.Context, collection *mongo.Collection, payload
 	return checkOrCreatePath(ctx, collection, payload)
 }
 
+// migration must be able to restructure documents regardless of the current validator
+func bypassValidationUpdateOptions() *options.UpdateOptions {
+	return options.Update().SetBypassDocumentValidation(true)
+}
+
+// this returns `collMod` command setting the validator of a collection
+// an empty validator removes the existing one
+func setValidatorCommand(collName string, fields []collection.Field) bson.D {
+	validator := bson.M{}
+	if len(fields) > 0 {
+		validator = bson.M(dictionary.NewSchemaValidation(fields).GetValidator())
+	}
+
+	return bson.D{
+		{Key: "collMod", Value: collName},
+		{Key: "validator", Value: validator},
+	}
+}
+
+// This converts collation document to the driver's collation option
+func toCollation(values bson.D) options.Collation {
+	collation := options.Collation{}
+	// based on https://www.mongodb.com/docs/manual/reference/collation/
+	for _, c := range values {
+		switch c.Key {
+		case "locale":
+			collation.Locale = c.Value.(string)
+		case "caseLevel":
+			collation.CaseLevel = c.Value.(bool)
+		case "caseFirst":
+			collation.CaseFirst = c.Value.(string)
+		case "strength":
+			// the stored collation is read as int32
+			switch strength := c.Value.(type) {
+			case int:
+				collation.Strength = strength
+			case int32:
+				collation.Strength = int(strength)
+			case int64:
+				collation.Strength = int(strength)
+			}
+		case "numericOrdering":
+			collation.NumericOrdering = c.Value.(bool)
+		case "alternate":
+			collation.Alternate = c.Value.(string)
+		case "maxVariable":
+			collation.MaxVariable = c.Value.(string)
+		case "backwards":
+			collation.Backwards = c.Value.(bool)
+		}
+	}
+
+	return collation
+}
+
+// This returns the default collation of a collection, or nil if it's not declared
+func metadataCollation(meta collection.Metadata) *options.Collation {
+	values := meta.Spec().GetCollation()
+	if values == nil {
+		return nil
+	}
+
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	d := bson.D{}
+	for _, key := range keys {
+		d = append(d, bson.E{Key: key, Value: values[key]})
+	}
+
+	collation := toCollation(d)
+
+	return &collation
+}
+
+// This returns the aggregation pipeline of a view
+func viewPipeline(meta collection.Metadata) bson.A {
+	res := bson.A{}
+	for _, stage := range meta.Spec().GetPipeline() {
+		res = append(res, stage)
+	}
+
+	return res
+}
+
+func createView(ctx context.Context, db *mongo.Database, meta collection.Metadata) error {
+	if meta.Spec().GetViewOn() == "" {
+		return fmt.Errorf("view %s must declare its source collection", meta.Spec().Name)
+	}
+
+	opt := options.CreateView()
+	if collation := metadataCollation(meta); collation != nil {
+		opt.SetCollation(collation)
+	}
+
+	return db.CreateView(ctx, meta.Spec().Name, meta.Spec().GetViewOn(), viewPipeline(meta), opt)
+}
+
+func createCollectionOptions(meta collection.Metadata) options.CreateCollectionOptions {
+	opt := options.CreateCollectionOptions{}
+	schemaOption := meta.Spec().Options
+	if schemaOption != nil {
+		_, capped := (*schemaOption)[metadata.CollectionOptionCapped]
+		if capped {
+			cappedSize, ok := (*schemaOption)[metadata.CollectionOptionCappedSize]
+			if ok {
+				opt.SetCapped(true)
+				opt.SetSizeInBytes(cappedSize.(int64))
+			}
+		}
+
+		ttl, useTTL := (*schemaOption)[metadata.CollectionOptionExpiredAfterSeconds]
+		if useTTL {
+			opt.SetExpireAfterSeconds(ttl.(int64))
+		}
+
+		if collation := metadataCollation(meta); collation != nil {
+			opt.SetCollation(collation)
+		}
+
+		if meta.Spec().IsTimeSeries() {
+			opt.SetTimeSeriesOptions(timeSeriesOptions(meta))
+		}
+	}
+
+	return opt
+}
+
+func timeSeriesOptions(meta collection.Metadata) *options.TimeSeriesOptions {
+	schemaOption := *meta.Spec().Options
+	opt := options.TimeSeries().SetTimeField(meta.Spec().GetTimeField())
+	if metaField := meta.Spec().GetMetaField(); metaField != "" {
+		opt.SetMetaField(metaField)
+	}
+
+	if granularity, ok := schemaOption[metadata.CollectionOptionGranularity]; ok {
+		opt.SetGranularity(string(granularity.(metadata.TimeSeriesGranularity)))
+	}
+
+	if maxSpan, ok := schemaOption[metadata.CollectionOptionBucketMaxSpanSeconds]; ok {
+		rounding := schemaOption[metadata.CollectionOptionBucketRoundingSeconds]
+		opt.SetBucketMaxSpan(time.Duration(maxSpan.(int64)) * time.Second)
+		opt.SetBucketRounding(time.Duration(rounding.(int64)) * time.Second)
+	}
+
+	return opt
+}
+
+// This returns commands modifying collection options from `from` to `to`
+// `convertToCapped` is used for capping a collection, otherwise `collMod` is used.
+// Transitions those cannot be applied to an existing collection (i.e: uncapping on rollback)
+// are skipped and returned as notes
+func modifyCollectionCommands(from, to collection.Metadata) ([]bson.D, []string) {
+	collName := to.Spec().Name
+	getOption := func(meta collection.Metadata, option metadata.CollectionOption) (interface{}, bool) {
+		if meta.Spec().Options == nil {
+			return nil, false
+		}
+
+		value, ok := (*meta.Spec().Options)[option]
+		return value, ok
+	}
+
+	commands := []bson.D{}
+	notes := []string{}
+
+	// capped collection
+	_, fromCapped := getOption(from, metadata.CollectionOptionCapped)
+	_, toCapped := getOption(to, metadata.CollectionOptionCapped)
+	fromSize, _ := getOption(from, metadata.CollectionOptionCappedSize)
+	toSize, _ := getOption(to, metadata.CollectionOptionCappedSize)
+	if !fromCapped && toCapped {
+		commands = append(commands, bson.D{
+			{Key: "convertToCapped", Value: collName},
+			{Key: "size", Value: toSize},
+		})
+	} else if fromCapped && !toCapped {
+		notes = append(notes, fmt.Sprintf("capped collection %s cannot be uncapped, skipped", collName))
+	} else if toCapped && fromSize != toSize {
+		commands = append(commands, bson.D{
+			{Key: "collMod", Value: collName},
+			{Key: "cappedSize", Value: toSize},
+		})
+	}
+
+	// expiration
+	fromTTL, _ := getOption(from, metadata.CollectionOptionExpiredAfterSeconds)
+	toTTL, toHasTTL := getOption(to, metadata.CollectionOptionExpiredAfterSeconds)
+	if fromTTL != toTTL {
+		var expireAfterSeconds interface{} = "off"
+		if toHasTTL {
+			expireAfterSeconds = toTTL
+		}
+
+		commands = append(commands, bson.D{
+			{Key: "collMod", Value: collName},
+			{Key: "expireAfterSeconds", Value: expireAfterSeconds},
+		})
+	}
+
+	// time series bucketing
+	if to.Spec().IsTimeSeries() {
+		fromGranularity, _ := getOption(from, metadata.CollectionOptionGranularity)
+		toGranularity, toHasGranularity := getOption(to, metadata.CollectionOptionGranularity)
+		fromMaxSpan, _ := getOption(from, metadata.CollectionOptionBucketMaxSpanSeconds)
+		toMaxSpan, toHasBucketSpan := getOption(to, metadata.CollectionOptionBucketMaxSpanSeconds)
+		if toHasGranularity && fromGranularity != toGranularity {
+			if fromGranularity != nil && toGranularity.(metadata.TimeSeriesGranularity).CompareTo(fromGranularity.(metadata.TimeSeriesGranularity)) < 0 {
+				notes = append(notes, fmt.Sprintf("granularity of time series collection %s cannot be decreased to %s, skipped", collName, toGranularity))
+			} else {
+				commands = append(commands, bson.D{
+					{Key: "collMod", Value: collName},
+					{Key: "timeseries", Value: bson.D{{Key: "granularity", Value: string(toGranularity.(metadata.TimeSeriesGranularity))}}},
+				})
+			}
+		} else if toHasBucketSpan && fromMaxSpan != toMaxSpan {
+			if fromMaxSpan != nil && toMaxSpan.(int64) < fromMaxSpan.(int64) {
+				notes = append(notes, fmt.Sprintf("bucket span of time series collection %s cannot be decreased to %d, skipped", collName, toMaxSpan))
+			} else {
+				toRounding, _ := getOption(to, metadata.CollectionOptionBucketRoundingSeconds)
+				commands = append(commands, bson.D{
+					{Key: "collMod", Value: collName},
+					{Key: "timeseries", Value: bson.D{
+						{Key: "bucketMaxSpanSeconds", Value: toMaxSpan},
+						{Key: "bucketRoundingSeconds", Value: toRounding},
+					}},
+				})
+			}
+		}
+	}
+
+	return commands, notes
+}
+
+// This returns `collMod` commands modifying options of an index from `from` to `to`
+// the index is referred by its previous name, since it's not rebuilt.
+// A non-unique index is converted to a unique one by preparing it first
+func modifyIndexCommands(collName string, from, to collection.Index) []bson.D {
+	getRule := func(idx collection.Index, option string) interface{} {
+		if !idx.Spec().HasRule(option) {
+			return nil
+		}
+
+		return (*idx.Spec().Rules)[option]
+	}
+	command := func(option string, value interface{}) bson.D {
+		return bson.D{
+			{Key: "collMod", Value: collName},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: from.Spec().GetName()},
+				{Key: option, Value: value},
+			}},
+		}
+	}
+
+	commands := []bson.D{}
+	if to.Spec().HasRule(index.OptionHidden) != from.Spec().HasRule(index.OptionHidden) {
+		commands = append(commands, command(index.OptionHidden, to.Spec().HasRule(index.OptionHidden)))
+	}
+
+	if ttl := getRule(to, index.OptionTTL); ttl != nil && ttl != getRule(from, index.OptionTTL) {
+		commands = append(commands, command(index.OptionTTL, ttl))
+	}
+
+	toUnique := to.Spec().HasRule(index.OptionUnique)
+	if toUnique != from.Spec().HasRule(index.OptionUnique) {
+		if toUnique {
+			// new duplicate values are rejected before the conversion
+			commands = append(commands, command("prepareUnique", true))
+		}
+
+		commands = append(commands, command(index.OptionUnique, toUnique))
+	}
+
+	return commands
+}
+
+func createFieldUpdatePayload(payload bson.D) bson.M {
+	// set field expects 1 path
+	return bson.M{
+		"$set": createFieldSetPayload(payload, ""),
+	}
+}
+
+func convertFieldUpdatePayload(to collection.Field, from field.FieldType) bson.A {
+	// depth as suffix of map alias to maintain the uniqueness of the alias
+	depth := 0
+	return bson.A{
+		bson.M{
+			"$set": convertFieldSetPayload(to, "", from, &depth),
+		},
+	}
+}
+
+func dropFieldUpdatePayload(payload bson.D) bson.M {
+	return bson.M{
+		"$unset": dropFieldUnsetPayload(payload, ""),
+	}
+}
+
 func createField(ctx context.Context, db *mongo.Database, collName string, payload bson.D, update bool) error {
 	collection := db.Collection(collName)
 	// if it's not an update, then create/insert entire documents
 	if !update {
-		if _, err := collection.InsertOne(ctx, payload); err != nil {
+		opt := options.InsertOne().SetBypassDocumentValidation(true)
+		if _, err := collection.InsertOne(ctx, payload, opt); err != nil {
 			return err
 		}
 
@@ -100,32 +433,59 @@ func createField(ctx context.Context, db *mongo.Database, collName string, paylo
 		return err
 	}
 
-	// set field expects 1 path
-	updatePayload := bson.M{
-		"$set": createFieldSetPayload(payload, ""),
+	updatePayload := createFieldUpdatePayload(payload)
+	opt := bypassValidationUpdateOptions().SetUpsert(true)
+	_, err = collection.UpdateMany(ctx, bson.M{}, updatePayload, opt)
+
+	return err
+}
+
+// This returns the stored value of a created field
+func createdFieldValue(spec *field.Spec) interface{} {
+	translated := dictionary.GetTranslatedField(field.FromFieldSpec(spec)).GetObject()
+	return si.ConvertValueTypeToRealType(translated[spec.Name])
+}
+
+// This returns the update pipeline of a field creation or drop, if there's a map or a one of along the path
+// @see requiresPipeline
+func fieldUpdatePipeline(fields []collection.Field, isDrop bool) bson.A {
+	if len(fields) != 1 {
+		return nil
+	}
+
+	if isDrop {
+		if path := getDroppedFieldPipelinePath(fields[0]); path != nil {
+			return dropFieldPipelinePayload(path)
+		}
+
+		return nil
 	}
-	upsert := true
-	opt := options.UpdateOptions{
-		Upsert: &upsert,
+
+	if path := getCreatedFieldPipelinePath(fields[0]); path != nil {
+		return createFieldPipelinePayload(path, createdFieldValue(path[len(path)-1]))
 	}
-	_, err = collection.UpdateMany(ctx, bson.M{}, updatePayload, &opt)
+
+	return nil
+}
+
+func updateWithPipeline(ctx context.Context, db *mongo.Database, collName string, pipeline bson.A) error {
+	_, err := db.Collection(collName).UpdateMany(ctx, bson.M{}, pipeline, bypassValidationUpdateOptions())
 
 	return err
 }
 
 func convertField(ctx context.Context, db *mongo.Database, collName string, to collection.Field, from field.FieldType) error {
-	// depth as suffix of map alias to maintain the uniqueness of the alias
-	depth := 0
-	updatePayload := bson.A{
-		bson.M{
-			"$set": convertFieldSetPayload(to, "", from, &depth),
-		},
-	}
+	updatePayload := convertFieldUpdatePayload(to, from)
+	collection := db.Collection(collName)
+	_, err := collection.UpdateMany(ctx, bson.M{}, updatePayload, bypassValidationUpdateOptions())
 
-	fmt.Println("convert payload:", updatePayload)
+	return err
+}
 
+func renameField(ctx context.Context, db *mongo.Database, collName string, f collection.Field) error {
+	updatePayload := renameFieldUpdatePayload(si.GetRenamedFieldPath(f))
 	collection := db.Collection(collName)
-	_, err := collection.UpdateMany(ctx, bson.M{}, updatePayload)
+	_, err := collection.UpdateMany(ctx, bson.M{}, updatePayload, bypassValidationUpdateOptions())
 
 	return err
 }
@@ -154,30 +514,22 @@ func createIndexes(ctx context.Context, db *mongo.Database, collName string, ind
 			case index.OptionTTL:
 				opt = opt.SetExpireAfterSeconds(rule.Value.(int32))
 			case index.OptionCollation:
-				collation := options.Collation{}
-				// based on https://www.mongodb.com/docs/manual/reference/collation/
-				for _, c := range rule.Value.(bson.D) {
-					switch c.Key {
-					case "locale":
-						collation.Locale = c.Value.(string)
-					case "caseLevel":
-						collation.CaseLevel = c.Value.(bool)
-					case "caseFirst":
-						collation.CaseFirst = c.Value.(string)
-					case "strength":
-						collation.Strength = c.Value.(int)
-					case "numericOrdering":
-						collation.NumericOrdering = c.Value.(bool)
-					case "alternate":
-						collation.Alternate = c.Value.(string)
-					case "maxVariable":
-						collation.MaxVariable = c.Value.(string)
-					case "backwards":
-						collation.Backwards = c.Value.(bool)
-					}
-				}
-
+				collation := toCollation(rule.Value.(bson.D))
 				opt = opt.SetCollation(&collation)
+			case index.OptionWildcardProjection:
+				opt = opt.SetWildcardProjection(rule.Value)
+			case index.OptionMin:
+				opt = opt.SetMin(rule.Value.(float64))
+			case index.OptionMax:
+				opt = opt.SetMax(rule.Value.(float64))
+			case index.OptionBits:
+				opt = opt.SetBits(rule.Value.(int32))
+			case index.OptionWeights:
+				opt = opt.SetWeights(rule.Value)
+			case index.OptionDefaultLanguage:
+				opt = opt.SetDefaultLanguage(rule.Value.(string))
+			case index.OptionLanguageOverride:
+				opt = opt.SetLanguageOverride(rule.Value.(string))
 			}
 		}
 
@@ -187,6 +539,10 @@ func createIndexes(ctx context.Context, db *mongo.Database, collName string, ind
 		}
 
 		_, err := collection.Indexes().CreateOne(ctx, indexModel)
+		if isIndexConflictError(err) {
+			err = recreateConflictingIndex(ctx, collection, name, keys, indexModel, err)
+		}
+
 		if err != nil {
 			return err
 		}
@@ -195,27 +551,128 @@ func createIndexes(ctx context.Context, db *mongo.Database, collName string, ind
 	return nil
 }
 
+// MongoDB error codes of index operations,
+// reference: https://www.mongodb.com/docs/manual/reference/error-codes/
+const (
+	errCodeIndexNotFound         = 27
+	errCodeIndexOptionsConflict  = 85
+	errCodeIndexKeySpecsConflict = 86
+)
+
+func hasErrorCode(err error, code int) bool {
+	var serverErr mongo.ServerError
+
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(code)
+}
+
+// an equivalent index on the same keys (or an index with the same name) already exists
+func isIndexConflictError(err error) bool {
+	return hasErrorCode(err, errCodeIndexOptionsConflict) || hasErrorCode(err, errCodeIndexKeySpecsConflict)
+}
+
+// whether the dropping of an index conflicting with its rebuilt index is allowed
+func isIndexDropAllowed(ctx context.Context) bool {
+	opt, ok := ctx.Value(option.MigrationOptionKey).(option.MigrationOption)
+
+	return ok && opt.AllowIndexDrop
+}
+
+// This compares index keys regardless of the numeric type of the values,
+// since the server might return an int32 of a declared int
+func isSameIndexKeys(keys bson.D, other bson.D) bool {
+	if len(keys) != len(other) {
+		return false
+	}
+
+	normalize := func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case int:
+			return float64(v)
+		case int32:
+			return float64(v)
+		case int64:
+			return float64(v)
+		}
+
+		return value
+	}
+
+	for i := range keys {
+		if keys[i].Key != other[i].Key || normalize(keys[i].Value) != normalize(other[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// This returns the names of existing indexes on the same keys as `keys` or named `name`
+func getConflictingIndexNames(ctx context.Context, coll *mongo.Collection, name string, keys bson.D) ([]string, error) {
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []struct {
+		Name string `bson:"name"`
+		Key  bson.D `bson:"key"`
+	}
+	if err := cursor.All(ctx, &existing); err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+	for _, idx := range existing {
+		if idx.Name == name || isSameIndexKeys(keys, idx.Key) {
+			res = append(res, idx.Name)
+		}
+	}
+
+	return res, nil
+}
+
+// This resolves an index rebuilt on the same keys, when it cannot coexist with the previous index.
+// The previous index is dropped first only if it's explicitly allowed, since the index is missing while it's rebuilt
+func recreateConflictingIndex(ctx context.Context, coll *mongo.Collection, name string, keys bson.D, indexModel mongo.IndexModel, cause error) error {
+	conflicts, err := getConflictingIndexNames(ctx, coll, name, keys)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) != 1 {
+		return fmt.Errorf("index %s conflicts with the existing indexes %v: %w", name, conflicts, cause)
+	}
+
+	// the previous index is dropped by name after the rebuild, so it must not be the rebuilt one
+	if conflicts[0] == name {
+		return fmt.Errorf("index %s cannot be rebuilt with different options under the same name, declare a different name: %w", name, cause)
+	}
+
+	if !isIndexDropAllowed(ctx) {
+		return fmt.Errorf("index %s cannot coexist with the existing index %s on the same keys, "+
+			"re-run with --%s to drop %s before creating %s (the index is missing while it's rebuilt): %w",
+			name, conflicts[0], option.MigrationOptionArgAllowIndexDrop, conflicts[0], name, cause)
+	}
+
+	log.Printf("Dropping index %s of collection %s before rebuilding it as %s\n", conflicts[0], coll.Name(), name)
+	if _, err := coll.Indexes().DropOne(ctx, conflicts[0]); err != nil {
+		return err
+	}
+
+	_, err = coll.Indexes().CreateOne(ctx, indexModel)
+
+	return err
+}
+
 func SubActionApiCreateCollection(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
 	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
 	exec := func(ctx context.Context, db *mongo.Database) error {
-		opt := options.CreateCollectionOptions{}
-		schemaOption := subAction.Second.ActionSchema.Collection.Spec().Options
-		if schemaOption != nil {
-			_, capped := (*schemaOption)[metadata.CollectionOptionCapped]
-			if capped {
-				cappedSize, ok := (*schemaOption)[metadata.CollectionOptionCappedSize]
-				if ok {
-					opt.SetCapped(true)
-					opt.SetSizeInBytes(cappedSize.(int64))
-				}
-			}
-
-			ttl, useTTL := (*schemaOption)[metadata.CollectionOptionExpiredAfterSeconds]
-			if useTTL {
-				opt.SetExpireAfterSeconds(ttl.(int64))
-			}
+		// a view has neither documents nor indexes
+		if subAction.Second.ActionSchema.Collection.Spec().Type == metadata.TypeViewCollection {
+			return createView(ctx, db, subAction.Second.ActionSchema.Collection)
 		}
 
+		opt := createCollectionOptions(subAction.Second.ActionSchema.Collection)
 		err := db.CreateCollection(ctx, collectionName, &opt)
 		if err != nil {
 			return err
@@ -229,36 +686,78 @@ func SubActionApiCreateCollection(subAction dt.Pair[migrator.Migration, si.SubAc
 		return createIndexes(ctx, db, collectionName, subAction.Second.GetIndexesBsonD())
 	}
 
+	simulate := func() ([]string, error) {
+		if subAction.Second.ActionSchema.Collection.Spec().Type == metadata.TypeViewCollection {
+			return []string{simulateCreateView(subAction.Second.ActionSchema.Collection)}, nil
+		}
+
+		res := []string{
+			simulateCreateCollection(subAction.Second.ActionSchema.Collection),
+			shellCollectionCommand(collectionName, "insertOne", subAction.Second.GetFieldsBsonD(), bypassValidationShellOptions),
+		}
+
+		return append(res, simulateCreateIndexes(collectionName, subAction.Second.GetIndexesBsonD())...), nil
+	}
+
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
 
 func SubActionApiCreateIndex(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
 	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
 	exec := func(ctx context.Context, db *mongo.Database) error {
+		// existing documents are checked first, so a unique index never fails midway
+		err := checkUniqueIndexes(ctx, db, collectionName, subAction.Second.GetIndexesBsonD())
+		if err != nil {
+			return err
+		}
+
 		return createIndexes(ctx, db, collectionName, subAction.Second.GetIndexesBsonD())
 	}
 
+	simulate := func() ([]string, error) {
+		return simulateCreateIndexes(collectionName, subAction.Second.GetIndexesBsonD()), nil
+	}
+
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
 
 func SubActionApiCreateField(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
 	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
 	exec := func(ctx context.Context, db *mongo.Database) error {
-		return createField(ctx, db, collectionName, subAction.Second.GetFieldsBsonD(), true)
+		sa := subAction.Second
+		sa.ActionSchema.Fields = withoutDropCheckpoints(sa.ActionSchema.Fields)
+		if pipeline := fieldUpdatePipeline(sa.ActionSchema.Fields, false); pipeline != nil {
+			return updateWithPipeline(ctx, db, collectionName, pipeline)
+		}
+
+		return createField(ctx, db, collectionName, sa.GetFieldsBsonD(), true)
+	}
+
+	simulate := func() ([]string, error) {
+		sa := subAction.Second
+		sa.ActionSchema.Fields = withoutDropCheckpoints(sa.ActionSchema.Fields)
+		if pipeline := fieldUpdatePipeline(sa.ActionSchema.Fields, false); pipeline != nil {
+			return []string{shellCollectionCommand(collectionName, "updateMany", bson.M{}, pipeline, bypassValidationShellOptions)}, nil
+		}
+
+		return simulateCreateField(collectionName, sa.GetFieldsBsonD()), nil
 	}
 
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
 
@@ -272,10 +771,20 @@ func SubActionApiConvertField(subAction dt.Pair[migrator.Migration, si.SubAction
 		return convertField(ctx, db, collectionName, subAction.Second.ActionSchema.Fields[0], *subAction.Second.ActionSchema.FieldConvertFrom)
 	}
 
+	simulate := func() ([]string, error) {
+		if subAction.Second.ActionSchema.FieldConvertFrom == nil {
+			return nil, fmt.Errorf("FieldConvertFrom is not provided")
+		}
+
+		updatePayload := convertFieldUpdatePayload(subAction.Second.ActionSchema.Fields[0], *subAction.Second.ActionSchema.FieldConvertFrom)
+		return []string{shellCollectionCommand(collectionName, "updateMany", bson.M{}, updatePayload, bypassValidationShellOptions)}, nil
+	}
+
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
 
@@ -286,10 +795,15 @@ func SubActionApiDropCollection(subAction dt.Pair[migrator.Migration, si.SubActi
 		return collection.Drop(ctx)
 	}
 
+	simulate := func() ([]string, error) {
+		return []string{shellCollectionCommand(collectionName, "drop")}, nil
+	}
+
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
 
@@ -300,6 +814,11 @@ func SubActionApiDropIndex(subAction dt.Pair[migrator.Migration, si.SubAction])
 		indexes := subAction.Second.ActionSchema.Indexes
 		for _, index := range indexes {
 			_, err := coll.Indexes().DropOne(ctx, index.Spec().GetName())
+			// the index might have been dropped to rebuild a conflicting index, @see recreateConflictingIndex
+			if hasErrorCode(err, errCodeIndexNotFound) && isIndexDropAllowed(ctx) {
+				continue
+			}
+
 			if err != nil {
 				return err
 			}
@@ -308,30 +827,184 @@ func SubActionApiDropIndex(subAction dt.Pair[migrator.Migration, si.SubAction])
 		return nil
 	}
 
+	simulate := func() ([]string, error) {
+		res := []string{}
+		for _, index := range subAction.Second.ActionSchema.Indexes {
+			res = append(res, shellCollectionCommand(collectionName, "dropIndex", index.Spec().GetName()))
+		}
+
+		return res, nil
+	}
+
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
 
 func SubActionApiDropField(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
 	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
 	exec := func(ctx context.Context, db *mongo.Database) error {
-		coll := db.Collection(collectionName)
-		payload := subAction.Second.GetFieldsBsonD()
-		unsetPayload := bson.M{
-			"$unset": dropFieldUnsetPayload(payload, ""),
+		if pipeline := fieldUpdatePipeline(subAction.Second.ActionSchema.Fields, true); pipeline != nil {
+			return updateWithPipeline(ctx, db, collectionName, pipeline)
 		}
 
-		_, err := coll.UpdateMany(ctx, bson.M{}, unsetPayload)
+		coll := db.Collection(collectionName)
+		unsetPayload := dropFieldUpdatePayload(subAction.Second.GetFieldsBsonD())
+		_, err := coll.UpdateMany(ctx, bson.M{}, unsetPayload, bypassValidationUpdateOptions())
 
 		return err
 	}
 
+	simulate := func() ([]string, error) {
+		if pipeline := fieldUpdatePipeline(subAction.Second.ActionSchema.Fields, true); pipeline != nil {
+			return []string{shellCollectionCommand(collectionName, "updateMany", bson.M{}, pipeline, bypassValidationShellOptions)}, nil
+		}
+
+		unsetPayload := dropFieldUpdatePayload(subAction.Second.GetFieldsBsonD())
+		return []string{shellCollectionCommand(collectionName, "updateMany", bson.M{}, unsetPayload, bypassValidationShellOptions)}, nil
+	}
+
+	return SubActionApi{
+		Migration: subAction.First,
+		SubAction: subAction.Second,
+		Execute:   exec,
+		Simulate:  simulate,
+	}
+}
+
+func SubActionApiRenameField(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
+	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
+	exec := func(ctx context.Context, db *mongo.Database) error {
+		return renameField(ctx, db, collectionName, subAction.Second.ActionSchema.Fields[0])
+	}
+
+	simulate := func() ([]string, error) {
+		updatePayload := renameFieldUpdatePayload(si.GetRenamedFieldPath(subAction.Second.ActionSchema.Fields[0]))
+		return []string{shellCollectionCommand(collectionName, "updateMany", bson.M{}, updatePayload, bypassValidationShellOptions)}, nil
+	}
+
+	return SubActionApi{
+		Migration: subAction.First,
+		SubAction: subAction.Second,
+		Execute:   exec,
+		Simulate:  simulate,
+	}
+}
+
+func SubActionApiSetValidator(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
+	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
+	exec := func(ctx context.Context, db *mongo.Database) error {
+		return db.RunCommand(ctx, setValidatorCommand(collectionName, subAction.Second.ActionSchema.Fields)).Err()
+	}
+
+	simulate := func() ([]string, error) {
+		return []string{simulateRunCommand(setValidatorCommand(collectionName, subAction.Second.ActionSchema.Fields))}, nil
+	}
+
+	return SubActionApi{
+		Migration: subAction.First,
+		SubAction: subAction.Second,
+		Execute:   exec,
+		Simulate:  simulate,
+	}
+}
+
+func SubActionApiUnsetValidator(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
+	collectionName := subAction.Second.ActionSchema.Collection.Spec().Name
+	exec := func(ctx context.Context, db *mongo.Database) error {
+		return db.RunCommand(ctx, setValidatorCommand(collectionName, nil)).Err()
+	}
+
+	simulate := func() ([]string, error) {
+		return []string{simulateRunCommand(setValidatorCommand(collectionName, nil))}, nil
+	}
+
+	return SubActionApi{
+		Migration: subAction.First,
+		SubAction: subAction.Second,
+		Execute:   exec,
+		Simulate:  simulate,
+	}
+}
+
+func SubActionApiModifyCollection(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
+	schema := subAction.Second.ActionSchema
+	commands, notes := modifyCollectionCommands(schema.CollectionModifyFrom, schema.Collection)
+	exec := func(ctx context.Context, db *mongo.Database) error {
+		for _, note := range notes {
+			log.Printf("Warning: %s\n", note)
+		}
+
+		for _, command := range commands {
+			err := db.RunCommand(ctx, command).Err()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	simulate := func() ([]string, error) {
+		res := []string{}
+		for _, note := range notes {
+			res = append(res, fmt.Sprintf("// %s", note))
+		}
+
+		for _, command := range commands {
+			res = append(res, simulateRunCommand(command))
+		}
+
+		return res, nil
+	}
+
+	return SubActionApi{
+		Migration: subAction.First,
+		SubAction: subAction.Second,
+		Execute:   exec,
+		Simulate:  simulate,
+	}
+}
+
+func SubActionApiModifyIndex(subAction dt.Pair[migrator.Migration, si.SubAction]) SubActionApi {
+	schema := subAction.Second.ActionSchema
+	collectionName := schema.Collection.Spec().Name
+	commands := modifyIndexCommands(collectionName, schema.IndexModifyFrom, schema.Indexes[0])
+	exec := func(ctx context.Context, db *mongo.Database) error {
+		// the conversion to unique index is also checked against existing documents
+		if !schema.IndexModifyFrom.Spec().HasRule(index.OptionUnique) {
+			err := checkUniqueIndexes(ctx, db, collectionName, subAction.Second.GetIndexesBsonD())
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, command := range commands {
+			err := db.RunCommand(ctx, command).Err()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	simulate := func() ([]string, error) {
+		res := []string{}
+		for _, command := range commands {
+			res = append(res, simulateRunCommand(command))
+		}
+
+		return res, nil
+	}
+
 	return SubActionApi{
 		Migration: subAction.First,
 		SubAction: subAction.Second,
 		Execute:   exec,
+		Simulate:  simulate,
 	}
 }
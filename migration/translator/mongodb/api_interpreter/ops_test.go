@@ -13,7 +13,7 @@ import (
 	"fmt"
 	//"log"
 	"reflect"
-	//"strings"
+	"strings"
 	"testing"
 	//"time"
 	"os"
@@ -28,6 +28,7 @@ import (
 	"github.com/amirkode/go-mongr8/collection/index"
 	"github.com/amirkode/go-mongr8/collection/metadata"
 	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/option"
 	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
 	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
 
@@ -382,9 +383,83 @@ func TestSubActionApiCreateIndex(t *testing.T) {
 		[]collection.Index{},
 	), "Case 2: Unexpected Indexes")
 
+	// Case 3: unique index on duplicate values is rejected before creation
+	_, err = db.Collection(MockCollection).InsertMany(*ctx, []interface{}{
+		bson.M{"email": "john@mail.com"},
+		bson.M{"email": "john@mail.com"},
+	})
+	test.AssertTrue(t, err == nil, "Case 3: Error while inserting duplicates")
+	case3SubActionApi := SubActionApiCreateIndex(dt.NewPair(
+		migrator.Migration{},
+		*si.SubActionCreateIndex(si.SubActionSchema{
+			Collection: metadata.InitMetadata(MockCollection),
+			Indexes: []collection.Index{
+				index.SingleFieldIndex(
+					index.Field("email", 1),
+				).AsUnique(),
+			},
+		}),
+	))
+	case3Err := case3SubActionApi.Execute(*ctx, db)
+
+	test.AssertTrue(t, case3Err != nil && strings.Contains(case3Err.Error(), `{"email":"john@mail.com"}: 2 documents`), "Case 3: Duplicate keys must be reported")
+
+	// Case 4: rebuild the index on name as sparse, it cannot coexist with the previous index
+	case4Previous := index.SingleFieldIndex(index.Field("name", 1))
+	case4Rebuilt := index.SingleFieldIndex(index.Field("name", 1)).AsSparse()
+	case4SubActionApi := SubActionApiCreateIndex(dt.NewPair(
+		migrator.Migration{},
+		*si.SubActionCreateIndex(si.SubActionSchema{
+			Collection: metadata.InitMetadata(MockCollection),
+			Indexes:    []collection.Index{case4Rebuilt},
+		}),
+	))
+	case4Err := case4SubActionApi.Execute(*ctx, db)
+
+	test.AssertTrue(t, case4Err != nil && strings.Contains(case4Err.Error(), option.MigrationOptionArgAllowIndexDrop), "Case 4: The conflict must be reported without the explicit option")
+	test.AssertTrue(t, indexesAreValid(*ctx, db, MockCollection, []collection.Index{case4Previous}, []collection.Index{case4Rebuilt}), "Case 4: The previous index must be kept")
+
+	// the previous index is dropped first with the explicit option
+	case4Ctx := context.WithValue(*ctx, option.MigrationOptionKey, option.MigrationOption{AllowIndexDrop: true})
+	case4Err = case4SubActionApi.Execute(case4Ctx, db)
+
+	test.AssertTrue(t, case4Err == nil, "Case 4: Unexpected error with the explicit option")
+	test.AssertTrue(t, indexesAreValid(*ctx, db, MockCollection, []collection.Index{case4Rebuilt}, []collection.Index{case4Previous}), "Case 4: The index must be rebuilt")
+
+	// the following drop of the previous index has nothing to drop
+	case4DropSubActionApi := SubActionApiDropIndex(dt.NewPair(
+		migrator.Migration{},
+		*si.SubActionDropIndex(si.SubActionSchema{
+			Collection: metadata.InitMetadata(MockCollection),
+			Indexes:    []collection.Index{case4Previous},
+		}),
+	))
+
+	test.AssertTrue(t, case4DropSubActionApi.Execute(case4Ctx, db) == nil, "Case 4: The dropped previous index must be skipped")
+
 	// TODO: add more cases
 }
 
+func TestIndexConflict(t *testing.T) {
+	// Case 1: index keys are compared regardless of the numeric type
+	test.AssertTrue(t, isSameIndexKeys(bson.D{{Key: "name", Value: 1}, {Key: "age", Value: -1}}, bson.D{{Key: "name", Value: int32(1)}, {Key: "age", Value: float64(-1)}}), "Case 1: Keys must be the same")
+	test.AssertTrue(t, isSameIndexKeys(bson.D{{Key: "location", Value: "2dsphere"}}, bson.D{{Key: "location", Value: "2dsphere"}}), "Case 1: Keys must be the same")
+	test.AssertTrue(t, !isSameIndexKeys(bson.D{{Key: "name", Value: 1}}, bson.D{{Key: "name", Value: int32(-1)}}), "Case 1: Keys of different directions must not be the same")
+	test.AssertTrue(t, !isSameIndexKeys(bson.D{{Key: "name", Value: 1}, {Key: "age", Value: 1}}, bson.D{{Key: "age", Value: 1}, {Key: "name", Value: 1}}), "Case 1: Keys of different orders must not be the same")
+	test.AssertTrue(t, !isSameIndexKeys(bson.D{{Key: "name", Value: 1}}, bson.D{{Key: "name", Value: 1}, {Key: "age", Value: 1}}), "Case 1: Keys of different lengths must not be the same")
+
+	// Case 2: conflict errors
+	test.AssertTrue(t, isIndexConflictError(mongo.CommandError{Code: errCodeIndexOptionsConflict}), "Case 2: IndexOptionsConflict must be a conflict")
+	test.AssertTrue(t, isIndexConflictError(fmt.Errorf("wrapped: %w", mongo.CommandError{Code: errCodeIndexKeySpecsConflict})), "Case 2: Wrapped IndexKeySpecsConflict must be a conflict")
+	test.AssertTrue(t, !isIndexConflictError(mongo.CommandError{Code: errCodeIndexNotFound}), "Case 2: IndexNotFound must not be a conflict")
+	test.AssertTrue(t, !isIndexConflictError(nil), "Case 2: No error must not be a conflict")
+
+	// Case 3: dropping a conflicting index must be explicitly allowed
+	test.AssertTrue(t, !isIndexDropAllowed(context.Background()), "Case 3: Index drop must not be allowed without the option")
+	test.AssertTrue(t, !isIndexDropAllowed(context.WithValue(context.Background(), option.MigrationOptionKey, option.MigrationOption{})), "Case 3: Index drop must not be allowed by default")
+	test.AssertTrue(t, isIndexDropAllowed(context.WithValue(context.Background(), option.MigrationOptionKey, option.MigrationOption{AllowIndexDrop: true})), "Case 3: Index drop must be allowed with the option")
+}
+
 func TestSubActionApiCreateField(t *testing.T) {
 	db, ctx := getMockDatabase()
 
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package api_interpreter
+
+// translate sub action to mongo shell commands without touching the database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+
+	"github.com/amirkode/go-mongr8/collection"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// write options of every document changes, @see bypassValidationUpdateOptions
+var bypassValidationShellOptions = bson.M{"bypassDocumentValidation": true}
+
+// this converts maps into documents with sorted keys, so the output is deterministic
+func sortedShellValue(value interface{}) interface{} {
+	sortedD := func(mp map[string]interface{}) bson.D {
+		keys := []string{}
+		for key := range mp {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		res := bson.D{}
+		for _, key := range keys {
+			res = append(res, bson.E{Key: key, Value: sortedShellValue(mp[key])})
+		}
+
+		return res
+	}
+
+	switch v := value.(type) {
+	case bson.M:
+		return sortedD(v)
+	case map[string]interface{}:
+		return sortedD(v)
+	case bson.D:
+		res := bson.D{}
+		for _, e := range v {
+			res = append(res, bson.E{Key: e.Key, Value: sortedShellValue(e.Value)})
+		}
+
+		return res
+	case bson.A:
+		res := bson.A{}
+		for _, item := range v {
+			res = append(res, sortedShellValue(item))
+		}
+
+		return res
+	case []interface{}:
+		return sortedShellValue(bson.A(v))
+	}
+
+	return value
+}
+
+// this renders a value in mongo shell syntax (relaxed extended JSON)
+func toShellSyntax(value interface{}) string {
+	// only documents can be marshalled, so the value is wrapped
+	raw, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: sortedShellValue(value)}}, false, false)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	// strip the wrapper: `{"v":` and `}`
+	return string(raw[len(`{"v":`) : len(raw)-1])
+}
+
+func shellCollectionCommand(collName, method string, args ...interface{}) string {
+	literalArgs := []string{}
+	for _, arg := range args {
+		literalArgs = append(literalArgs, toShellSyntax(arg))
+	}
+
+	return fmt.Sprintf("db.getCollection(%s).%s(%s)", toShellSyntax(collName), method, strings.Join(literalArgs, ", "))
+}
+
+func simulateRunCommand(command bson.D) string {
+	return fmt.Sprintf("db.runCommand(%s)", toShellSyntax(command))
+}
+
+func simulateCreateCollection(meta collection.Metadata) string {
+	opt := createCollectionOptions(meta)
+	optPayload := bson.D{}
+	if opt.Capped != nil {
+		optPayload = append(optPayload, bson.E{Key: "capped", Value: *opt.Capped})
+	}
+
+	if opt.SizeInBytes != nil {
+		optPayload = append(optPayload, bson.E{Key: "size", Value: *opt.SizeInBytes})
+	}
+
+	if opt.ExpireAfterSeconds != nil {
+		optPayload = append(optPayload, bson.E{Key: "expireAfterSeconds", Value: *opt.ExpireAfterSeconds})
+	}
+
+	if opt.TimeSeriesOptions != nil {
+		timeSeries := bson.D{{Key: "timeField", Value: opt.TimeSeriesOptions.TimeField}}
+		if opt.TimeSeriesOptions.MetaField != nil {
+			timeSeries = append(timeSeries, bson.E{Key: "metaField", Value: *opt.TimeSeriesOptions.MetaField})
+		}
+
+		if opt.TimeSeriesOptions.Granularity != nil {
+			timeSeries = append(timeSeries, bson.E{Key: "granularity", Value: *opt.TimeSeriesOptions.Granularity})
+		}
+
+		if opt.TimeSeriesOptions.BucketMaxSpan != nil {
+			timeSeries = append(timeSeries, bson.E{Key: "bucketMaxSpanSeconds", Value: int64(opt.TimeSeriesOptions.BucketMaxSpan.Seconds())})
+		}
+
+		if opt.TimeSeriesOptions.BucketRounding != nil {
+			timeSeries = append(timeSeries, bson.E{Key: "bucketRoundingSeconds", Value: int64(opt.TimeSeriesOptions.BucketRounding.Seconds())})
+		}
+
+		optPayload = append(optPayload, bson.E{Key: "timeseries", Value: timeSeries})
+	}
+
+	if collation := meta.Spec().GetCollation(); collation != nil {
+		optPayload = append(optPayload, bson.E{Key: "collation", Value: collation})
+	}
+
+	if len(optPayload) == 0 {
+		return fmt.Sprintf("db.createCollection(%s)", toShellSyntax(meta.Spec().Name))
+	}
+
+	return fmt.Sprintf("db.createCollection(%s, %s)", toShellSyntax(meta.Spec().Name), toShellSyntax(optPayload))
+}
+
+func simulateCreateView(meta collection.Metadata) string {
+	args := []string{
+		toShellSyntax(meta.Spec().Name),
+		toShellSyntax(meta.Spec().GetViewOn()),
+		toShellSyntax(viewPipeline(meta)),
+	}
+
+	if collation := meta.Spec().GetCollation(); collation != nil {
+		args = append(args, toShellSyntax(bson.D{{Key: "collation", Value: collation}}))
+	}
+
+	return fmt.Sprintf("db.createView(%s)", strings.Join(args, ", "))
+}
+
+func simulateCreateIndexes(collName string, indexes []dt.Pair[string, dt.Pair[bson.D, bson.D]]) []string {
+	res := []string{}
+	for _, idx := range indexes {
+		opt := append(bson.D{{Key: "name", Value: idx.First}}, idx.Second.Second...)
+		res = append(res, shellCollectionCommand(collName, "createIndex", idx.Second.First, opt))
+	}
+
+	return res
+}
+
+func simulateCreateField(collName string, payload bson.D) []string {
+	// a missing parent path is created, when no document has it
+	res := []string{}
+	for _, step := range getCreatePathSteps(payload) {
+		res = append(res, fmt.Sprintf("if (%s == 0) %s",
+			shellCollectionCommand(collName, "countDocuments", step.check),
+			shellCollectionCommand(collName, "updateMany", bson.M{}, step.update, bypassValidationShellOptions),
+		))
+	}
+
+	return append(res, shellCollectionCommand(collName, "updateMany", bson.M{}, createFieldUpdatePayload(payload), bson.D{{Key: "upsert", Value: true}, {Key: "bypassDocumentValidation", Value: true}}))
+}
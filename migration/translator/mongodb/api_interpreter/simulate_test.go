@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package api_interpreter
+
+import (
+	"testing"
+
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestToShellSyntax(t *testing.T) {
+	Convey("To Shell Syntax", t, func() {
+		So(toShellSyntax("users"), ShouldEqual, `"users"`)
+		So(toShellSyntax(bson.D{{Key: "name", Value: 1}}), ShouldEqual, `{"name":1}`)
+		So(toShellSyntax(bson.A{bson.D{{Key: "$set", Value: bson.D{{Key: "age", Value: "$age"}}}}}), ShouldEqual, `[{"$set":{"age":"$age"}}]`)
+	})
+}
+
+// this returns the simulated commands of a sub action expected to succeed
+func mustSimulate(api SubActionApi) []string {
+	commands, err := api.Simulate()
+	So(err, ShouldBeNil)
+
+	return commands
+}
+
+func TestSimulate(t *testing.T) {
+	migration := migrator.Migration{ID: "20240101_000000"}
+	meta := metadata.InitMetadata("logs").Capped(1024)
+
+	Convey("Simulate Create Collection", t, func() {
+		api := SubActionApiCreateCollection(dt.NewPair(migration, *si.SubActionCreateCollection(si.SubActionSchema{
+			Collection: meta,
+			Fields: []collection.Field{
+				field.StringField("message"),
+			},
+			Indexes: []collection.Index{
+				index.SingleFieldIndex(index.Field("message", 1)).AsUnique(),
+			},
+		})))
+
+		commands := mustSimulate(api)
+		So(commands, ShouldResemble, []string{
+			`db.createCollection("logs", {"capped":true,"size":1024})`,
+			`db.getCollection("logs").insertOne({"message":""}, {"bypassDocumentValidation":true})`,
+			`db.getCollection("logs").createIndex({"message":1}, {"name":"message_1_true_unique","unique":true})`,
+		})
+	})
+
+	Convey("Simulate Create Time Series Collection", t, func() {
+		api := SubActionApiCreateCollection(dt.NewPair(migration, *si.SubActionCreateCollection(si.SubActionSchema{
+			Collection: metadata.InitMetadata("metrics").TimeSeries("timestamp").SetMetaField("device").SetGranularity(metadata.GranularityMinutes).TTL(3600),
+			Fields: []collection.Field{
+				field.TimestampField("timestamp"),
+				field.StringField("device"),
+			},
+		})))
+
+		So(mustSimulate(api)[0], ShouldEqual,
+			`db.createCollection("metrics", {"expireAfterSeconds":3600,"timeseries":{"timeField":"timestamp","metaField":"device","granularity":"minutes"}})`,
+		)
+	})
+
+	Convey("Simulate Create View", t, func() {
+		api := SubActionApiCreateCollection(dt.NewPair(migration, *si.SubActionCreateCollection(si.SubActionSchema{
+			Collection: metadata.InitMetadata("error_logs").ViewOn("logs", []map[string]interface{}{
+				{"$match": map[string]interface{}{"level": "error"}},
+			}).SetCollation(map[string]interface{}{"locale": "en"}),
+			Fields: []collection.Field{
+				field.StringField("message"),
+			},
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.createView("error_logs", "logs", [{"$match":{"level":"error"}}], {"collation":{"locale":"en"}})`,
+		})
+	})
+
+	Convey("Simulate Create Field With Default", t, func() {
+		api := SubActionApiCreateField(dt.NewPair(migration, *si.SubActionCreateField(si.SubActionSchema{
+			Collection: meta,
+			Fields: []collection.Field{
+				field.ArrayField("tx_history",
+					field.ObjectField("",
+						field.StringField("status").SetDefault("active"),
+					),
+				),
+			},
+		})))
+
+		// the missing parent paths are created as the execution does
+		So(mustSimulate(api), ShouldResemble, []string{
+			`if (db.getCollection("logs").countDocuments({"tx_history":{"$exists":true}}) == 0) db.getCollection("logs").updateMany({}, {"$set":{"tx_history":[]}}, {"bypassDocumentValidation":true})`,
+			`if (db.getCollection("logs").countDocuments({"tx_history.0":{"$exists":true}}) == 0) db.getCollection("logs").updateMany({}, {"$push":{"tx_history":{}}}, {"bypassDocumentValidation":true})`,
+			`db.getCollection("logs").updateMany({}, {"$set":{"tx_history.$[].status":"active"}}, {"upsert":true,"bypassDocumentValidation":true})`,
+		})
+	})
+
+	Convey("Simulate Convert Field Without Previous Type", t, func() {
+		api := SubActionApiConvertField(dt.NewPair(migration, *si.SubActionConvertField(si.SubActionSchema{
+			Collection: meta,
+			Fields: []collection.Field{
+				field.Int64Field("count"),
+			},
+		})))
+
+		_, err := api.Simulate()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "FieldConvertFrom is not provided")
+	})
+
+	Convey("Simulate Drop Field", t, func() {
+		api := SubActionApiDropField(dt.NewPair(migration, *si.SubActionDropField(si.SubActionSchema{
+			Collection: meta,
+			Fields: []collection.Field{
+				field.StringField("message"),
+			},
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.getCollection("logs").updateMany({}, {"$unset":{"message":""}}, {"bypassDocumentValidation":true})`,
+		})
+	})
+
+	Convey("Simulate Drop Collection", t, func() {
+		api := SubActionApiDropCollection(dt.NewPair(migration, *si.SubActionDropCollection(si.SubActionSchema{
+			Collection: meta,
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{`db.getCollection("logs").drop()`})
+	})
+
+	Convey("Simulate Rename Field", t, func() {
+		api := SubActionApiRenameField(dt.NewPair(migration, *si.SubActionRenameField(si.SubActionSchema{
+			Collection: meta,
+			Fields: []collection.Field{
+				field.ObjectField("detail",
+					field.StringField("content").RenamedFrom("message"),
+				),
+			},
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.getCollection("logs").updateMany({}, {"$rename":{"detail.message":"detail.content"}}, {"bypassDocumentValidation":true})`,
+		})
+	})
+
+	Convey("Simulate Validator", t, func() {
+		api := SubActionApiSetValidator(dt.NewPair(migration, *si.SubActionSetValidator(si.SubActionSchema{
+			Collection: meta,
+			Fields: []collection.Field{
+				field.StringField("message"),
+			},
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.runCommand({"collMod":"logs","validator":{"$jsonSchema":{"bsonType":"object","properties":{"message":{"bsonType":"string"}},"required":["message"]}}})`,
+		})
+
+		api = SubActionApiUnsetValidator(dt.NewPair(migration, *si.SubActionUnsetValidator(si.SubActionSchema{
+			Collection: meta,
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{`db.runCommand({"collMod":"logs","validator":{}})`})
+	})
+
+	Convey("Simulate Modify Collection", t, func() {
+		api := SubActionApiModifyCollection(dt.NewPair(migration, *si.SubActionModifyCollection(si.SubActionSchema{
+			Collection:           metadata.InitMetadata("logs").Capped(2048).TTL(60),
+			CollectionModifyFrom: meta,
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.runCommand({"collMod":"logs","cappedSize":2048})`,
+			`db.runCommand({"collMod":"logs","expireAfterSeconds":60})`,
+		})
+
+		api = SubActionApiModifyCollection(dt.NewPair(migration, *si.SubActionModifyCollection(si.SubActionSchema{
+			Collection:           meta,
+			CollectionModifyFrom: metadata.InitMetadata("logs"),
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{`db.runCommand({"convertToCapped":"logs","size":1024})`})
+
+		// rollback of capping
+		api = SubActionApiModifyCollection(dt.NewPair(migration, *si.SubActionModifyCollection(si.SubActionSchema{
+			Collection:           metadata.InitMetadata("logs").TTL(60),
+			CollectionModifyFrom: meta,
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`// capped collection logs cannot be uncapped, skipped`,
+			`db.runCommand({"collMod":"logs","expireAfterSeconds":60})`,
+		})
+
+		api = SubActionApiModifyCollection(dt.NewPair(migration, *si.SubActionModifyCollection(si.SubActionSchema{
+			Collection:           metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularityHours),
+			CollectionModifyFrom: metadata.InitMetadata("metrics").TimeSeries("timestamp").SetGranularity(metadata.GranularityMinutes).TTL(60),
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.runCommand({"collMod":"metrics","expireAfterSeconds":"off"})`,
+			`db.runCommand({"collMod":"metrics","timeseries":{"granularity":"hours"}})`,
+		})
+	})
+
+	Convey("Simulate Modify Index", t, func() {
+		api := SubActionApiModifyIndex(dt.NewPair(migration, *si.SubActionModifyIndex(si.SubActionSchema{
+			Collection: meta,
+			Indexes: []collection.Index{
+				index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(120).AsUnique().SetCustomIndexName("created_at_ttl"),
+			},
+			IndexModifyFrom: index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(60).AsHidden().SetCustomIndexName("created_at_ttl"),
+		})))
+
+		So(mustSimulate(api), ShouldResemble, []string{
+			`db.runCommand({"collMod":"logs","index":{"name":"created_at_ttl","hidden":false}})`,
+			`db.runCommand({"collMod":"logs","index":{"name":"created_at_ttl","expireAfterSeconds":120}})`,
+			`db.runCommand({"collMod":"logs","index":{"name":"created_at_ttl","prepareUnique":true}})`,
+			`db.runCommand({"collMod":"logs","index":{"name":"created_at_ttl","unique":true}})`,
+		})
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
 	"github.com/amirkode/go-mongr8/internal/test"
+	"github.com/amirkode/go-mongr8/internal/util"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -55,7 +56,7 @@ func getParentPath(path string) string {
 
 // This functions returns all possible paths based on the payload
 func checkPathExistPayloads(curr interface{}, path string, res *[]bson.D) {
-	if reflect.TypeOf(curr) == reflect.TypeOf(bson.D{}) {
+	if reflect.TypeOf(curr) == reflect.TypeOf(bson.D{}) && len(curr.(bson.D)) > 0 {
 		d := curr.(bson.D)
 		if reflect.TypeOf(d[0].Value) == reflect.TypeOf(bson.A{}) ||
 			reflect.TypeOf(d[0].Value) == reflect.TypeOf(bson.D{}) {
@@ -113,6 +114,11 @@ func checkPathExistPayloads(curr interface{}, path string, res *[]bson.D) {
 // must be a one way path as assured in migration generation
 // @see migration/translation/sync_strategy/ for more clarity
 //
+// the only exception is an object with more than one key, such as
+// a restored drop checkpoint on rollback or a Geo JSON object,
+// or an empty object, such as a map without any key,
+// in that case the whole object is set to the current path
+//
 // Parameters:
 // `curr` represents the current payload yet to explore
 // `path` represents the path has been explored so far
@@ -121,8 +127,9 @@ func checkPathExistPayloads(curr interface{}, path string, res *[]bson.D) {
 func createFieldSetPayload(curr interface{}, path string) bson.M {
 	if reflect.TypeOf(curr) == reflect.TypeOf(bson.D{}) {
 		d := curr.(bson.D)
-		test.Assert(len(d) == 1, "createFieldSetPayload", "Object is not one way path")
-		return createFieldSetPayload(d[0].Value, appendPath(path, d[0].Key))
+		if len(d) == 1 {
+			return createFieldSetPayload(d[0].Value, appendPath(path, d[0].Key))
+		}
 	} else if reflect.TypeOf(curr) == reflect.TypeOf(bson.A{}) {
 		a := curr.(bson.A)
 		test.Assert(len(a) == 1, "createFieldSetPayload", "Array is not one way path")
@@ -138,6 +145,36 @@ func createFieldSetPayload(curr interface{}, path string) bson.M {
 	}
 }
 
+// This returns a copy of fields without any drop checkpoint
+// a field created from a Down action of a field drop (i.e: on rollback)
+// still carries the checkpoint, but the whole structure must be restored
+func withoutDropCheckpoints(fields []collection.Field) []collection.Field {
+	var clear func(spec *field.Spec)
+	clear = func(spec *field.Spec) {
+		delete(spec.Extra, field.ExtraDrop)
+		if spec.ArrayFields != nil {
+			for idx := range *spec.ArrayFields {
+				clear(&(*spec.ArrayFields)[idx])
+			}
+		}
+
+		if spec.Object != nil {
+			for idx := range *spec.Object {
+				clear(&(*spec.Object)[idx])
+			}
+		}
+	}
+
+	res := make([]collection.Field, len(fields))
+	for idx, f := range fields {
+		spec := util.DeepCopy(*f.Spec())
+		clear(&spec)
+		res[idx] = field.FromFieldSpec(&spec)
+	}
+
+	return res
+}
+
 func dropFieldUnsetPayload(curr interface{}, path string) bson.M {
 	// check wether we need deeper drop path
 	if reflect.TypeOf(curr) == reflect.TypeOf(bson.D{}) && len(curr.(bson.D)) > 0 {
@@ -166,7 +203,7 @@ func convertFunction(to field.FieldType, from field.FieldType) string {
 		return "$toString"
 	case field.TypeBoolean:
 		return "$toBool"
-	case field.TypeTimestamp:
+	case field.TypeTimestamp, field.TypeDate:
 		return "$toDate"
 	case field.TypeInt32:
 		return "$toInt"
@@ -174,6 +211,10 @@ func convertFunction(to field.FieldType, from field.FieldType) string {
 		return "$toLong"
 	case field.TypeDouble:
 		return "$toDouble"
+	case field.TypeDecimal128:
+		return "$toDecimal"
+	case field.TypeObjectID:
+		return "$toObjectId"
 		// TODO: complete for the future usecases
 	}
 
@@ -188,6 +229,10 @@ func convertFieldObjectPayload(curr collection.Field, path string, from field.Fi
 		child = convertFieldMapPayload(field.FromFieldSpec(&(*curr.Spec().ArrayFields)[0]), appendPath(path, curr.Spec().Name), from, depth)
 	case field.TypeObject:
 		child = convertFieldObjectPayload(field.FromFieldSpec(&(*curr.Spec().Object)[0]), appendPath(path, curr.Spec().Name), from, depth)
+	case field.TypeMap:
+		child = convertFieldKeyValuePayload(field.FromFieldSpec(curr.Spec().GetMapValue()), appendPath(path, curr.Spec().Name), from, depth)
+	case field.TypeOneOf:
+		child = convertFieldVariantPayload(curr, appendPath(path, curr.Spec().Name), from, depth)
 	default:
 		child = bson.M{
 			convertFunction(curr.Spec().Type, from): fmt.Sprintf("$%s", appendPath(path, curr.Spec().Name)),
@@ -210,6 +255,10 @@ func convertFieldMapPayload(curr collection.Field, path string, from field.Field
 	case field.TypeObject:
 		// this must be a child of map operation
 		child = convertFieldObjectPayload(field.FromFieldSpec(&(*curr.Spec().Object)[0]), fmt.Sprintf("$%s", currAlias), from, depth)
+	case field.TypeMap:
+		child = convertFieldKeyValuePayload(field.FromFieldSpec(curr.Spec().GetMapValue()), fmt.Sprintf("$%s", currAlias), from, depth)
+	case field.TypeOneOf:
+		child = convertFieldVariantPayload(curr, fmt.Sprintf("$%s", currAlias), from, depth)
 	default:
 		child = bson.M{
 			convertFunction(curr.Spec().Type, from): fmt.Sprintf("$$%s", currAlias),
@@ -237,6 +286,69 @@ func convertFieldMapPayload(curr collection.Field, path string, from field.Field
 	}
 }
 
+// This returns the payload converting each value of a map field,
+// the map is turned into key value pairs with `$objectToArray`, then each value is converted,
+// and the pairs are turned back into the map with `$arrayToObject`.
+// `curr` represents the value of the map
+func convertFieldKeyValuePayload(curr collection.Field, path string, from field.FieldType, depth *int) bson.M {
+	var value interface{}
+	*depth += 1
+	currAlias := fmt.Sprintf("alias_%d", *depth)
+	valuePath := fmt.Sprintf("$%s.v", currAlias)
+	switch curr.Spec().Type {
+	case field.TypeArray:
+		value = convertFieldMapPayload(field.FromFieldSpec(&(*curr.Spec().ArrayFields)[0]), valuePath, from, depth)
+	case field.TypeObject:
+		value = bson.M{
+			"$mergeObjects": bson.A{
+				fmt.Sprintf("$%s", valuePath),
+				convertFieldObjectPayload(field.FromFieldSpec(&(*curr.Spec().Object)[0]), valuePath, from, depth),
+			},
+		}
+	case field.TypeMap:
+		value = convertFieldKeyValuePayload(field.FromFieldSpec(curr.Spec().GetMapValue()), valuePath, from, depth)
+	case field.TypeOneOf:
+		value = convertFieldVariantPayload(curr, valuePath, from, depth)
+	default:
+		value = bson.M{
+			convertFunction(curr.Spec().Type, from): fmt.Sprintf("$%s", valuePath),
+		}
+	}
+
+	return bson.M{
+		"$arrayToObject": bson.M{
+			"$map": bson.M{
+				"input": bson.M{"$objectToArray": fmt.Sprintf("$%s", path)},
+				"as":    currAlias,
+				"in": bson.M{
+					"k": fmt.Sprintf("$$%s.k", currAlias),
+					"v": value,
+				},
+			},
+		},
+	}
+}
+
+// This returns the payload converting a field inside a variant of a one of field,
+// only the value matching the discriminator value of the variant is converted.
+// `curr` represents the one of field, `path` follows the same format as convertFieldObjectPayload
+func convertFieldVariantPayload(curr collection.Field, path string, from field.FieldType, depth *int) bson.M {
+	expr := fmt.Sprintf("$%s", path)
+	variant := (*curr.Spec().Object)[0]
+	return bson.M{
+		"$cond": bson.A{
+			variantMatchPayload(expr, curr.Spec(), variant.Name),
+			bson.M{
+				"$mergeObjects": bson.A{
+					expr,
+					convertFieldObjectPayload(field.FromFieldSpec(&(*variant.Object)[0]), path, from, depth),
+				},
+			},
+			expr,
+		},
+	}
+}
+
 // This returns the payload of conversions
 // Parameters:
 // `curr` represents the current instance of Field
@@ -252,6 +364,14 @@ func convertFieldSetPayload(curr collection.Field, path string, from field.Field
 		}
 	case field.TypeObject:
 		return convertFieldSetPayload(field.FromFieldSpec(&(*curr.Spec().Object)[0]), currPath, from, depth)
+	case field.TypeMap:
+		return bson.M{
+			currPath: convertFieldKeyValuePayload(field.FromFieldSpec(curr.Spec().GetMapValue()), currPath, from, depth),
+		}
+	case field.TypeOneOf:
+		return bson.M{
+			currPath: convertFieldVariantPayload(curr, currPath, from, depth),
+		}
 	}
 
 	return bson.M{
@@ -260,3 +380,435 @@ func convertFieldSetPayload(curr collection.Field, path string, from field.Field
 		},
 	}
 }
+
+// This returns the payload renaming a field inside the value of `expr`,
+// the value is expected to be an object containing the next field of `path`.
+// The value is kept as it is, if it's not an object
+// Parameters:
+// `path` represents the remaining one way path to the renamed field
+// `expr` represents the expression of current value, i.e: "$$alias_1.field"
+// `depth` represents the the depth of map operations has reached
+func renameFieldObjectPayload(path []*field.Spec, expr string, depth *int) bson.M {
+	head := path[0]
+	var renamed interface{}
+	if len(path) == 1 {
+		// remove the previous key and set the new key
+		*depth += 1
+		renamed = bson.M{
+			"$mergeObjects": bson.A{
+				bson.M{
+					"$arrayToObject": bson.M{
+						"$filter": bson.M{
+							"input": bson.M{"$objectToArray": expr},
+							"as":    fmt.Sprintf("kv_%d", *depth),
+							"cond":  bson.M{"$ne": bson.A{fmt.Sprintf("$$kv_%d.k", *depth), head.GetRenamedFrom()}},
+						},
+					},
+				},
+				bson.M{
+					head.Name: fmt.Sprintf("%s.%s", expr, head.GetRenamedFrom()),
+				},
+			},
+		}
+	} else {
+		renamed = bson.M{
+			"$mergeObjects": bson.A{
+				expr,
+				bson.M{
+					head.Name: renameFieldValuePayload(path[1:], head, fmt.Sprintf("%s.%s", expr, head.Name), depth),
+				},
+			},
+		}
+	}
+
+	return bson.M{
+		"$cond": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$type": expr}, "object"}},
+			renamed,
+			expr,
+		},
+	}
+}
+
+// This returns the payload of the value of `curr` field containing the renamed field
+func renameFieldValuePayload(path []*field.Spec, curr *field.Spec, expr string, depth *int) bson.M {
+	if curr.Type == field.TypeArray {
+		*depth += 1
+		currAlias := fmt.Sprintf("alias_%d", *depth)
+		// the array item is not part of the renamed path, but the item itself
+		return bson.M{
+			"$cond": bson.A{
+				bson.M{"$isArray": expr},
+				bson.M{
+					"$map": bson.M{
+						"input": expr,
+						"as":    currAlias,
+						"in":    renameFieldValuePayload(path[1:], path[0], fmt.Sprintf("$$%s", currAlias), depth),
+					},
+				},
+				expr,
+			},
+		}
+	}
+
+	if curr.Type == field.TypeMap {
+		// the map value is not part of the renamed path, but the value itself
+		return bson.M{
+			"$cond": bson.A{
+				isObjectPayload(expr),
+				mapValuesPayload(expr, depth, func(valueExpr string) interface{} {
+					return renameFieldValuePayload(path[1:], path[0], valueExpr, depth)
+				}),
+				expr,
+			},
+		}
+	}
+
+	if curr.Type == field.TypeOneOf {
+		// the variant shares the value of the one of, and only the matching variant is renamed
+		variant := path[0]
+		return bson.M{
+			"$cond": bson.A{
+				variantMatchPayload(expr, curr, variant.Name),
+				renameFieldValuePayload(path[1:], variant, expr, depth),
+				expr,
+			},
+		}
+	}
+
+	return renameFieldObjectPayload(path, expr, depth)
+}
+
+// This returns the update payload renaming a field, `path` is the one way path to the renamed field
+// @see si.GetRenamedFieldPath
+// a plain `$rename` is used if there's no array, map, or one of along the path,
+// otherwise those are mapped with an aggregation pipeline
+func renameFieldUpdatePayload(path []*field.Spec) interface{} {
+	parentPath := ""
+	for i, spec := range path {
+		if i == len(path)-1 {
+			return bson.M{
+				"$rename": bson.M{
+					appendPath(parentPath, spec.GetRenamedFrom()): appendPath(parentPath, spec.Name),
+				},
+			}
+		}
+
+		currPath := appendPath(parentPath, spec.Name)
+		if util.InList(spec.Type, []field.FieldType{field.TypeArray, field.TypeMap, field.TypeOneOf}) {
+			depth := 0
+			return bson.A{
+				bson.M{
+					"$set": bson.M{
+						currPath: renameFieldValuePayload(path[i+1:], spec, fmt.Sprintf("$%s", currPath), &depth),
+					},
+				},
+			}
+		}
+
+		parentPath = currPath
+	}
+
+	return nil
+}
+
+func isObjectPayload(expr string) bson.M {
+	return bson.M{"$eq": bson.A{bson.M{"$type": expr}, "object"}}
+}
+
+// This checks whether the value of a one of field is the variant of `value`
+func variantMatchPayload(expr string, oneOf *field.Spec, value string) bson.M {
+	return bson.M{"$eq": bson.A{fmt.Sprintf("%s.%s", expr, oneOf.GetDiscriminator()), value}}
+}
+
+// This returns the payload mapping each value of a map field (an object with arbitrary keys),
+// `value` returns the payload of the new value given the expression of the current value
+func mapValuesPayload(expr string, depth *int, value func(valueExpr string) interface{}) bson.M {
+	*depth += 1
+	currAlias := fmt.Sprintf("alias_%d", *depth)
+	return bson.M{
+		"$arrayToObject": bson.M{
+			"$map": bson.M{
+				"input": bson.M{"$objectToArray": expr},
+				"as":    currAlias,
+				"in": bson.M{
+					"k": fmt.Sprintf("$$%s.k", currAlias),
+					"v": value(fmt.Sprintf("$$%s.v", currAlias)),
+				},
+			},
+		},
+	}
+}
+
+// This returns the one way path of a field, ending at the first field satisfying `isTarget`
+// or at the deepest field. An object (or a one of) is only followed if it has a single child,
+// otherwise the whole object is the deepest field
+func getFieldPath(f collection.Field, isTarget func(spec *field.Spec) bool) []*field.Spec {
+	res := []*field.Spec{}
+	curr := f.Spec()
+	for curr != nil {
+		res = append(res, curr)
+		if isTarget(curr) {
+			break
+		}
+
+		switch {
+		case (curr.Type == field.TypeObject || curr.Type == field.TypeOneOf) && curr.Object != nil && len(*curr.Object) == 1:
+			curr = &(*curr.Object)[0]
+		case (curr.Type == field.TypeArray || curr.Type == field.TypeMap) && curr.ArrayFields != nil && len(*curr.ArrayFields) > 0:
+			curr = &(*curr.ArrayFields)[0]
+		default:
+			curr = nil
+		}
+	}
+
+	return res
+}
+
+// This checks whether there's a map or a one of before the last field of the path,
+// the keys of a map can't be addressed by an update path,
+// and a variant must only be updated on values matching its discriminator value,
+// so an aggregation pipeline is required
+func requiresPipeline(path []*field.Spec) bool {
+	for _, spec := range path[:len(path)-1] {
+		if spec.Type == field.TypeMap || spec.Type == field.TypeOneOf {
+			return true
+		}
+	}
+
+	return false
+}
+
+// This returns the path to the field created by `f`, if a pipeline is required along the path
+// otherwise, it returns nil and the field is created with an update path
+func getCreatedFieldPipelinePath(f collection.Field) []*field.Spec {
+	path := getFieldPath(f, func(spec *field.Spec) bool {
+		return false
+	})
+	if !requiresPipeline(path) {
+		return nil
+	}
+
+	return path
+}
+
+// This returns the path to the field dropped by `f`, if a pipeline is required along the path
+// otherwise, it returns nil and the field is dropped with an update path
+func getDroppedFieldPipelinePath(f collection.Field) []*field.Spec {
+	path := getFieldPath(f, func(spec *field.Spec) bool {
+		drop, _ := spec.Extra[field.ExtraDrop].(bool)
+		return drop
+	})
+	// an array item or a map value can't be dropped by itself, so the container is dropped
+	for len(path) > 1 && util.InList(path[len(path)-2].Type, []field.FieldType{field.TypeArray, field.TypeMap}) {
+		path = path[:len(path)-1]
+	}
+
+	if !requiresPipeline(path) {
+		return nil
+	}
+
+	return path
+}
+
+// This returns the payload of the value of `path[0]` containing the created field,
+// `expr` represents the expression of current value, i.e: "$$alias_1.v".
+// The created field is the last field of `path`, and the missing parents are created along the path
+func createFieldValuePayload(path []*field.Spec, expr string, value interface{}, depth *int) interface{} {
+	curr := path[0]
+	if len(path) == 1 {
+		// the value might contain a string prefixed with "$"
+		return bson.M{"$literal": value}
+	}
+
+	switch curr.Type {
+	case field.TypeArray:
+		*depth += 1
+		currAlias := fmt.Sprintf("alias_%d", *depth)
+		return bson.M{
+			"$cond": bson.A{
+				bson.M{"$isArray": expr},
+				bson.M{
+					"$map": bson.M{
+						"input": expr,
+						"as":    currAlias,
+						"in":    createFieldValuePayload(path[1:], fmt.Sprintf("$$%s", currAlias), value, depth),
+					},
+				},
+				bson.A{},
+			},
+		}
+	case field.TypeMap:
+		return bson.M{
+			"$cond": bson.A{
+				isObjectPayload(expr),
+				mapValuesPayload(expr, depth, func(valueExpr string) interface{} {
+					return createFieldValuePayload(path[1:], valueExpr, value, depth)
+				}),
+				bson.M{},
+			},
+		}
+	case field.TypeOneOf:
+		// the variant shares the value of the one of
+		variant := path[1]
+		var created interface{}
+		if len(path) == 2 {
+			// the whole variant is created, so its fields are merged
+			created = bson.M{"$mergeObjects": bson.A{expr, bson.M{"$literal": value}}}
+		} else {
+			created = createFieldValuePayload(path[1:], expr, value, depth)
+		}
+
+		return bson.M{
+			"$cond": bson.A{
+				variantMatchPayload(expr, curr, variant.Name),
+				created,
+				expr,
+			},
+		}
+	}
+
+	// a missing object is ignored by $mergeObjects, so it's created
+	child := path[1]
+	return bson.M{
+		"$mergeObjects": bson.A{
+			expr,
+			bson.M{
+				child.Name: createFieldValuePayload(path[1:], fmt.Sprintf("%s.%s", expr, child.Name), value, depth),
+			},
+		},
+	}
+}
+
+// This returns the payload of the value of `path[0]` without the dropped field,
+// `expr` represents the expression of current value, i.e: "$$alias_1.v".
+// The dropped field is the last field of `path`, the value is kept as it is if it doesn't contain the field
+func dropFieldValuePayload(path []*field.Spec, expr string, depth *int) interface{} {
+	curr := path[0]
+	switch curr.Type {
+	case field.TypeArray:
+		*depth += 1
+		currAlias := fmt.Sprintf("alias_%d", *depth)
+		return bson.M{
+			"$cond": bson.A{
+				bson.M{"$isArray": expr},
+				bson.M{
+					"$map": bson.M{
+						"input": expr,
+						"as":    currAlias,
+						"in":    dropFieldValuePayload(path[1:], fmt.Sprintf("$$%s", currAlias), depth),
+					},
+				},
+				expr,
+			},
+		}
+	case field.TypeMap:
+		return bson.M{
+			"$cond": bson.A{
+				isObjectPayload(expr),
+				mapValuesPayload(expr, depth, func(valueExpr string) interface{} {
+					return dropFieldValuePayload(path[1:], valueExpr, depth)
+				}),
+				expr,
+			},
+		}
+	case field.TypeOneOf:
+		// the variant shares the value of the one of
+		variant := path[1]
+		var dropped interface{}
+		if len(path) == 2 {
+			// the whole variant is dropped, so all of its fields are removed
+			keys := []string{}
+			if variant.Object != nil {
+				for _, child := range *variant.Object {
+					keys = append(keys, child.Name)
+				}
+			}
+			dropped = removeKeysPayload(expr, keys, depth)
+		} else {
+			dropped = dropFieldValuePayload(path[1:], expr, depth)
+		}
+
+		return bson.M{
+			"$cond": bson.A{
+				variantMatchPayload(expr, curr, variant.Name),
+				dropped,
+				expr,
+			},
+		}
+	}
+
+	child := path[1]
+	var dropped interface{}
+	if len(path) == 2 {
+		// remove the key of the dropped field
+		dropped = removeKeysPayload(expr, []string{child.Name}, depth)
+	} else {
+		dropped = bson.M{
+			"$mergeObjects": bson.A{
+				expr,
+				bson.M{
+					child.Name: dropFieldValuePayload(path[1:], fmt.Sprintf("%s.%s", expr, child.Name), depth),
+				},
+			},
+		}
+	}
+
+	return bson.M{
+		"$cond": bson.A{
+			isObjectPayload(expr),
+			dropped,
+			expr,
+		},
+	}
+}
+
+// This returns the payload of the object value of `expr` without `keys`
+func removeKeysPayload(expr string, keys []string, depth *int) interface{} {
+	if len(keys) == 0 {
+		return expr
+	}
+
+	*depth += 1
+	kvAlias := fmt.Sprintf("kv_%d", *depth)
+	cond := bson.M{"$ne": bson.A{fmt.Sprintf("$$%s.k", kvAlias), keys[0]}}
+	if len(keys) > 1 {
+		cond = bson.M{"$not": bson.A{bson.M{"$in": bson.A{fmt.Sprintf("$$%s.k", kvAlias), keys}}}}
+	}
+
+	return bson.M{
+		"$arrayToObject": bson.M{
+			"$filter": bson.M{
+				"input": bson.M{"$objectToArray": expr},
+				"as":    kvAlias,
+				"cond":  cond,
+			},
+		},
+	}
+}
+
+// This returns the update pipeline creating the last field of `path` with `value`
+// @see getCreatedFieldPipelinePath
+func createFieldPipelinePayload(path []*field.Spec, value interface{}) bson.A {
+	depth := 0
+	return bson.A{
+		bson.M{
+			"$set": bson.M{
+				path[0].Name: createFieldValuePayload(path, fmt.Sprintf("$%s", path[0].Name), value, &depth),
+			},
+		},
+	}
+}
+
+// This returns the update pipeline dropping the last field of `path`
+// @see getDroppedFieldPipelinePath
+func dropFieldPipelinePayload(path []*field.Spec) bson.A {
+	depth := 0
+	return bson.A{
+		bson.M{
+			"$set": bson.M{
+				path[0].Name: dropFieldValuePayload(path, fmt.Sprintf("$%s", path[0].Name), &depth),
+			},
+		},
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/amirkode/go-mongr8/internal/test"
 
+	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -373,9 +374,61 @@ func TestCreateFieldSetPayload(t *testing.T) {
 
 	test.AssertTrue(t, bsonMAreEqual(case5Payload, case5ExpectedPayload), "Case 5: Unexpected Payload")
 
+	// case 6: restoring a whole object inside array (i.e: rollback of dropped field)
+	// {
+	// 	"arr": [
+	// 		{
+	// 			"obj": {
+	// 				"field1": "",
+	// 				"field2": 0
+	// 			}
+	// 		}
+	// 	]
+	// }
+	case6Obj := bson.D{
+		{Key: "field1", Value: ""},
+		{Key: "field2", Value: 0},
+	}
+	case6Field := bson.D{
+		{Key: "arr", Value: bson.A{
+			bson.D{
+				{Key: "obj", Value: case6Obj},
+			},
+		}},
+	}
+	case6Payload := createFieldSetPayload(case6Field, "")
+
+	test.AssertTrue(t, len(case6Payload) == 1, "Case 6: Unexpected Payload length")
+	test.AssertTrue(t, reflect.DeepEqual(case6Payload["arr.$[].obj"], case6Obj), "Case 6: Unexpected Payload")
+
 	// TODO: add more cases
 }
 
+func TestWithoutDropCheckpoints(t *testing.T) {
+	// case 1: nested drop checkpoint
+	Convey("Case 1: Nested drop checkpoint", t, func() {
+		original := field.ObjectField("obj",
+			field.ArrayField("arr",
+				field.ObjectField("",
+					field.StringField("field1"),
+				).SetExtra(field.ExtraDrop, true),
+			),
+		)
+		cleared := withoutDropCheckpoints([]collection.Field{original})
+		child := (*(*cleared[0].Spec().Object)[0].ArrayFields)[0]
+
+		Convey("Drop checkpoint must be removed", func() {
+			_, ok := child.Extra[field.ExtraDrop]
+			So(ok, ShouldBeFalse)
+		})
+		Convey("Original field must not be modified", func() {
+			originalChild := (*(*original.Spec().Object)[0].ArrayFields)[0]
+			_, ok := originalChild.Extra[field.ExtraDrop]
+			So(ok, ShouldBeTrue)
+		})
+	})
+}
+
 func TestDropFieldUnsetPayload(t *testing.T) {
 	// case 1: simple addition
 	// {
@@ -478,12 +531,15 @@ func TestConvertFunction(t *testing.T) {
 	// case 1: default
 	Convey("Case 1: Default", t, func() {
 		functionMap := map[field.FieldType]string{
-			field.TypeString:    "$toString",
-			field.TypeBoolean:   "$toBool",
-			field.TypeTimestamp: "$toDate",
-			field.TypeInt32:     "$toInt",
-			field.TypeInt64:     "$toLong",
-			field.TypeDouble:    "$toDouble",
+			field.TypeString:     "$toString",
+			field.TypeBoolean:    "$toBool",
+			field.TypeTimestamp:  "$toDate",
+			field.TypeInt32:      "$toInt",
+			field.TypeInt64:      "$toLong",
+			field.TypeDouble:     "$toDouble",
+			field.TypeDecimal128: "$toDecimal",
+			field.TypeObjectID:   "$toObjectId",
+			field.TypeDate:       "$toDate",
 		}
 
 		Convey("String Conversion", func() {
@@ -504,6 +560,15 @@ func TestConvertFunction(t *testing.T) {
 		Convey("Double Conversion", func() {
 			So(functionMap[field.TypeDouble], ShouldEqual, convertFunction(field.TypeDouble, field.TypeString))
 		})
+		Convey("Decimal128 Conversion", func() {
+			So(functionMap[field.TypeDecimal128], ShouldEqual, convertFunction(field.TypeDecimal128, field.TypeDouble))
+		})
+		Convey("ObjectID Conversion", func() {
+			So(functionMap[field.TypeObjectID], ShouldEqual, convertFunction(field.TypeObjectID, field.TypeString))
+		})
+		Convey("Date Conversion", func() {
+			So(functionMap[field.TypeDate], ShouldEqual, convertFunction(field.TypeDate, field.TypeTimestamp))
+		})
 	})
 
 	// case 2: unsupported conversion
@@ -716,4 +781,254 @@ func TestConvertFieldSetPayload(t *testing.T) {
 	}
 
 	test.AssertTrue(t, bsonMAreEqual(case4Payload, case4ExpectedPayload), "Case 4: Unexpected Payload")
+
+	// case 5: map values in nested object conversion
+	case5Field := field.ObjectField("field1",
+		field.MapField("field2", field.StringField("not required")),
+	)
+	case5Depth := 0
+	case5Payload := convertFieldSetPayload(case5Field, "", field.TypeInt32, &case5Depth)
+	case5ExpectedPayload := bson.M{
+		"field1.field2": bson.M{
+			"$arrayToObject": bson.M{
+				"$map": bson.M{
+					"input": bson.M{"$objectToArray": "$field1.field2"},
+					"as":    "alias_1",
+					"in": bson.M{
+						"k": "$$alias_1.k",
+						"v": bson.M{
+							convertFunction(field.TypeString, field.TypeInt32): "$$alias_1.v",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case5Payload, case5ExpectedPayload), "Case 5: Unexpected Payload")
+
+	// case 6: field in a variant conversion, only the matching variant is converted
+	case6Field := field.OneOf("field1", "kind", map[string]*field.FieldSpec{
+		"variant1": field.ObjectField("", field.StringField("field2")),
+	})
+	case6Depth := 0
+	case6Payload := convertFieldSetPayload(case6Field, "", field.TypeInt32, &case6Depth)
+	case6ExpectedPayload := bson.M{
+		"field1": bson.M{
+			"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$field1.kind", "variant1"}},
+				bson.M{
+					"$mergeObjects": bson.A{
+						"$field1",
+						bson.M{
+							"field2": bson.M{
+								convertFunction(field.TypeString, field.TypeInt32): "$field1.field2",
+							},
+						},
+					},
+				},
+				"$field1",
+			},
+		},
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case6Payload, case6ExpectedPayload), "Case 6: Unexpected Payload")
+}
+
+func TestFieldPipelinePayload(t *testing.T) {
+	Convey("Case 1: No map along the path", t, func() {
+		f := field.ObjectField("field1", field.StringField("field2"))
+		So(getCreatedFieldPipelinePath(f), ShouldBeNil)
+		So(getDroppedFieldPipelinePath(f), ShouldBeNil)
+	})
+
+	Convey("Case 2: Field created inside map values", t, func() {
+		f := field.MapField("field1", field.ObjectField("", field.StringField("field2")))
+		path := getCreatedFieldPipelinePath(f)
+		So(len(path), ShouldEqual, 3)
+		So(toShellSyntax(createFieldPipelinePayload(path, "")), ShouldEqual,
+			`[{"$set":{"field1":{"$cond":[{"$eq":[{"$type":"$field1"},"object"]},`+
+				`{"$arrayToObject":{"$map":{"as":"alias_1","in":{"k":"$$alias_1.k","v":{"$mergeObjects":["$$alias_1.v",{"field2":{"$literal":""}}]}},"input":{"$objectToArray":"$field1"}}}},`+
+				`{}]}}}]`,
+		)
+	})
+
+	Convey("Case 3: Field dropped inside map values", t, func() {
+		f := field.MapField("field1",
+			field.ObjectField("",
+				field.StringField("field2").SetExtra(field.ExtraDrop, true),
+			),
+		)
+		path := getDroppedFieldPipelinePath(f)
+		So(len(path), ShouldEqual, 3)
+		So(toShellSyntax(dropFieldPipelinePayload(path)), ShouldEqual,
+			`[{"$set":{"field1":{"$cond":[{"$eq":[{"$type":"$field1"},"object"]},`+
+				`{"$arrayToObject":{"$map":{"as":"alias_1","in":{"k":"$$alias_1.k","v":{"$cond":[{"$eq":[{"$type":"$$alias_1.v"},"object"]},`+
+				`{"$arrayToObject":{"$filter":{"as":"kv_2","cond":{"$ne":["$$kv_2.k","field2"]},"input":{"$objectToArray":"$$alias_1.v"}}}},"$$alias_1.v"]}},`+
+				`"input":{"$objectToArray":"$field1"}}}},"$field1"]}}}]`,
+		)
+	})
+
+	Convey("Case 4: Map value is dropped with its map", t, func() {
+		f := field.ObjectField("field1",
+			field.MapField("field2",
+				field.StringField("").SetExtra(field.ExtraDrop, true),
+			),
+		)
+		So(getDroppedFieldPipelinePath(f), ShouldBeNil)
+	})
+
+	Convey("Case 5: Field created inside a variant of array items", t, func() {
+		f := field.ArrayField("field1",
+			field.OneOf("", "kind", map[string]*field.FieldSpec{
+				"variant1": field.ObjectField("", field.StringField("field2")),
+			}),
+		)
+		path := getCreatedFieldPipelinePath(f)
+		So(len(path), ShouldEqual, 4)
+		So(toShellSyntax(createFieldPipelinePayload(path, "value")), ShouldEqual,
+			`[{"$set":{"field1":{"$cond":[{"$isArray":"$field1"},`+
+				`{"$map":{"as":"alias_1","in":{"$cond":[{"$eq":["$$alias_1.kind","variant1"]},{"$mergeObjects":["$$alias_1",{"field2":{"$literal":"value"}}]},"$$alias_1"]},"input":"$field1"}},`+
+				`[]]}}}]`,
+		)
+	})
+
+	Convey("Case 6: Variant dropped with all of its fields", t, func() {
+		f := field.OneOf("field1", "kind", map[string]*field.FieldSpec{
+			"variant1": field.ObjectField("", field.StringField("field2"), field.StringField("field3")).SetExtra(field.ExtraDrop, true),
+		})
+		path := getDroppedFieldPipelinePath(f)
+		So(len(path), ShouldEqual, 2)
+		So(toShellSyntax(dropFieldPipelinePayload(path)), ShouldEqual,
+			`[{"$set":{"field1":{"$cond":[{"$eq":["$field1.kind","variant1"]},`+
+				`{"$arrayToObject":{"$filter":{"as":"kv_1","cond":{"$not":[{"$in":["$$kv_1.k",["field2","field3"]]}]},"input":{"$objectToArray":"$field1"}}}},`+
+				`"$field1"]}}}]`,
+		)
+	})
+}
+
+func TestRenameFieldUpdatePayload(t *testing.T) {
+	getPath := func(f collection.Field) []*field.Spec {
+		res := []*field.Spec{}
+		curr := f.Spec()
+		for {
+			res = append(res, curr)
+			if curr.GetRenamedFrom() != "" {
+				return res
+			}
+
+			if curr.Type == field.TypeArray {
+				curr = &(*curr.ArrayFields)[0]
+			} else {
+				curr = &(*curr.Object)[0]
+			}
+		}
+	}
+
+	// case 1: top level field
+	case1Payload := renameFieldUpdatePayload(getPath(field.StringField("field2").RenamedFrom("field1")))
+	case1ExpectedPayload := bson.M{
+		"$rename": bson.M{
+			"field1": "field2",
+		},
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case1Payload, case1ExpectedPayload), "Case 1: Unexpected Payload")
+
+	// case 2: field in nested object
+	case2Field := field.ObjectField("field1",
+		field.ObjectField("field2",
+			field.StringField("field4").RenamedFrom("field3"),
+		),
+	)
+	case2Payload := renameFieldUpdatePayload(getPath(case2Field))
+	case2ExpectedPayload := bson.M{
+		"$rename": bson.M{
+			"field1.field2.field3": "field1.field2.field4",
+		},
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case2Payload, case2ExpectedPayload), "Case 2: Unexpected Payload")
+
+	// case 3: field in array of nested object
+	case3Field := field.ObjectField("field1",
+		field.ArrayField("field2",
+			field.ObjectField("",
+				field.ObjectField("field3",
+					field.StringField("field5").RenamedFrom("field4"),
+				),
+			),
+		),
+	)
+	case3Payload := renameFieldUpdatePayload(getPath(case3Field))
+	case3ExpectedPayload := bson.A{
+		bson.M{
+			"$set": bson.M{
+				"field1.field2": bson.M{
+					"$cond": bson.A{
+						bson.M{"$isArray": "$field1.field2"},
+						bson.M{
+							"$map": bson.M{
+								"input": "$field1.field2",
+								"as":    "alias_1",
+								"in": bson.M{
+									"$cond": bson.A{
+										bson.M{"$eq": bson.A{bson.M{"$type": "$$alias_1"}, "object"}},
+										bson.M{
+											"$mergeObjects": bson.A{
+												"$$alias_1",
+												bson.M{
+													"field3": bson.M{
+														"$cond": bson.A{
+															bson.M{"$eq": bson.A{bson.M{"$type": "$$alias_1.field3"}, "object"}},
+															bson.M{
+																"$mergeObjects": bson.A{
+																	bson.M{
+																		"$arrayToObject": bson.M{
+																			"$filter": bson.M{
+																				"input": bson.M{"$objectToArray": "$$alias_1.field3"},
+																				"as":    "kv_2",
+																				"cond":  bson.M{"$ne": bson.A{"$$kv_2.k", "field4"}},
+																			},
+																		},
+																	},
+																	bson.M{
+																		"field5": "$$alias_1.field3.field4",
+																	},
+																},
+															},
+															"$$alias_1.field3",
+														},
+													},
+												},
+											},
+										},
+										"$$alias_1",
+									},
+								},
+							},
+						},
+						"$field1.field2",
+					},
+				},
+			},
+		},
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case3Payload, case3ExpectedPayload), "Case 3: Unexpected Payload")
+
+	// case 4: field in a variant of array items, only the matching variant is renamed
+	case4Field := field.ArrayField("field1",
+		field.OneOf("", "kind", map[string]*field.FieldSpec{
+			"variant1": field.ObjectField("", field.StringField("field3").RenamedFrom("field2")),
+		}),
+	)
+	case4Payload := renameFieldUpdatePayload(getPath(case4Field))
+
+	test.AssertEqual(t, toShellSyntax(case4Payload),
+		`[{"$set":{"field1":{"$cond":[{"$isArray":"$field1"},{"$map":{"as":"alias_1","in":`+
+			`{"$cond":[{"$eq":["$$alias_1.kind","variant1"]},`+
+			`{"$cond":[{"$eq":[{"$type":"$$alias_1"},"object"]},{"$mergeObjects":[{"$arrayToObject":{"$filter":{"as":"kv_2","cond":{"$ne":["$$kv_2.k","field2"]},"input":{"$objectToArray":"$$alias_1"}}}},{"field3":"$$alias_1.field2"}]},"$$alias_1"]},`+
+			`"$$alias_1"]},"input":"$field1"}},"$field1"]}}}]`,
+		"Case 4: Unexpected Payload")
 }
@@ -35,6 +35,12 @@ type (
 		// we're expecting only a single field conversion
 		// each sub action
 		FieldConvertFrom *field.FieldType
+		// previous collection metadata for modification
+		// the options are modified from this metadata to `Collection`
+		CollectionModifyFrom collection.Metadata
+		// previous index for modification
+		// the options are modified from this index to the only index in `Indexes`
+		IndexModifyFrom collection.Index
 	}
 
 	SubActionIf interface {
@@ -10,26 +10,97 @@ package schema_interpreter
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// D and E are the ordered document types used in the literals of migration files,
+// so an ordered document (i.e: bson.D of a $sort stage) keeps its order
+// without importing the bson package in the migration file
+type D = primitive.D
+type E = primitive.E
+
+// the package alias of this package in migration files
+const literalPkgAlias = "si"
+
+// type names of the composite values in a literal
+type literalTypes struct {
+	Map   string
+	Slice string
+	Doc   string
+}
+
+var (
+	anyLiteralTypes = literalTypes{
+		Map:   "map[string]interface{}",
+		Slice: "[]interface{}",
+		Doc:   literalPkgAlias + ".D",
+	}
+	bsonLiteralTypes = literalTypes{
+		Map:   "bson.M",
+		Slice: "bson.A",
+		Doc:   "bson.D",
+	}
 )
 
+// map keys are sorted, so the same map always produces the same literal
+func sortedKeys(m map[string]interface{}) []string {
+	return sortedMapKeys(reflect.ValueOf(m))
+}
+
+func sortedMapKeys(m reflect.Value) []string {
+	keys := []string{}
+	for _, key := range m.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 // convert any value to literal, this function can be called any where
 func AnyToLiteral(value interface{}) string {
-	if reflect.TypeOf(value).Kind() == reflect.Map &&
-		reflect.TypeOf(value).Key().Kind() == reflect.String {
-		res := "map[string]interface{}{\n"
-		for key, v := range value.(map[string]interface{}) {
-			res += fmt.Sprintf(`"%s": %s,`, key, AnyToLiteral(v)) + "\n"
+	return compositeToLiteral(value, anyLiteralTypes)
+}
+
+// This converts a value to its literal, maps with string keys and slices of any type
+// are walked by reflection, i.e: bson.M and []string are declared as a map[string]interface{} and a []interface{}
+func compositeToLiteral(value interface{}, types literalTypes) string {
+	if value == nil {
+		return "nil"
+	}
+
+	switch v := value.(type) {
+	case primitive.D:
+		res := types.Doc + "{\n"
+		for _, elem := range v {
+			res += fmt.Sprintf("{Key: %q, Value: %s},\n", elem.Key, compositeToLiteral(elem.Value, types))
 		}
 		res += "}"
 
 		return res
-	} else if reflect.TypeOf(value).Kind() == reflect.Slice {
-		res := "[]interface{}{\n"
-		for _, v := range value.([]interface{}) {
-			res += fmt.Sprintf("%s\n,", AnyToLiteral(v))
+	case dictionary.ValueType:
+		return anyToLiteralString(value)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+		res := types.Map + "{\n"
+		for _, key := range sortedMapKeys(rv) {
+			elem := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+			res += fmt.Sprintf("%q: %s,\n", key, compositeToLiteral(elem.Interface(), types))
+		}
+		res += "}"
+
+		return res
+	} else if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		res := types.Slice + "{\n"
+		for i := 0; i < rv.Len(); i++ {
+			res += fmt.Sprintf("%s,\n", compositeToLiteral(rv.Index(i).Interface(), types))
 		}
 		res += "}"
 
@@ -47,7 +118,9 @@ func anyToLiteralString(value interface{}) string {
 		v = value.(dictionary.ValueType).Value
 	}
 
-	// TODO: handle nil values
+	if v == nil {
+		return "nil"
+	}
 
 	// handle primitives
 	switch reflect.TypeOf(v).Kind() {
@@ -66,7 +139,7 @@ func anyToLiteralString(value interface{}) string {
 	case reflect.Float64:
 		return fmt.Sprintf("float64(%v)", v)
 	case reflect.String:
-		return fmt.Sprintf(`string("%v")`, v)
+		return fmt.Sprintf("string(%q)", v)
 	case reflect.Bool:
 		return fmt.Sprintf("bool(%v)", v)
 	}
@@ -78,7 +151,45 @@ func anyToLiteralString(value interface{}) string {
 	}
 
 	// if none of type is recognized, just return as a string ValueType
-	return fmt.Sprintf(`string("%v")`, v)
+	return fmt.Sprintf("string(%q)", fmt.Sprintf("%v", v))
+}
+
+// converts a default value of a field to its literal,
+// BSON specific values are declared as strings, since the field type decides the stored type
+func defaultValueLiteral(value interface{}) string {
+	var normalize func(value interface{}) interface{}
+	normalize = func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(time.RFC3339Nano)
+		case primitive.ObjectID:
+			return v.Hex()
+		case primitive.Decimal128:
+			return v.String()
+		}
+
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			res := []interface{}{}
+			for i := 0; i < rv.Len(); i++ {
+				res = append(res, normalize(rv.Index(i).Interface()))
+			}
+
+			return res
+		case reflect.Map:
+			res := map[string]interface{}{}
+			for _, key := range rv.MapKeys() {
+				res[fmt.Sprintf("%v", key.Interface())] = normalize(rv.MapIndex(key).Interface())
+			}
+
+			return res
+		}
+
+		return value
+	}
+
+	return AnyToLiteral(normalize(value))
 }
 
 func timeToLiteralString(t time.Time) string {
@@ -92,26 +203,7 @@ func timeToLiteralString(t time.Time) string {
 
 // convert a map to literal bson.M map definition in string
 func toLiteralStringBsonMap(value interface{}) string {
-	if reflect.TypeOf(value).Kind() == reflect.Map &&
-		reflect.TypeOf(value).Key().Kind() == reflect.String {
-		res := "bson.M{\n"
-		for key, v := range value.(map[string]interface{}) {
-			res += fmt.Sprintf(`"%s": %s,`, key, toLiteralStringBsonMap(v)) + "\n"
-		}
-		res += "}"
-
-		return res
-	} else if reflect.TypeOf(value).Kind() == reflect.Slice {
-		res := "bson.A{\n"
-		for _, v := range value.([]interface{}) {
-			res += fmt.Sprintf("%s\n,", toLiteralStringBsonMap(v))
-		}
-		res += "}"
-
-		return res
-	}
-
-	return anyToLiteralString(value)
+	return compositeToLiteral(value, bsonLiteralTypes)
 }
 
 // ConvertValueTypeToRealType return value reversal of ValueType(d) structure
@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package schema_interpreter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/amirkode/go-mongr8/internal/test"
+)
+
+// evaluates a literal produced by AnyToLiteral back to its value
+func evalLiteral(expr ast.Expr) (interface{}, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if e.Name == "nil" {
+			return nil, nil
+		}
+	case *ast.CallExpr:
+		// a type conversion of a basic literal, i.e: int(-1)
+		arg := e.Args[0]
+		sign := ""
+		if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+			arg, sign = unary.X, "-"
+		}
+
+		lit, ok := arg.(*ast.BasicLit)
+		if !ok {
+			break
+		}
+
+		switch e.Fun.(*ast.Ident).Name {
+		case "string":
+			return strconv.Unquote(lit.Value)
+		case "int":
+			return strconv.Atoi(sign + lit.Value)
+		case "float64":
+			return strconv.ParseFloat(sign+lit.Value, 64)
+		}
+	case *ast.CompositeLit:
+		switch typ := e.Type.(type) {
+		case *ast.MapType:
+			res := map[string]interface{}{}
+			for _, elt := range e.Elts {
+				kv := elt.(*ast.KeyValueExpr)
+				key, err := strconv.Unquote(kv.Key.(*ast.BasicLit).Value)
+				if err != nil {
+					return nil, err
+				}
+
+				if res[key], err = evalLiteral(kv.Value); err != nil {
+					return nil, err
+				}
+			}
+
+			return res, nil
+		case *ast.ArrayType:
+			res := []interface{}{}
+			for _, elt := range e.Elts {
+				value, err := evalLiteral(elt)
+				if err != nil {
+					return nil, err
+				}
+
+				res = append(res, value)
+			}
+
+			return res, nil
+		case *ast.SelectorExpr:
+			if typ.Sel.Name != "D" {
+				break
+			}
+
+			res := D{}
+			for _, elt := range e.Elts {
+				fields := elt.(*ast.CompositeLit).Elts
+				key, err := strconv.Unquote(fields[0].(*ast.KeyValueExpr).Value.(*ast.BasicLit).Value)
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := evalLiteral(fields[1].(*ast.KeyValueExpr).Value)
+				if err != nil {
+					return nil, err
+				}
+
+				res = append(res, E{Key: key, Value: value})
+			}
+
+			return res, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected expression %T", expr)
+}
+
+func TestAnyToLiteral(t *testing.T) {
+	roundTrip := func(value interface{}) interface{} {
+		literal := AnyToLiteral(value)
+		expr, err := parser.ParseExpr(literal)
+		if err != nil {
+			t.Fatalf("Invalid literal %s: %s", literal, err.Error())
+		}
+
+		res, err := evalLiteral(expr)
+		if err != nil {
+			t.Fatalf("Cannot evaluate literal %s: %s", literal, err.Error())
+		}
+
+		return res
+	}
+
+	// Case 1: bson.M pipeline stage with a typed slice and a regex
+	case1Stage := map[string]interface{}{
+		"$match": bson.M{
+			"status": bson.M{"$in": []string{"active", `say "hi"`}},
+			"code":   bson.M{"$regex": `^a\d+`},
+			"score":  bson.M{"$gte": 1.5, "$ne": nil},
+		},
+	}
+	case1Expected := map[string]interface{}{
+		"$match": map[string]interface{}{
+			"status": map[string]interface{}{"$in": []interface{}{"active", `say "hi"`}},
+			"code":   map[string]interface{}{"$regex": `^a\d+`},
+			"score":  map[string]interface{}{"$gte": 1.5, "$ne": nil},
+		},
+	}
+	test.AssertTrue(t, reflect.DeepEqual(roundTrip(case1Stage), case1Expected), "Case 1: Unexpected bson.M round trip")
+
+	// Case 2: bson.D pipeline stages keep their order
+	case2Stage := map[string]interface{}{
+		"$sort": bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}},
+		"$project": bson.D{
+			{Key: "path", Value: `C:\tmp`},
+			{Key: "tags", Value: bson.A{"a", bson.D{{Key: "b", Value: 1}}}},
+		},
+	}
+	case2Expected := map[string]interface{}{
+		"$sort": D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}},
+		"$project": D{
+			{Key: "path", Value: `C:\tmp`},
+			{Key: "tags", Value: []interface{}{"a", D{{Key: "b", Value: 1}}}},
+		},
+	}
+	test.AssertTrue(t, reflect.DeepEqual(roundTrip(case2Stage), case2Expected), "Case 2: Unexpected bson.D round trip")
+
+	literal := AnyToLiteral(case2Stage)
+	test.AssertTrue(t, literal == "map[string]interface{}{\n"+
+		"\"$project\": si.D{\n{Key: \"path\", Value: string(\"C:\\\\tmp\")},\n{Key: \"tags\", Value: []interface{}{\nstring(\"a\"),\nsi.D{\n{Key: \"b\", Value: int(1)},\n},\n}},\n},\n"+
+		"\"$sort\": si.D{\n{Key: \"created_at\", Value: int(-1)},\n{Key: \"_id\", Value: int(1)},\n},\n"+
+		"}", fmt.Sprintf("Case 2: Unexpected literal %s", literal))
+
+	// Case 3: bson literal of an insert payload
+	bsonLiteral := toLiteralStringBsonMap(map[string]interface{}{
+		"name":  `say "hi"`,
+		"roles": []string{"admin"},
+		"meta":  bson.D{{Key: "v", Value: 1}},
+	})
+	test.AssertTrue(t, bsonLiteral == "bson.M{\n"+
+		"\"meta\": bson.D{\n{Key: \"v\", Value: int(1)},\n},\n"+
+		"\"name\": string(\"say \\\"hi\\\"\"),\n"+
+		"\"roles\": bson.A{\nstring(\"admin\"),\n},\n"+
+		"}", fmt.Sprintf("Case 3: Unexpected literal %s", bsonLiteral))
+}
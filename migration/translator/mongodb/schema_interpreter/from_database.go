@@ -0,0 +1,633 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package schema_interpreter
+
+// this contains interpretation of raw database schema (documents, indexes, and options)
+// into the collection entities, it's mainly used to reconstruct current schema in database
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type (
+	// inferred field accumulates all values found on the same path
+	// across sampled documents
+	inferredField struct {
+		name string
+		// number of occurrences for each type
+		types map[field.FieldType]int
+		// types sorted by the first occurrence
+		typeOrder []field.FieldType
+		nullable  bool
+		// children, if any object found on this path
+		object *inferredFields
+		// item, if any array found on this path
+		array *inferredField
+	}
+
+	inferredFields struct {
+		// field names sorted by the first occurrence
+		order  []string
+		fields map[string]*inferredField
+	}
+)
+
+func newInferredFields() *inferredFields {
+	return &inferredFields{
+		order:  []string{},
+		fields: map[string]*inferredField{},
+	}
+}
+
+func newInferredField(name string) *inferredField {
+	return &inferredField{
+		name:      name,
+		types:     map[field.FieldType]int{},
+		typeOrder: []field.FieldType{},
+	}
+}
+
+func lookupBsonD(d bson.D, key string) (interface{}, bool) {
+	for _, e := range d {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// convert any bson value into plain map/slice representation
+func bsonToPlain(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bson.D:
+		res := map[string]interface{}{}
+		for _, e := range v {
+			res[e.Key] = bsonToPlain(e.Value)
+		}
+
+		return res
+	case bson.M:
+		res := map[string]interface{}{}
+		for key, e := range v {
+			res[key] = bsonToPlain(e)
+		}
+
+		return res
+	case bson.A:
+		res := []interface{}{}
+		for _, e := range v {
+			res = append(res, bsonToPlain(e))
+		}
+
+		return res
+	}
+
+	return value
+}
+
+func numberToInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	}
+
+	return 0, false
+}
+
+func numberToFloat64(value interface{}) float64 {
+	if v, ok := value.(float64); ok {
+		return v
+	}
+
+	num, _ := numberToInt64(value)
+	return float64(num)
+}
+
+// this returns Geo JSON field type of a document if it's a valid Geo JSON object
+func getGeoJSONType(d bson.D) *field.FieldType {
+	_type, ok := lookupBsonD(d, "type")
+	if !ok {
+		return nil
+	}
+
+	typeStr, ok := _type.(string)
+	if !ok {
+		return nil
+	}
+
+	if typeStr == "GeometryCollection" {
+		if _, ok := lookupBsonD(d, "geometries"); ok {
+			return field.GetTypePointer(field.TypeGeoJSONGeometryCollection)
+		}
+
+		return nil
+	}
+
+	coordinates, ok := lookupBsonD(d, "coordinates")
+	if !ok {
+		return nil
+	}
+
+	arr, ok := coordinates.(bson.A)
+	if !ok {
+		return nil
+	}
+
+	switch typeStr {
+	case "Point":
+		return field.GetTypePointer(field.TypeGeoJSONPoint)
+	case "LineString":
+		return field.GetTypePointer(field.TypeGeoJSONLineString)
+	case "Polygon":
+		if len(arr) > 1 {
+			return field.GetTypePointer(field.TypeGeoJSONPolygonMultipleRing)
+		}
+
+		return field.GetTypePointer(field.TypeGeoJSONPolygonSingleRing)
+	case "MultiPoint":
+		return field.GetTypePointer(field.TypeGeoJSONMultiPoint)
+	case "MultiLineString":
+		return field.GetTypePointer(field.TypeGeoJSONMultiLineString)
+	case "MultiPolygon":
+		return field.GetTypePointer(field.TypeGeoJSONMultiPolygon)
+	}
+
+	return nil
+}
+
+func (f *inferredField) addType(fieldType field.FieldType) {
+	if _, ok := f.types[fieldType]; !ok {
+		f.typeOrder = append(f.typeOrder, fieldType)
+	}
+
+	f.types[fieldType]++
+}
+
+// add a single value found on this path
+func (f *inferredField) add(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		f.nullable = true
+	case string:
+		f.addType(field.TypeString)
+	case int32:
+		f.addType(field.TypeInt32)
+	case int64:
+		f.addType(field.TypeInt64)
+	case float64:
+		f.addType(field.TypeDouble)
+	case bool:
+		f.addType(field.TypeBoolean)
+	case primitive.DateTime:
+		f.addType(field.TypeDate)
+	case primitive.Timestamp:
+		f.addType(field.TypeTimestamp)
+	case primitive.ObjectID:
+		f.addType(field.TypeObjectID)
+	case primitive.Decimal128:
+		f.addType(field.TypeDecimal128)
+	case primitive.Binary:
+		// both the standard and legacy UUID subtypes
+		if v.Subtype == bson.TypeBinaryUUID || v.Subtype == bson.TypeBinaryUUIDOld {
+			f.addType(field.TypeUUID)
+		} else {
+			f.addType(field.TypeBinary)
+		}
+	case bson.A:
+		f.addType(field.TypeArray)
+		if f.array == nil {
+			f.array = newInferredField("")
+		}
+
+		for _, item := range v {
+			f.array.add(item)
+		}
+	case bson.D:
+		if geoJSONType := getGeoJSONType(v); geoJSONType != nil {
+			f.addType(*geoJSONType)
+			return
+		}
+
+		f.addType(field.TypeObject)
+		if f.object == nil {
+			f.object = newInferredFields()
+		}
+
+		f.object.add(v)
+	}
+
+	// other types are not yet supported to be declared as a field
+}
+
+// this decides a single type of current path
+// numeric types are widen to the largest one
+// otherwise, the most frequent type is chosen
+func (f *inferredField) getType() *field.FieldType {
+	if len(f.typeOrder) == 0 {
+		return nil
+	}
+
+	allNumeric := true
+	for _, t := range f.typeOrder {
+		if !t.IsNumeric() {
+			allNumeric = false
+			break
+		}
+	}
+
+	if allNumeric {
+		for _, t := range []field.FieldType{field.TypeDecimal128, field.TypeDouble, field.TypeInt64, field.TypeInt32} {
+			if _, ok := f.types[t]; ok {
+				return field.GetTypePointer(t)
+			}
+		}
+	}
+
+	res := f.typeOrder[0]
+	for _, t := range f.typeOrder {
+		if f.types[t] > f.types[res] {
+			res = t
+		}
+	}
+
+	return &res
+}
+
+// this returns nil if the type cannot be decided
+func (f *inferredField) toField() *field.FieldSpec {
+	fieldType := f.getType()
+	if fieldType == nil {
+		return nil
+	}
+
+	var res *field.FieldSpec
+	switch *fieldType {
+	case field.TypeArray:
+		if f.array == nil {
+			return nil
+		}
+
+		item := f.array.toField()
+		if item == nil {
+			// an array without any known item cannot be declared
+			return nil
+		}
+
+		res = field.ArrayField(f.name, item)
+	case field.TypeObject:
+		children := []*field.FieldSpec{}
+		if f.object != nil {
+			children = f.object.toFields()
+		}
+
+		res = field.ObjectField(f.name, children...)
+	default:
+		res = collection.FieldFromType(f.name, *fieldType).(*field.FieldSpec)
+	}
+
+	if f.nullable {
+		res.SetNullable()
+	}
+
+	return res
+}
+
+func (fs *inferredFields) add(doc bson.D) {
+	for _, e := range doc {
+		curr, ok := fs.fields[e.Key]
+		if !ok {
+			curr = newInferredField(e.Key)
+			fs.fields[e.Key] = curr
+			fs.order = append(fs.order, e.Key)
+		}
+
+		curr.add(e.Value)
+	}
+}
+
+func (fs *inferredFields) toFields() []*field.FieldSpec {
+	res := []*field.FieldSpec{}
+	for _, name := range fs.order {
+		curr := fs.fields[name].toField()
+		if curr != nil {
+			res = append(res, curr)
+		}
+	}
+
+	return res
+}
+
+// This infers fields of a collection from sampled documents
+// the `_id` field is skipped, since it's maintained by MongoDB by default
+func GetFieldsFromDocuments(docs []bson.D) []collection.Field {
+	inferred := newInferredFields()
+	for _, doc := range docs {
+		inferred.add(doc)
+	}
+
+	res := []collection.Field{}
+	for _, f := range inferred.toFields() {
+		if f.Spec().Name == "_id" {
+			continue
+		}
+
+		res = append(res, f)
+	}
+
+	return res
+}
+
+// This returns collection metadata from a collection specification
+// `collType` is either "collection" or "view"
+// `options` is the collection options as returned by `listCollections`
+func GetMetadataFromOptions(name, collType string, options bson.D) collection.Metadata {
+	res := metadata.InitMetadata(name)
+	if collation, ok := lookupBsonD(options, string(metadata.CollectionOptionCollation)); ok {
+		if plain, ok := bsonToPlain(collation).(map[string]interface{}); ok {
+			res.SetCollation(plain)
+		}
+	}
+
+	if collType == "view" {
+		viewOn, _ := lookupBsonD(options, string(metadata.CollectionOptionViewOn))
+		source, ok := viewOn.(string)
+		if !ok {
+			return res.AsView()
+		}
+
+		pipeline := []map[string]interface{}{}
+		if stages, ok := lookupBsonD(options, string(metadata.CollectionOptionPipeline)); ok {
+			if plain, ok := bsonToPlain(stages).([]interface{}); ok {
+				for _, stage := range plain {
+					if m, ok := stage.(map[string]interface{}); ok {
+						pipeline = append(pipeline, m)
+					}
+				}
+			}
+		}
+
+		return res.ViewOn(source, pipeline)
+	}
+
+	if capped, ok := lookupBsonD(options, string(metadata.CollectionOptionCapped)); ok && capped == true {
+		size, _ := lookupBsonD(options, string(metadata.CollectionOptionCappedSize))
+		sizeInt64, _ := numberToInt64(size)
+		res.Capped(sizeInt64)
+	}
+
+	if ttl, ok := lookupBsonD(options, "expireAfterSeconds"); ok {
+		if ttlInt64, ok := numberToInt64(ttl); ok {
+			res.TTL(ttlInt64)
+		}
+	}
+
+	if timeSeries, ok := lookupBsonD(options, "timeseries"); ok {
+		if d, ok := timeSeries.(bson.D); ok {
+			timeField, _ := lookupBsonD(d, string(metadata.CollectionOptionTimeField))
+			timeFieldStr, _ := timeField.(string)
+			res.TimeSeries(timeFieldStr)
+			if metaField, ok := lookupBsonD(d, string(metadata.CollectionOptionMetaField)); ok {
+				if metaFieldStr, ok := metaField.(string); ok {
+					res.SetMetaField(metaFieldStr)
+				}
+			}
+
+			// the bucket span is reported along with the granularity, so it's only read without granularity
+			if granularity, ok := lookupBsonD(d, string(metadata.CollectionOptionGranularity)); ok {
+				if granularityStr, ok := granularity.(string); ok {
+					res.SetGranularity(metadata.TimeSeriesGranularity(granularityStr))
+				}
+			} else if maxSpan, ok := lookupBsonD(d, string(metadata.CollectionOptionBucketMaxSpanSeconds)); ok {
+				rounding, _ := lookupBsonD(d, string(metadata.CollectionOptionBucketRoundingSeconds))
+				maxSpanInt64, _ := numberToInt64(maxSpan)
+				roundingInt64, _ := numberToInt64(rounding)
+				res.SetBucketSpan(maxSpanInt64, roundingInt64)
+			}
+		}
+	}
+
+	return res
+}
+
+// This returns an index from an index specification as returned by `listIndexes`
+// return nil for the default `_id` index
+func GetIndexFromSpecification(spec bson.D) collection.Index {
+	name, _ := lookupBsonD(spec, "name")
+	if name == "_id_" {
+		return nil
+	}
+
+	keys := bson.D{}
+	if rawKeys, ok := lookupBsonD(spec, "key"); ok {
+		if d, ok := rawKeys.(bson.D); ok {
+			keys = d
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// normalize numeric key values as declared in go-mongr8 index APIs
+	fields := []index.IndexField{}
+	allNumeric := true
+	for _, k := range keys {
+		if num, ok := numberToInt64(k.Value); ok {
+			fields = append(fields, index.NewIndexField(k.Key, int(num)))
+		} else {
+			allNumeric = false
+			fields = append(fields, index.NewIndexField(k.Key, k.Value))
+		}
+	}
+
+	var res *index.IndexSpec
+	isRaw := false
+	// a compound index may mix 2dsphere and hashed keys
+	allCompoundKeys := true
+	for _, k := range keys {
+		if _, ok := numberToInt64(k.Value); !ok && k.Value != index.Key2dsphere && k.Value != index.KeyHashed {
+			allCompoundKeys = false
+		}
+	}
+
+	if value, ok := lookupBsonD(keys, "_fts"); ok && value == "text" {
+		// text index keys are stored in weights,
+		// the other keys are the prefix or suffix keys of a compound text index
+		textFields := []index.IndexField{}
+		for _, f := range fields {
+			if f.Key == "_fts" {
+				if weights, ok := lookupBsonD(spec, index.OptionWeights); ok {
+					if d, ok := weights.(bson.D); ok {
+						for _, w := range d {
+							textFields = append(textFields, index.Field(w.Key))
+						}
+					}
+				}
+			} else if f.Key != "_ftsx" {
+				textFields = append(textFields, f)
+			}
+		}
+
+		res = index.TextIndex(textFields...)
+	} else if len(keys) == 1 && keys[0].Value == index.Key2dsphere {
+		res = index.Geospatial2dsphereIndex(index.Field(keys[0].Key))
+	} else if len(keys) == 1 && keys[0].Value == index.KeyHashed {
+		res = index.HashedIndex(index.Field(keys[0].Key))
+	} else if len(keys) == 1 && keys[0].Value == index.Key2d {
+		res = index.Geospatial2dIndex(index.Field(keys[0].Key))
+	} else if len(keys) == 1 && strings.HasSuffix(keys[0].Key, index.KeyWildcard) {
+		if path := index.GetWildcardPath(keys[0].Key); path == "" {
+			res = index.WildcardIndex()
+		} else {
+			res = index.WildcardIndex(path)
+		}
+	} else if len(keys) == 1 && allNumeric {
+		res = index.SingleFieldIndex(fields[0])
+	} else if allCompoundKeys {
+		res = index.CompoundIndex(fields...)
+	} else {
+		isRaw = true
+		rawFields := map[string]interface{}{}
+		for _, f := range fields {
+			rawFields[f.Key] = f.Value
+		}
+
+		res = index.RawIndex(rawFields, nil)
+	}
+
+	// collect options
+	rules := map[string]interface{}{}
+	for _, option := range []string{
+		index.OptionSparse,
+		index.OptionBackground,
+		index.OptionUnique,
+		index.OptionHidden,
+	} {
+		if value, ok := lookupBsonD(spec, option); ok && value == true {
+			rules[option] = true
+		}
+	}
+
+	if value, ok := lookupBsonD(spec, index.OptionPartialFilterExp); ok {
+		rules[index.OptionPartialFilterExp] = bsonToPlain(value)
+	}
+
+	if value, ok := lookupBsonD(spec, index.OptionTTL); ok {
+		if ttl, ok := numberToInt64(value); ok {
+			rules[index.OptionTTL] = int32(ttl)
+		}
+	}
+
+	if value, ok := lookupBsonD(spec, index.OptionCollation); ok {
+		collation := map[string]interface{}{}
+		if d, ok := value.(bson.D); ok {
+			for _, c := range d {
+				// collation numbers (e.g: strength) are declared as int
+				if num, ok := c.Value.(int32); ok {
+					collation[c.Key] = int(num)
+				} else {
+					collation[c.Key] = c.Value
+				}
+			}
+		}
+
+		rules[index.OptionCollation] = collation
+	}
+
+	indexType := res.Spec().Type
+	if value, ok := lookupBsonD(spec, index.OptionWildcardProjection); ok && indexType == index.TypeWildcard {
+		rules[index.OptionWildcardProjection] = bsonToPlain(value)
+	}
+
+	if indexType == index.TypeGeospatial2d {
+		min, hasMin := lookupBsonD(spec, index.OptionMin)
+		max, hasMax := lookupBsonD(spec, index.OptionMax)
+		if hasMin && hasMax {
+			rules[index.OptionMin] = numberToFloat64(min)
+			rules[index.OptionMax] = numberToFloat64(max)
+		}
+
+		if value, ok := lookupBsonD(spec, index.OptionBits); ok {
+			if bits, ok := numberToInt64(value); ok && bits != 26 {
+				rules[index.OptionBits] = int32(bits)
+			}
+		}
+	}
+
+	if indexType == index.TypeText {
+		// only the non-default text options are kept
+		if value, ok := lookupBsonD(spec, index.OptionWeights); ok {
+			weights := map[string]interface{}{}
+			if d, ok := value.(bson.D); ok {
+				for _, w := range d {
+					if weight, ok := numberToInt64(w.Value); ok && weight != 1 {
+						weights[w.Key] = int32(weight)
+					}
+				}
+			}
+
+			if len(weights) > 0 {
+				rules[index.OptionWeights] = weights
+			}
+		}
+
+		if value, ok := lookupBsonD(spec, index.OptionDefaultLanguage); ok && value != "english" {
+			rules[index.OptionDefaultLanguage] = value
+		}
+
+		if value, ok := lookupBsonD(spec, index.OptionLanguageOverride); ok && value != "language" {
+			rules[index.OptionLanguageOverride] = value
+		}
+	}
+
+	if len(rules) > 0 {
+		if isRaw {
+			res.SetRules(rules)
+		} else {
+			// sorted, to keep it deterministic
+			keys := []string{}
+			for key := range rules {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			res.InitRules()
+			for _, key := range keys {
+				(*res.Spec().Rules)[key] = rules[key]
+			}
+		}
+	}
+
+	// keep the original name, if it's not the generated one
+	if nameStr, ok := name.(string); ok && nameStr != res.Spec().GetName() {
+		res.SetCustomIndexName(nameStr)
+	}
+
+	return res
+}
+
+// This checks whether a collection is maintained by MongoDB internally
+func IsInternalCollection(name string) bool {
+	return strings.HasPrefix(name, "system.")
+}
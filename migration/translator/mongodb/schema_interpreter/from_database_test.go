@@ -0,0 +1,291 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package schema_interpreter
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetFieldsFromDocuments(t *testing.T) {
+	Convey("Get Fields From Documents", t, func() {
+		docs := []bson.D{
+			{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "name", Value: "John"},
+				{Key: "age", Value: int32(20)},
+				{Key: "created_at", Value: primitive.NewDateTimeFromTime(primitive.NewObjectID().Timestamp())},
+				{Key: "address", Value: bson.D{
+					{Key: "city", Value: "Jakarta"},
+					{Key: "location", Value: bson.D{
+						{Key: "type", Value: "Point"},
+						{Key: "coordinates", Value: bson.A{106.8, -6.2}},
+					}},
+				}},
+				{Key: "tags", Value: bson.A{"a", "b"}},
+				{Key: "empty", Value: bson.A{}},
+			},
+			{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "name", Value: nil},
+				{Key: "age", Value: int64(21)},
+				{Key: "score", Value: 9.5},
+				{Key: "area", Value: bson.D{
+					{Key: "type", Value: "Polygon"},
+					{Key: "coordinates", Value: bson.A{bson.A{}, bson.A{}}},
+				}},
+			},
+		}
+
+		fields := GetFieldsFromDocuments(docs)
+		So(len(fields), ShouldEqual, 7)
+
+		// case 1: `_id` is skipped and the order is preserved
+		So(fields[0].Spec().Name, ShouldEqual, "name")
+		So(fields[0].Spec().Type, ShouldEqual, field.TypeString)
+		So(fields[0].Spec().Nullable, ShouldBeTrue)
+
+		// case 2: numeric types are widen
+		So(fields[1].Spec().Name, ShouldEqual, "age")
+		So(fields[1].Spec().Type, ShouldEqual, field.TypeInt64)
+		So(fields[1].Spec().Nullable, ShouldBeFalse)
+
+		// case 3: date is mapped to date
+		So(fields[2].Spec().Type, ShouldEqual, field.TypeDate)
+
+		// case 4: nested object with Geo JSON
+		So(fields[3].Spec().Type, ShouldEqual, field.TypeObject)
+		children := *fields[3].Spec().Object
+		So(len(children), ShouldEqual, 2)
+		So(children[0].Type, ShouldEqual, field.TypeString)
+		So(children[1].Name, ShouldEqual, "location")
+		So(children[1].Type, ShouldEqual, field.TypeGeoJSONPoint)
+
+		// case 5: array with its item, empty array is skipped
+		So(fields[4].Spec().Name, ShouldEqual, "tags")
+		So(fields[4].Spec().Type, ShouldEqual, field.TypeArray)
+		So((*fields[4].Spec().ArrayFields)[0].Type, ShouldEqual, field.TypeString)
+
+		// case 6: fields only found in some documents
+		So(fields[5].Spec().Name, ShouldEqual, "score")
+		So(fields[5].Spec().Type, ShouldEqual, field.TypeDouble)
+		So(fields[6].Spec().Type, ShouldEqual, field.TypeGeoJSONPolygonMultipleRing)
+	})
+
+	Convey("Get Fields With BSON Types", t, func() {
+		price, _ := primitive.ParseDecimal128("10.25")
+		fields := GetFieldsFromDocuments([]bson.D{
+			{
+				{Key: "owner_id", Value: primitive.NewObjectID()},
+				{Key: "price", Value: price},
+				{Key: "token", Value: primitive.Binary{Subtype: bson.TypeBinaryUUID, Data: make([]byte, 16)}},
+				{Key: "thumbnail", Value: primitive.Binary{Subtype: bson.TypeBinaryGeneric, Data: []byte{1}}},
+				{Key: "total", Value: int64(10)},
+			},
+			{
+				{Key: "total", Value: price},
+			},
+		})
+
+		So(fields[0].Spec().Type, ShouldEqual, field.TypeObjectID)
+		So(fields[1].Spec().Type, ShouldEqual, field.TypeDecimal128)
+		So(fields[2].Spec().Type, ShouldEqual, field.TypeUUID)
+		So(fields[3].Spec().Type, ShouldEqual, field.TypeBinary)
+		// numeric types are widen to decimal
+		So(fields[4].Spec().Type, ShouldEqual, field.TypeDecimal128)
+	})
+}
+
+func TestGetMetadataFromOptions(t *testing.T) {
+	Convey("Get Metadata From Options", t, func() {
+		// case 1: default collection
+		meta := GetMetadataFromOptions("users", "collection", bson.D{})
+		So(meta.Spec().Name, ShouldEqual, "users")
+		So(meta.Spec().Type, ShouldEqual, metadata.TypeDefaultCollection)
+		So(meta.Spec().Options, ShouldBeNil)
+
+		// case 2: capped collection
+		meta = GetMetadataFromOptions("logs", "collection", bson.D{
+			{Key: "capped", Value: true},
+			{Key: "size", Value: int32(4096)},
+		})
+		So((*meta.Spec().Options)[metadata.CollectionOptionCapped], ShouldEqual, true)
+		So((*meta.Spec().Options)[metadata.CollectionOptionCappedSize], ShouldEqual, int64(4096))
+
+		// case 3: time series collection
+		meta = GetMetadataFromOptions("metrics", "timeseries", bson.D{
+			{Key: "timeseries", Value: bson.D{
+				{Key: "timeField", Value: "timestamp"},
+				{Key: "metaField", Value: "device"},
+				{Key: "granularity", Value: "minutes"},
+				{Key: "bucketMaxSpanSeconds", Value: int32(86400)},
+			}},
+			{Key: "expireAfterSeconds", Value: int64(3600)},
+		})
+		So(meta.Spec().GetTimeField(), ShouldEqual, "timestamp")
+		So(meta.Spec().GetMetaField(), ShouldEqual, "device")
+		So((*meta.Spec().Options)[metadata.CollectionOptionGranularity], ShouldEqual, metadata.GranularityMinutes)
+		So((*meta.Spec().Options)[metadata.CollectionOptionBucketMaxSpanSeconds], ShouldBeNil)
+		So((*meta.Spec().Options)[metadata.CollectionOptionExpiredAfterSeconds], ShouldEqual, int64(3600))
+
+		// case 4: view
+		meta = GetMetadataFromOptions("users_view", "view", bson.D{
+			{Key: "viewOn", Value: "users"},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "status", Value: "active"}}}},
+			}},
+		})
+		So(meta.Spec().Type, ShouldEqual, metadata.TypeViewCollection)
+		So(meta.Spec().GetViewOn(), ShouldEqual, "users")
+		So(meta.Spec().GetPipeline(), ShouldResemble, []map[string]interface{}{
+			{"$match": map[string]interface{}{"status": "active"}},
+		})
+	})
+}
+
+func TestGetIndexFromSpecification(t *testing.T) {
+	Convey("Get Index From Specification", t, func() {
+		// case 1: default `_id` index is skipped
+		So(GetIndexFromSpecification(bson.D{
+			{Key: "v", Value: int32(2)},
+			{Key: "key", Value: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Key: "name", Value: "_id_"},
+		}), ShouldBeNil)
+
+		// case 2: single field with options
+		idx := GetIndexFromSpecification(bson.D{
+			{Key: "v", Value: int32(2)},
+			{Key: "key", Value: bson.D{{Key: "email", Value: int32(1)}}},
+			{Key: "name", Value: "email_1"},
+			{Key: "unique", Value: true},
+			{Key: "expireAfterSeconds", Value: int64(60)},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeSingleField)
+		So(idx.Spec().Fields[0], ShouldResemble, index.Field("email", 1))
+		So((*idx.Spec().Rules)[index.OptionUnique], ShouldEqual, true)
+		So((*idx.Spec().Rules)[index.OptionTTL], ShouldEqual, int32(60))
+		So(idx.Spec().GetName(), ShouldEqual, "email_1")
+
+		// case 3: compound keeps the order
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{
+				{Key: "name", Value: int32(-1)},
+				{Key: "age", Value: float64(1)},
+			}},
+			{Key: "name", Value: "name_-1_age_1"},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeCompound)
+		So(idx.Spec().Fields, ShouldResemble, []index.IndexField{
+			index.Field("name", -1),
+			index.Field("age", 1),
+		})
+		So(idx.Spec().Rules, ShouldBeNil)
+
+		// case 4: text index
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{
+				{Key: "_fts", Value: "text"},
+				{Key: "_ftsx", Value: int32(1)},
+			}},
+			{Key: "name", Value: "bio_text"},
+			{Key: "weights", Value: bson.D{{Key: "bio", Value: int32(1)}}},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeText)
+		So(idx.Spec().Fields[0].Key, ShouldEqual, "bio")
+
+		// case 5: 2dsphere index
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{{Key: "location", Value: "2dsphere"}}},
+			{Key: "name", Value: "location_2dsphere"},
+			{Key: "2dsphereIndexVersion", Value: int32(3)},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeGeopatial2dsphere)
+
+		// case 6: compound text index with weights and language
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{
+				{Key: "category", Value: int32(1)},
+				{Key: "_fts", Value: "text"},
+				{Key: "_ftsx", Value: int32(1)},
+			}},
+			{Key: "name", Value: "articles_text"},
+			{Key: "weights", Value: bson.D{
+				{Key: "body", Value: int32(1)},
+				{Key: "title", Value: int32(10)},
+			}},
+			{Key: "default_language", Value: "indonesian"},
+			{Key: "language_override", Value: "language"},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeText)
+		So(idx.Spec().Fields, ShouldResemble, []index.IndexField{
+			index.Field("category", 1),
+			index.Field("body", index.KeyText),
+			index.Field("title", index.KeyText),
+		})
+		So(*idx.Spec().Rules, ShouldResemble, map[string]interface{}{
+			index.OptionWeights:         map[string]interface{}{"title": int32(10)},
+			index.OptionDefaultLanguage: "indonesian",
+		})
+
+		// case 7: wildcard index with projection
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{{Key: "$**", Value: int32(1)}}},
+			{Key: "name", Value: "$**_1"},
+			{Key: "wildcardProjection", Value: bson.D{{Key: "secret", Value: int32(0)}}},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeWildcard)
+		So(idx.Spec().Fields[0].Key, ShouldEqual, "$**")
+		So((*idx.Spec().Rules)[index.OptionWildcardProjection], ShouldResemble, map[string]interface{}{"secret": int32(0)})
+
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{{Key: "attributes.$**", Value: int32(1)}}},
+			{Key: "name", Value: "attributes.$**_1"},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeWildcard)
+		So(index.GetWildcardPath(idx.Spec().Fields[0].Key), ShouldEqual, "attributes")
+
+		// case 8: 2d index with bounds and bits
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{{Key: "location", Value: "2d"}}},
+			{Key: "name", Value: "location_2d"},
+			{Key: "min", Value: float64(-90)},
+			{Key: "max", Value: int32(90)},
+			{Key: "bits", Value: int32(20)},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeGeospatial2d)
+		So(*idx.Spec().Rules, ShouldResemble, map[string]interface{}{
+			index.OptionMin:  float64(-90),
+			index.OptionMax:  float64(90),
+			index.OptionBits: int32(20),
+		})
+
+		// case 9: compound index mixing 2dsphere and hashed keys
+		idx = GetIndexFromSpecification(bson.D{
+			{Key: "key", Value: bson.D{
+				{Key: "location", Value: "2dsphere"},
+				{Key: "category", Value: "hashed"},
+				{Key: "name", Value: int32(1)},
+			}},
+			{Key: "name", Value: "location_2dsphere_category_hashed_name_1"},
+		})
+		So(idx.Spec().Type, ShouldEqual, index.TypeCompound)
+		So(idx.Spec().Fields, ShouldResemble, []index.IndexField{
+			index.Field("location", index.Key2dsphere),
+			index.Field("category", index.KeyHashed),
+			index.Field("name", 1),
+		})
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/amirkode/go-mongr8/internal/util"
 
 	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
 	"github.com/amirkode/go-mongr8/collection/index"
 	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
 
@@ -123,6 +124,7 @@ func (sa SubAction) IsUp() bool {
 		SubActionTypeCreateField,
 		SubActionTypeCreateIndex,
 		SubActionTypeConvertField,
+		SubActionTypeSetValidator,
 	})
 }
 
@@ -144,6 +146,16 @@ func (sa SubAction) GetLiteralInstance(prefix string, isArrayItem bool) string {
 		res += fmt.Sprintf("*%sSubActionDropIndex(%s)", prefix, actionSchema)
 	case SubActionTypeDropField:
 		res += fmt.Sprintf("*%sSubActionDropField(%s)", prefix, actionSchema)
+	case SubActionTypeSetValidator:
+		res += fmt.Sprintf("*%sSubActionSetValidator(%s)", prefix, actionSchema)
+	case SubActionTypeUnsetValidator:
+		res += fmt.Sprintf("*%sSubActionUnsetValidator(%s)", prefix, actionSchema)
+	case SubActionTypeRenameField:
+		res += fmt.Sprintf("*%sSubActionRenameField(%s)", prefix, actionSchema)
+	case SubActionTypeModifyCollection:
+		res += fmt.Sprintf("*%sSubActionModifyCollection(%s)", prefix, actionSchema)
+	case SubActionTypeModifyIndex:
+		res += fmt.Sprintf("*%sSubActionModifyIndex(%s)", prefix, actionSchema)
 	default:
 		if !isArrayItem {
 			res += fmt.Sprintf("%sSubAction", prefix)
@@ -246,6 +258,29 @@ func SubActionDropField(schema SubActionSchema) *SubAction {
 	}
 }
 
+// the validator is generated from all fields in the schema
+func SubActionSetValidator(schema SubActionSchema) *SubAction {
+	return &SubAction{
+		Type:         SubActionTypeSetValidator,
+		ActionSchema: schema,
+		validate: func() {
+			if len(schema.Fields) == 0 {
+				panic("At least a field declared for setting validator")
+			}
+		},
+	}
+}
+
+func SubActionUnsetValidator(schema SubActionSchema) *SubAction {
+	return &SubAction{
+		Type:         SubActionTypeUnsetValidator,
+		ActionSchema: schema,
+		validate: func() {
+			// nothing to validate
+		},
+	}
+}
+
 /*
 var ctx context.Context
 var db *mongo.Database
@@ -309,3 +344,75 @@ func NewInsertSingleSubAction(initCollection bool, collectionName string, payloa
 	}
 }
 */
+
+// the field is a one way path to the renamed field,
+// the renamed field holds its previous name, @see field.FieldSpec.RenamedFrom
+func SubActionRenameField(schema SubActionSchema) *SubAction {
+	return &SubAction{
+		Type:         SubActionTypeRenameField,
+		ActionSchema: schema,
+		validate: func() {
+			if len(schema.Fields) != 1 {
+				panic("At least a field declared for field renaming")
+			}
+
+			if GetRenamedFieldPath(schema.Fields[0]) == nil {
+				panic("Renamed field must declare its previous name")
+			}
+		},
+	}
+}
+
+// This returns specs along the one way path from the field to the renamed field (inclusive),
+// or nil if there's no renamed field in the path
+func GetRenamedFieldPath(f collection.Field) []*field.Spec {
+	res := []*field.Spec{}
+	curr := f.Spec()
+	for curr != nil {
+		res = append(res, curr)
+		if curr.GetRenamedFrom() != "" {
+			return res
+		}
+
+		switch {
+		case (curr.Type == field.TypeObject || curr.Type == field.TypeOneOf) && curr.Object != nil && len(*curr.Object) > 0:
+			curr = &(*curr.Object)[0]
+		case (curr.Type == field.TypeArray || curr.Type == field.TypeMap) && curr.ArrayFields != nil && len(*curr.ArrayFields) > 0:
+			curr = &(*curr.ArrayFields)[0]
+		default:
+			curr = nil
+		}
+	}
+
+	return nil
+}
+
+// the collection options are modified from `CollectionModifyFrom` to `Collection`
+func SubActionModifyCollection(schema SubActionSchema) *SubAction {
+	return &SubAction{
+		Type:         SubActionTypeModifyCollection,
+		ActionSchema: schema,
+		validate: func() {
+			if schema.CollectionModifyFrom == nil {
+				panic("CollectionModifyFrom must not be nil for collection modification")
+			}
+		},
+	}
+}
+
+// the options of an index are modified from `IndexModifyFrom` to the only index in `Indexes`
+func SubActionModifyIndex(schema SubActionSchema) *SubAction {
+	return &SubAction{
+		Type:         SubActionTypeModifyIndex,
+		ActionSchema: schema,
+		validate: func() {
+			if len(schema.Indexes) != 1 {
+				panic("Exactly an index declared for modifying index")
+			}
+
+			if schema.IndexModifyFrom == nil {
+				panic("IndexModifyFrom must not be nil for index modification")
+			}
+		},
+	}
+}
@@ -9,6 +9,7 @@ package schema_interpreter
 
 import (
 	// "fmt"
+	"strings"
 	"testing"
 
 	// "internal/test"
@@ -50,4 +51,32 @@ func TestGetLiteralInstance(t *testing.T) {
 	// TODO: add value checking
 }
 
+func TestGetRenamedFieldPath(t *testing.T) {
+	// case 1: renamed field in array of object
+	case1Field := field.ArrayField("tx_history",
+		field.ObjectField("",
+			field.StringField("description").RenamedFrom("desc"),
+		),
+	)
+	case1Path := GetRenamedFieldPath(case1Field)
+	if len(case1Path) != 3 || case1Path[2].Name != "description" || case1Path[2].GetRenamedFrom() != "desc" {
+		t.Errorf("Case 1: Unexpected renamed field path")
+	}
+
+	// case 2: no renamed field
+	if GetRenamedFieldPath(field.ObjectField("info", field.StringField("desc"))) != nil {
+		t.Errorf("Case 2: Renamed field path must be nil")
+	}
+
+	// case 3: the literal keeps the previous name
+	case3SubAction := SubActionRenameField(SubActionSchema{
+		Collection: metadata.InitMetadata("users"),
+		Fields:     []collection.Field{case1Field},
+	})
+	case3Literal := case3SubAction.GetLiteralInstance("", false)
+	if !strings.Contains(case3Literal, `field.StringField("description").RenamedFrom("desc")`) {
+		t.Errorf("Case 3: Literal must declare the previous name, got %s", case3Literal)
+	}
+}
+
 // TODO: write some other tests
@@ -9,6 +9,7 @@ package schema_interpreter
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
@@ -17,18 +18,56 @@ import (
 )
 
 func (sas SubActionSchema) getMetadataDeclarationLiteral() string {
-	res := fmt.Sprintf(`metadata.InitMetadata("%s")`, sas.Collection.Spec().Name)
-	if sas.Collection.Spec().Options != nil {
+	return getMetadataLiteral(sas.Collection)
+}
+
+func getMetadataLiteral(meta collection.Metadata) string {
+	res := fmt.Sprintf(`metadata.InitMetadata("%s")`, meta.Spec().Name)
+	if meta.Spec().Options != nil {
 		// check whether the collection is capped
-		_, capped := (*sas.Collection.Spec().Options)[metadata.CollectionOptionCapped]
+		_, capped := (*meta.Spec().Options)[metadata.CollectionOptionCapped]
 		if capped {
-			res += fmt.Sprintf(".Capped(%d)", (*sas.Collection.Spec().Options)[metadata.CollectionOptionCappedSize])
+			res += fmt.Sprintf(".Capped(%d)", (*meta.Spec().Options)[metadata.CollectionOptionCappedSize])
 		}
 		// check whether the collection has expiration time
-		_, hasExpiration := (*sas.Collection.Spec().Options)[metadata.CollectionOptionExpiredAfterSeconds]
+		_, hasExpiration := (*meta.Spec().Options)[metadata.CollectionOptionExpiredAfterSeconds]
 		if hasExpiration {
-			res += fmt.Sprintf(".TTL(%d)", (*sas.Collection.Spec().Options)[metadata.CollectionOptionExpiredAfterSeconds])
+			res += fmt.Sprintf(".TTL(%d)", (*meta.Spec().Options)[metadata.CollectionOptionExpiredAfterSeconds])
+		}
+		// check whether the collection is a time series collection
+		if meta.Spec().IsTimeSeries() {
+			opts := *meta.Spec().Options
+			res += fmt.Sprintf(`.TimeSeries("%s")`, meta.Spec().GetTimeField())
+			if metaField := meta.Spec().GetMetaField(); metaField != "" {
+				res += fmt.Sprintf(`.SetMetaField("%s")`, metaField)
+			}
+
+			if granularity, ok := opts[metadata.CollectionOptionGranularity]; ok {
+				res += fmt.Sprintf(`.SetGranularity("%s")`, granularity)
+			}
+
+			if maxSpan, ok := opts[metadata.CollectionOptionBucketMaxSpanSeconds]; ok {
+				res += fmt.Sprintf(".SetBucketSpan(%d, %d)", maxSpan, opts[metadata.CollectionOptionBucketRoundingSeconds])
+			}
+		}
+		// check whether the collection is a view with its pipeline
+		_, isView := (*meta.Spec().Options)[metadata.CollectionOptionViewOn]
+		if isView {
+			stages := ""
+			for _, stage := range meta.Spec().GetPipeline() {
+				stages += fmt.Sprintf("%s,\n", AnyToLiteral(stage))
+			}
+			res += fmt.Sprintf(`.ViewOn("%s", []map[string]interface{}{%s%s})`, meta.Spec().GetViewOn(), "\n", stages)
 		}
+		// check whether the collection has default collation
+		if collation := meta.Spec().GetCollation(); collation != nil {
+			res += fmt.Sprintf(".SetCollation(%s)", AnyToLiteral(collation))
+		}
+	}
+
+	// a view without declared pipeline
+	if meta.Spec().Type == metadata.TypeViewCollection && meta.Spec().GetViewOn() == "" {
+		res += ".AsView()"
 	}
 
 	return res
@@ -65,8 +104,33 @@ func (sas SubActionSchema) getFieldDeclarationLiteral(f collection.Field) string
 			}
 			res += fmt.Sprintf(`field.ObjectField("%s",%s%s)`, f.Spec().Name, "\n", children)
 			// implement nested
+		case field.TypeMap:
+			value := "nil"
+			if valueSpec := f.Spec().GetMapValue(); valueSpec != nil {
+				value = fieldLiteral(field.FromFieldSpec(valueSpec))
+			}
+			res += fmt.Sprintf(`field.MapField("%s", %s)`, f.Spec().Name, value)
+		case field.TypeOneOf:
+			variants := ""
+			for _, variant := range *f.Spec().Object {
+				// the variant name is declared as the map key
+				variantSpec := variant
+				variantSpec.Name = ""
+				variants += fmt.Sprintf("%q: %s,\n", variant.Name, fieldLiteral(field.FromFieldSpec(&variantSpec)))
+			}
+			res += fmt.Sprintf(`field.OneOf("%s", "%s", map[string]*field.FieldSpec{%s%s})`, f.Spec().Name, f.Spec().GetDiscriminator(), "\n", variants)
 		case field.TypeTimestamp:
 			res += fmt.Sprintf(`field.TimestampField("%s")`, f.Spec().Name)
+		case field.TypeObjectID:
+			res += fmt.Sprintf(`field.ObjectIDField("%s")`, f.Spec().Name)
+		case field.TypeDecimal128:
+			res += fmt.Sprintf(`field.Decimal128Field("%s")`, f.Spec().Name)
+		case field.TypeBinary:
+			res += fmt.Sprintf(`field.BinaryField("%s")`, f.Spec().Name)
+		case field.TypeUUID:
+			res += fmt.Sprintf(`field.UUIDField("%s")`, f.Spec().Name)
+		case field.TypeDate:
+			res += fmt.Sprintf(`field.DateField("%s")`, f.Spec().Name)
 		case field.TypeGeoJSONPoint:
 			res += fmt.Sprintf(`field.GeoJSONPointField("%s")`, f.Spec().Name)
 		case field.TypeGeoJSONLineString:
@@ -87,6 +151,10 @@ func (sas SubActionSchema) getFieldDeclarationLiteral(f collection.Field) string
 			res += fmt.Sprintf(`field.LegacyCoordinateArrayField("%s")`, f.Spec().Name)
 		}
 
+		if f.Spec().Nullable {
+			res += ".SetNullable()"
+		}
+
 		// check whether drop flag exists in the extra
 		if f.Spec().Extra != nil {
 			if val, ok := f.Spec().Extra[field.ExtraDrop]; ok {
@@ -96,6 +164,16 @@ func (sas SubActionSchema) getFieldDeclarationLiteral(f collection.Field) string
 				}
 				res += fmt.Sprintf(".SetExtra(field.ExtraDrop, %t)", drop)
 			}
+
+			if renamedFrom := f.Spec().GetRenamedFrom(); renamedFrom != "" {
+				res += fmt.Sprintf(`.RenamedFrom("%s")`, renamedFrom)
+			}
+
+			if value, ok := f.Spec().GetDefault(); ok {
+				res += fmt.Sprintf(`.SetDefault(%s)`, defaultValueLiteral(value))
+			}
+
+			res += constraintsLiteral(f.Spec())
 		}
 
 		return res
@@ -104,6 +182,52 @@ func (sas SubActionSchema) getFieldDeclarationLiteral(f collection.Field) string
 	return fieldLiteral(f)
 }
 
+// converts value constraints of a field to its builder calls
+func constraintsLiteral(spec *field.Spec) string {
+	res := ""
+	if enum := spec.GetEnum(); len(enum) > 0 {
+		values := []string{}
+		for _, value := range enum {
+			values = append(values, defaultValueLiteral(value))
+		}
+		res += fmt.Sprintf(`.Enum(%s)`, strings.Join(values, ", "))
+	}
+
+	if value, ok := spec.GetMin(); ok {
+		res += fmt.Sprintf(`.Min(%v)`, value)
+	}
+
+	if value, ok := spec.GetMax(); ok {
+		res += fmt.Sprintf(`.Max(%v)`, value)
+	}
+
+	if value, ok := spec.GetMinLength(); ok {
+		res += fmt.Sprintf(`.MinLength(%d)`, value)
+	}
+
+	if value, ok := spec.GetMaxLength(); ok {
+		res += fmt.Sprintf(`.MaxLength(%d)`, value)
+	}
+
+	if value, ok := spec.GetPattern(); ok {
+		res += fmt.Sprintf(`.Pattern(%q)`, value)
+	}
+
+	if value, ok := spec.GetMinItems(); ok {
+		res += fmt.Sprintf(`.MinItems(%d)`, value)
+	}
+
+	if value, ok := spec.GetMaxItems(); ok {
+		res += fmt.Sprintf(`.MaxItems(%d)`, value)
+	}
+
+	if spec.IsUniqueItems() {
+		res += ".UniqueItems()"
+	}
+
+	return res
+}
+
 func (sas SubActionSchema) getIndexDeclarationLiteral(idx collection.Index) string {
 	res := ""
 
@@ -143,8 +267,25 @@ func (sas SubActionSchema) getIndexDeclarationLiteral(idx collection.Index) stri
 			children,
 		)
 	case index.TypeText:
-		res += fmt.Sprintf(`index.TextIndex(index.Field("%s"))`,
-			idx.Spec().Fields[0].Key,
+		children := ""
+		for _, f := range idx.Spec().Fields {
+			// text keys are declared without value
+			if f.Value == index.KeyText {
+				children += fmt.Sprintf(`index.Field("%s"),%s`,
+					f.Key,
+					"\n",
+				)
+			} else {
+				children += fmt.Sprintf(`index.Field("%s", %s),%s`,
+					f.Key,
+					anyToLiteralString(f.Value),
+					"\n",
+				)
+			}
+		}
+		res += fmt.Sprintf(`index.TextIndex(%s%s)`,
+			"\n",
+			children,
 		)
 	case index.TypeGeopatial2dsphere:
 		res += fmt.Sprintf(`index.Geospatial2dsphereIndex(index.Field("%s"))`,
@@ -154,6 +295,19 @@ func (sas SubActionSchema) getIndexDeclarationLiteral(idx collection.Index) stri
 		res += fmt.Sprintf(`index.HashedIndex(index.Field("%s"))`,
 			idx.Spec().Fields[0].Key,
 		)
+	case index.TypeWildcard:
+		path := index.GetWildcardPath(idx.Spec().Fields[0].Key)
+		if path == "" {
+			res += "index.WildcardIndex()"
+		} else {
+			res += fmt.Sprintf(`index.WildcardIndex("%s")`,
+				path,
+			)
+		}
+	case index.TypeGeospatial2d:
+		res += fmt.Sprintf(`index.Geospatial2dIndex(index.Field("%s"))`,
+			idx.Spec().Fields[0].Key,
+		)
 	case index.TypeRaw:
 		fArgs := AnyToLiteral(fieldsToMap())
 		ruleArgs := rulesToLiteral()
@@ -202,7 +356,7 @@ func (sas SubActionSchema) getIndexDeclarationLiteral(idx collection.Index) stri
 		}
 
 		if idx.Spec().HasRule(index.OptionTTL) {
-			res += fmt.Sprintf(`.SetTTL(%s)`,
+			res += fmt.Sprintf(`.SetTTL(%d)`,
 				(*idx.Spec().Rules)[index.OptionTTL],
 			)
 		}
@@ -213,6 +367,50 @@ func (sas SubActionSchema) getIndexDeclarationLiteral(idx collection.Index) stri
 				fArgs,
 			)
 		}
+
+		if idx.Spec().HasRule(index.OptionWildcardProjection) {
+			fArgs := AnyToLiteral((*idx.Spec().Rules)[index.OptionWildcardProjection])
+			res += fmt.Sprintf(`.SetWildcardProjection(%s)`,
+				fArgs,
+			)
+		}
+
+		if idx.Spec().HasRule(index.OptionMin) && idx.Spec().HasRule(index.OptionMax) {
+			res += fmt.Sprintf(`.SetBounds(%v, %v)`,
+				(*idx.Spec().Rules)[index.OptionMin],
+				(*idx.Spec().Rules)[index.OptionMax],
+			)
+		}
+
+		if idx.Spec().HasRule(index.OptionBits) {
+			res += fmt.Sprintf(`.SetBits(%d)`,
+				(*idx.Spec().Rules)[index.OptionBits],
+			)
+		}
+
+		if idx.Spec().HasRule(index.OptionWeights) {
+			weights := (*idx.Spec().Rules)[index.OptionWeights].(map[string]interface{})
+			fArgs := "map[string]int32{\n"
+			for _, key := range sortedKeys(weights) {
+				fArgs += fmt.Sprintf(`"%s": %d,`, key, weights[key]) + "\n"
+			}
+			fArgs += "}"
+			res += fmt.Sprintf(`.SetWeights(%s)`,
+				fArgs,
+			)
+		}
+
+		if idx.Spec().HasRule(index.OptionDefaultLanguage) {
+			res += fmt.Sprintf(`.SetDefaultLanguage("%s")`,
+				(*idx.Spec().Rules)[index.OptionDefaultLanguage],
+			)
+		}
+
+		if idx.Spec().HasRule(index.OptionLanguageOverride) {
+			res += fmt.Sprintf(`.SetLanguageOverride("%s")`,
+				(*idx.Spec().Rules)[index.OptionLanguageOverride],
+			)
+		}
 	}
 
 	return res
@@ -244,6 +442,13 @@ func (sas SubActionSchema) GetLiteralInstance(prefix string, isArrayItem bool) s
 	if sas.FieldConvertFrom != nil {
 		res += fmt.Sprintf("FieldConvertFrom: field.GetTypePointer(field.%s),\n", sas.FieldConvertFrom.ToString())
 	}
+	// set modifyFrom if exists
+	if sas.CollectionModifyFrom != nil {
+		res += fmt.Sprintf("CollectionModifyFrom: %s,\n", getMetadataLiteral(sas.CollectionModifyFrom))
+	}
+	if sas.IndexModifyFrom != nil {
+		res += fmt.Sprintf("IndexModifyFrom: %s,\n", sas.getIndexDeclarationLiteral(sas.IndexModifyFrom))
+	}
 
 	res += "}"
 
@@ -6,3 +6,202 @@ Licensed under the MIT License
 (https://opensource.org/licenses/MIT)
 */
 package schema_interpreter
+
+import (
+	"go/parser"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+)
+
+func TestGetMetadataDeclarationLiteral(t *testing.T) {
+	// case 1: capped collection
+	case1Literal := SubActionSchema{
+		Collection: metadata.InitMetadata("logs").Capped(1024),
+	}.getMetadataDeclarationLiteral()
+	if case1Literal != `metadata.InitMetadata("logs").Capped(1024)` {
+		t.Errorf("Case 1: Unexpected literal %s", case1Literal)
+	}
+
+	// case 2: time series collection
+	case2TimeSeriesLiteral := SubActionSchema{
+		Collection: metadata.InitMetadata("metrics").TimeSeries("timestamp").SetMetaField("device").SetBucketSpan(300, 300).TTL(3600),
+	}.getMetadataDeclarationLiteral()
+	if case2TimeSeriesLiteral != `metadata.InitMetadata("metrics").TTL(3600).TimeSeries("timestamp").SetMetaField("device").SetBucketSpan(300, 300)` {
+		t.Errorf("Case 2: Unexpected literal %s", case2TimeSeriesLiteral)
+	}
+
+	// case 3: view with pipeline and collation
+	case3Literal := SubActionSchema{
+		Collection: metadata.InitMetadata("error_logs").ViewOn("logs", []map[string]interface{}{
+			{"$match": map[string]interface{}{"level": "error"}},
+		}).SetCollation(map[string]interface{}{"locale": "en"}),
+	}.getMetadataDeclarationLiteral()
+	for _, expected := range []string{
+		`metadata.InitMetadata("error_logs").ViewOn("logs", []map[string]interface{}{`,
+		`"$match": map[string]interface{}{`,
+		`"level": string("error"),`,
+		`.SetCollation(map[string]interface{}{`,
+		`"locale": string("en"),`,
+	} {
+		if !strings.Contains(case3Literal, expected) {
+			t.Errorf("Case 3: Literal must contain %s, got %s", expected, case3Literal)
+		}
+	}
+
+	// case 4: collection modification
+	case4Literal := SubActionSchema{
+		Collection:           metadata.InitMetadata("logs").Capped(2048),
+		CollectionModifyFrom: metadata.InitMetadata("logs").Capped(1024),
+	}.GetLiteralInstance("si.", false)
+	for _, expected := range []string{
+		`Collection: metadata.InitMetadata("logs").Capped(2048),`,
+		`CollectionModifyFrom: metadata.InitMetadata("logs").Capped(1024),`,
+	} {
+		if !strings.Contains(case4Literal, expected) {
+			t.Errorf("Case 4: Literal must contain %s, got %s", expected, case4Literal)
+		}
+	}
+
+	// case 5: index modification
+	case5Literal := SubActionSchema{
+		Collection: metadata.InitMetadata("logs"),
+		Indexes: []collection.Index{
+			index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(120).SetCustomIndexName("created_at_ttl"),
+		},
+		IndexModifyFrom: index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(60).SetCustomIndexName("created_at_ttl"),
+	}.GetLiteralInstance("si.", false)
+	for _, expected := range []string{
+		`index.SingleFieldIndex(index.Field("created_at", int(1))).SetCustomIndexName("created_at_ttl").SetTTL(120),`,
+		`IndexModifyFrom: index.SingleFieldIndex(index.Field("created_at", int(1))).SetCustomIndexName("created_at_ttl").SetTTL(60),`,
+	} {
+		if !strings.Contains(case5Literal, expected) {
+			t.Errorf("Case 5: Literal must contain %s, got %s", expected, case5Literal)
+		}
+	}
+
+	// case 6: view with bson values in the pipeline
+	case6Literal := SubActionSchema{
+		Collection: metadata.InitMetadata("admins").ViewOn("users", []map[string]interface{}{
+			{"$match": bson.M{"role": bson.M{"$in": []string{"admin", "owner"}}}},
+			{"$sort": bson.D{{Key: "name", Value: 1}}},
+		}),
+	}.getMetadataDeclarationLiteral()
+	if _, err := parser.ParseExpr(case6Literal); err != nil {
+		t.Errorf("Case 6: Invalid literal %s: %s", case6Literal, err.Error())
+	}
+
+	for _, expected := range []string{
+		"\"$in\": []interface{}{\nstring(\"admin\"),\nstring(\"owner\"),\n},",
+		"\"$sort\": si.D{\n{Key: \"name\", Value: int(1)},\n},",
+	} {
+		if !strings.Contains(case6Literal, expected) {
+			t.Errorf("Case 6: Literal must contain %s, got %s", expected, case6Literal)
+		}
+	}
+}
+
+func TestGetIndexDeclarationLiteral(t *testing.T) {
+	sas := SubActionSchema{}
+
+	// case 1: wildcard indexes
+	case1AllLiteral := sas.getIndexDeclarationLiteral(index.WildcardIndex().SetWildcardProjection(map[string]interface{}{"secret": 0}))
+	if case1AllLiteral != "index.WildcardIndex().SetWildcardProjection(map[string]interface{}{\n\"secret\": int(0),\n})" {
+		t.Errorf("Case 1: Unexpected literal %s", case1AllLiteral)
+	}
+
+	case1PathLiteral := sas.getIndexDeclarationLiteral(index.WildcardIndex("attributes"))
+	if case1PathLiteral != `index.WildcardIndex("attributes")` {
+		t.Errorf("Case 1: Unexpected literal %s", case1PathLiteral)
+	}
+
+	// case 2: 2d index
+	case2Literal := sas.getIndexDeclarationLiteral(index.Geospatial2dIndex(index.Field("location")).SetBounds(-90, 90).SetBits(26))
+	if case2Literal != `index.Geospatial2dIndex(index.Field("location")).SetBounds(-90, 90).SetBits(26)` {
+		t.Errorf("Case 2: Unexpected literal %s", case2Literal)
+	}
+
+	// case 3: compound text index with options
+	case3Literal := sas.getIndexDeclarationLiteral(
+		index.TextIndex(index.Field("category", 1), index.Field("title"), index.Field("body")).
+			SetWeights(map[string]int32{"title": 10, "body": 2}).
+			SetDefaultLanguage("english").
+			SetLanguageOverride("lang"),
+	)
+	expected := "index.TextIndex(\n" +
+		"index.Field(\"category\", int(1)),\n" +
+		"index.Field(\"title\"),\n" +
+		"index.Field(\"body\"),\n" +
+		")" +
+		".SetWeights(map[string]int32{\n\"body\": 2,\n\"title\": 10,\n})" +
+		`.SetDefaultLanguage("english")` +
+		`.SetLanguageOverride("lang")`
+	if case3Literal != expected {
+		t.Errorf("Case 3: Unexpected literal %s", case3Literal)
+	}
+
+	// case 4: compound index mixing 2dsphere and hashed keys
+	case4Literal := sas.getIndexDeclarationLiteral(index.CompoundIndex(index.Field("location").As2dsphere(), index.Field("category").AsHashed()))
+	if case4Literal != "index.CompoundIndex(\nindex.Field(\"location\", string(\"2dsphere\")),\nindex.Field(\"category\", string(\"hashed\")),\n)" {
+		t.Errorf("Case 4: Unexpected literal %s", case4Literal)
+	}
+}
+
+func TestGetFieldDeclarationLiteral(t *testing.T) {
+	sas := SubActionSchema{}
+
+	// case 1: BSON specific types
+	for _, pair := range []struct {
+		field   collection.Field
+		literal string
+	}{
+		{field.ObjectIDField("_id"), `field.ObjectIDField("_id")`},
+		{field.Decimal128Field("price"), `field.Decimal128Field("price")`},
+		{field.BinaryField("thumbnail").SetNullable(), `field.BinaryField("thumbnail").SetNullable()`},
+		{field.UUIDField("token"), `field.UUIDField("token")`},
+		{field.DateField("paid_at"), `field.DateField("paid_at")`},
+		// case 2: default values
+		{field.StringField("status").SetDefault("active"), `field.StringField("status").SetDefault(string("active"))`},
+		{field.StringField("status").SetDefault(`say "hi" C:\tmp`), `field.StringField("status").SetDefault(string("say \"hi\" C:\\tmp"))`},
+		{field.Int64Field("count").SetDefault(1).SetNullable(), `field.Int64Field("count").SetNullable().SetDefault(int(1))`},
+		{field.DateField("paid_at").SetDefault(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)), `field.DateField("paid_at").SetDefault(string("2024-01-01T00:00:00Z"))`},
+		{field.LegacyCoordinateArrayField("location").SetDefault([]float64{0, 0}), "field.LegacyCoordinateArrayField(\"location\").SetDefault([]interface{}{\nfloat64(0),\nfloat64(0),\n})"},
+		// case 3: value constraints
+		{field.StringField("status").Enum("active", "inactive").SetDefault("active"), `field.StringField("status").SetDefault(string("active")).Enum(string("active"), string("inactive"))`},
+		{field.StringField("code").MinLength(2).MaxLength(4).Pattern(`^[A-Z]+\d?$`), `field.StringField("code").MinLength(2).MaxLength(4).Pattern("^[A-Z]+\\d?$")`},
+		{field.StringField("path").Enum(`C:\tmp`, `say "hi"`).Pattern(`^"\d+"\\$`), `field.StringField("path").Enum(string("C:\\tmp"), string("say \"hi\"")).Pattern("^\"\\d+\"\\\\$")`},
+		{field.DoubleField("score").Min(-1.5).Max(1e6), `field.DoubleField("score").Min(-1.5).Max(1e+06)`},
+		{field.ArrayField("tags", field.StringField("")).MinItems(1).MaxItems(5).UniqueItems(), "field.ArrayField(\"tags\",\nfield.StringField(\"\"),\n).MinItems(1).MaxItems(5).UniqueItems()"},
+		// case 4: map fields
+		{field.MapField("scores", field.Int32Field("")).SetNullable(), `field.MapField("scores", field.Int32Field("")).SetNullable()`},
+		{field.MapField("attributes", field.ObjectField("", field.StringField("label"))), "field.MapField(\"attributes\", field.ObjectField(\"\",\nfield.StringField(\"label\"),\n))"},
+		// case 5: one of fields
+		{
+			field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+				"view":  field.ObjectField("", field.StringField("page")),
+				"click": field.ObjectField("", field.Int32Field("x")).SetExtra(field.ExtraDrop, true),
+			}).SetNullable(),
+			"field.OneOf(\"payload\", \"kind\", map[string]*field.FieldSpec{\n" +
+				"\"click\": field.ObjectField(\"\",\nfield.Int32Field(\"x\"),\n).SetExtra(field.ExtraDrop, true),\n" +
+				"\"view\": field.ObjectField(\"\",\nfield.StringField(\"page\"),\n),\n" +
+				"}).SetNullable()",
+		},
+	} {
+		literal := sas.getFieldDeclarationLiteral(pair.field)
+		if literal != pair.literal {
+			t.Errorf("Unexpected literal %s", literal)
+		}
+
+		// the literal is written to a migration file, so it must be a valid expression
+		if _, err := parser.ParseExpr(literal); err != nil {
+			t.Errorf("Invalid literal %s: %s", literal, err.Error())
+		}
+	}
+}
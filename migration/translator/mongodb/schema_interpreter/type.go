@@ -18,6 +18,11 @@ const (
 	SubActionTypeDropCollection   SubActionType = "SubActionTypeDropCollection"
 	SubActionTypeDropIndex        SubActionType = "SubActionTypeDropIndex"
 	SubActionTypeDropField        SubActionType = "SubActionTypeDropField"
+	SubActionTypeSetValidator     SubActionType = "SubActionTypeSetValidator"
+	SubActionTypeUnsetValidator   SubActionType = "SubActionTypeUnsetValidator"
+	SubActionTypeRenameField      SubActionType = "SubActionTypeRenameField"
+	SubActionTypeModifyCollection SubActionType = "SubActionTypeModifyCollection"
+	SubActionTypeModifyIndex      SubActionType = "SubActionTypeModifyIndex"
 )
 
 func (sat SubActionType) ToString() string {
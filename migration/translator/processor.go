@@ -9,10 +9,12 @@ package translator
 
 import (
 	"context"
+	"sort"
 
 	"github.com/amirkode/go-mongr8/collection"
 	dt "github.com/amirkode/go-mongr8/internal/data_type"
 	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/option"
 	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
 	ai "github.com/amirkode/go-mongr8/migration/translator/mongodb/api_interpreter"
 	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
@@ -22,9 +24,10 @@ import (
 type (
 	ProcessorIf interface {
 		validateCollection(collections []collection.Collection, panic bool) error
-		GetApi(migrations []migrator.Migration, dbSchemas []collection.Collection) []ai.SubActionApi
+		GetApi(migrations []migrator.Migration) []ai.SubActionApi
+		GetRollbackApi(migrations []migrator.Migration) []ai.SubActionApi
 		Generate(collections []collection.Collection, migrations []migrator.Migration) dt.Pair[[]si.Action, []si.Action]
-		Consolidate(collections []collection.Collection, dbCollections []collection.Collection, migrations []migrator.Migration)
+		Consolidate(collections []collection.Collection, dbCollections []collection.Collection, migrations []migrator.Migration) dt.Pair[[]si.Action, []si.Action]
 	}
 
 	Processor struct {
@@ -50,19 +53,47 @@ func (p Processor) validateCollection(collections []collection.Collection, panic
 	return nil
 }
 
-func (p Processor) GetApi(migrations []migrator.Migration, dbSchemas []collection.Collection) []ai.SubActionApi {
+func (p Processor) GetApi(migrations []migrator.Migration) []ai.SubActionApi {
 	// For now, we only add Up Actions
-	// pair of migration ID and sub action
-	subActions := []dt.Pair[migrator.Migration, si.SubAction]{}
+	res := []ai.SubActionApi{}
 	for _, m := range migrations {
 		for _, action := range m.Up {
+			for idx, subAction := range action.SubActions {
+				// the position is attached, so the progress of each sub action can be recorded
+				subActions := []dt.Pair[migrator.Migration, si.SubAction]{dt.NewPair(m, subAction)}
+				for _, api := range ai.GetSubActionApis(subActions) {
+					api.ActionKey = action.ActionKey
+					api.SubActionIndex = idx
+					res = append(res, api)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func (p Processor) GetRollbackApi(migrations []migrator.Migration) []ai.SubActionApi {
+	// the latest migration must be reverted first
+	sorted := make([]migrator.Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	// pair of migration ID and sub action
+	// Down actions are executed in the stored order, not reversed,
+	// since they're generated in the reverting order (i.e: a view is dropped before its source)
+	subActions := []dt.Pair[migrator.Migration, si.SubAction]{}
+	for _, m := range sorted {
+		for _, action := range m.Down {
 			for _, subAction := range action.SubActions {
 				subActions = append(subActions, dt.NewPair(m, subAction))
 			}
 		}
 	}
 
-	return ai.GetSubActionApis(subActions, dbSchemas)
+	return ai.GetSubActionApis(subActions)
 }
 
 func (p Processor) Generate(collections []collection.Collection, migrations []migrator.Migration) dt.Pair[[]si.Action, []si.Action] {
@@ -76,11 +107,25 @@ func (p Processor) Generate(collections []collection.Collection, migrations []mi
 		panic(err)
 	}
 
-	return sync_strategy.GetActions(collections, collectionsFromMigrations)
+	actions := sync_strategy.GetActions(collections, collectionsFromMigrations)
+	// validators are generated after the schema changes
+	useSchemaValidation := option.GetMigrationOptionFromContext(p.Ctx).UseSchemaValidation
+	validatorActions := sync_strategy.GetValidatorActions(collections, migrations, useSchemaValidation)
+
+	return dt.NewPair(
+		sync_strategy.MergeActions(actions.First, validatorActions.First),
+		sync_strategy.MergeActions(actions.Second, validatorActions.Second),
+	)
 }
 
-func (p Processor) Consolidate(collections []collection.Collection, dbCollections []collection.Collection, migrations []migrator.Migration) {
-	// TODO: something to find consolidation resulting intersection of user-defined collections, db collections, migration-generated sub action schemas
+func (p Processor) Consolidate(collections []collection.Collection, dbCollections []collection.Collection, migrations []migrator.Migration) dt.Pair[[]si.Action, []si.Action] {
+	// validate incoming collections
+	p.validateCollection(collections, true)
+	collectionsFromMigrations := sync_strategy.GetCollectionFromMigrations(migrations)
+	// the actual schema in database becomes the new state of migration files
+	consolidated := sync_strategy.GetConsolidatedCollections(collections, collectionsFromMigrations, dbCollections)
+
+	return sync_strategy.GetActions(consolidated, collectionsFromMigrations)
 }
 
 func NewProcessor(ctx *context.Context) ProcessorIf {
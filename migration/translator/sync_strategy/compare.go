@@ -17,8 +17,11 @@ import (
 
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
 	dt "github.com/amirkode/go-mongr8/internal/data_type"
 	"github.com/amirkode/go-mongr8/internal/util"
+	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
 )
 
 type EntitySign int8
@@ -30,6 +33,9 @@ const (
 	// for now, the usecase is only for field entity
 	// make it generic sign to cover future usecase in other entities
 	SignConvert EntitySign = 0
+	// this additional sign means the entity is renamed from previous entity
+	// for now, the usecase is only for field entity declared with `RenamedFrom`
+	SignRename EntitySign = 2
 )
 
 type (
@@ -64,13 +70,15 @@ type (
 	SignedIndex struct {
 		operator[SignedIndex]
 		collection.Index
-		Sign EntitySign
+		convertFrom *SignedIndex
+		Sign        EntitySign
 	}
 
 	SignedMetadata struct {
 		operator[SignedMetadata]
 		collection.Metadata
-		Sign EntitySign
+		convertFrom *SignedMetadata
+		Sign        EntitySign
 	}
 
 	SignedCollection struct {
@@ -83,6 +91,66 @@ type (
 	}
 )
 
+// This resolves renamed fields between incoming and origin fields in the same level
+// a field is renamed if it declares a previous name existing in origin, while its current name does not.
+// It returns the renamed fields (holding the previous name as extra), and
+// the origin fields with the renamed ones taking the new names, so any other difference
+// of the renamed fields is found as usual
+func resolveFieldRenames(incoming []SignedField, origin []SignedField) ([]SignedField, []SignedField) {
+	originByName := map[string]SignedField{}
+	for _, org := range origin {
+		originByName[org.Key()] = org
+	}
+
+	incomingNames := map[string]bool{}
+	for _, inc := range incoming {
+		incomingNames[inc.Key()] = true
+	}
+
+	renamed := []SignedField{}
+	newNames := map[string]string{}
+	for _, inc := range incoming {
+		oldName := inc.Spec().GetRenamedFrom()
+		if oldName == "" || oldName == inc.Key() || incomingNames[oldName] {
+			continue
+		}
+
+		// already renamed in previous migration
+		if _, ok := originByName[inc.Key()]; ok {
+			continue
+		}
+
+		if _, ok := originByName[oldName]; !ok {
+			continue
+		}
+
+		spec := util.DeepCopy(*inc.Spec())
+		renamed = append(renamed, SignedField{
+			Field: field.FromFieldSpec(&spec),
+			Sign:  SignRename,
+		})
+		newNames[oldName] = inc.Key()
+	}
+
+	adjustedOrigin := []SignedField{}
+	for _, org := range origin {
+		newName, ok := newNames[org.Key()]
+		if !ok {
+			adjustedOrigin = append(adjustedOrigin, org)
+			continue
+		}
+
+		spec := util.DeepCopy(*org.Spec())
+		spec.Name = newName
+		adjustedOrigin = append(adjustedOrigin, SignedField{
+			Field: field.FromFieldSpec(&spec),
+			Sign:  org.Sign,
+		})
+	}
+
+	return renamed, adjustedOrigin
+}
+
 func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 	if incoming.Key() != origin.Key() {
 		return nil
@@ -116,17 +184,19 @@ func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 			if !util.InList(prevType, []field.FieldType{
 				field.TypeArray,
 				field.TypeObject,
+				field.TypeMap,
+				field.TypeOneOf,
 			}) {
-				panic(fmt.Sprintf("Cannot add more child, previous field type (%s) is not Array or Object", prevType))
+				panic(fmt.Sprintf("Cannot add more child, previous field type (%s) is not Array, Object, Map, or One Of", prevType))
 			}
 
 			currField := collection.FieldFromType(path[i].First, path[i].Second)
 			switch prevType {
-			case field.TypeArray:
-				// set current field as previous field's array field
+			case field.TypeArray, field.TypeMap:
+				// set current field as previous field's array field (or map value)
 				(*prevField).Spec().ArrayFields = &[]field.Spec{*currField.Spec()}
-			case field.TypeObject:
-				// set current field as previous field's object field
+			case field.TypeObject, field.TypeOneOf:
+				// set current field as previous field's object field (or variant)
 				(*prevField).Spec().Object = &[]field.Spec{*currField.Spec()}
 			}
 
@@ -149,10 +219,10 @@ func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 		// check type of both fields
 		// assuming if one of the field is not an array or an object
 		// we don't need to check the children (array/object)
-		if this.Spec().Type != other.Spec().Type {
+		// types stored the same (i.e: timestamp and date) need no conversion
+		if !this.Spec().Type.IsSameStoredType(other.Spec().Type) {
 			// decide proper type conversion (Supported, Unsupported, or Undefined)
-			if this.Spec().Type == field.TypeString ||
-				(this.Spec().Type.IsNumeric() && other.Spec().Type.IsNumeric()) {
+			if this.Spec().Type.IsConvertibleFrom(other.Spec().Type) {
 				// by default any type to string must be supported
 				// for numeric to numeric conversion, there's an edge case
 				// please see note on sync.go
@@ -203,7 +273,9 @@ func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 		// the behaviour might change in the future. So, we keep declaring this DFS function
 		// - otherwise, we perform nothing
 		switch this.Spec().Type {
-		case field.TypeArray:
+		case field.TypeArray, field.TypeMap:
+			// map value is held as a single array field,
+			// so it's compared the same way as an array item
 			if this.Spec().ArrayFields == nil || len(*this.Spec().ArrayFields) == 0 {
 				panic("Array fields should not be nil or empty")
 			}
@@ -266,14 +338,28 @@ func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 				// eventually, add curr to res
 				res = append(res, curr)
 			}
-		case field.TypeObject:
+		case field.TypeObject, field.TypeOneOf:
 			// for object, we do the exact same operation as the array field
 			// we separate all the (similar) parts because we might
 			// encounter some differences in the future
+			// variants of one of are compared the same way as object fields,
+			// each variant is keyed by its discriminator value
 			if this.Spec().Object == nil || len(*this.Spec().Object) == 0 {
 				panic("Object fields should not be nil or empty")
 			}
 
+			// the discriminator key is not restored from the path, so it's set back
+			restoreObjectPath := func() (SignedField, *collection.Field) {
+				curr, lastField := restorePath(path)
+				if this.Spec().Type == field.TypeOneOf {
+					(*lastField).Spec().Extra = map[field.FieldExtra]any{
+						field.ExtraDiscriminator: this.Spec().GetDiscriminator(),
+					}
+				}
+
+				return curr, lastField
+			}
+
 			// just perform union
 			// init this array fields
 			thisFields := []SignedField{}
@@ -298,10 +384,23 @@ func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 				}
 			}
 
+			// renamed children are resolved before the union,
+			// a variant can't be renamed, since its name is the discriminator value
+			renamed := []SignedField{}
+			if this.Spec().Type == field.TypeObject {
+				renamed, otherObjFields = resolveFieldRenames(thisFields, otherObjFields)
+			}
+			for _, r := range renamed {
+				curr, lastField := restoreObjectPath()
+				curr.Sign = SignRename
+				(*lastField).Spec().Object = &[]field.Spec{*r.Spec()}
+				res = append(res, curr)
+			}
+
 			union := Union(thisFields, otherObjFields)
 			for _, u := range union {
 				// create new instance of signed field each child
-				curr, lastField := restorePath(path)
+				curr, lastField := restoreObjectPath()
 				// the sign of curr based on nested child u
 				curr.Sign = u.Sign
 				// check whether current field sign is convert
@@ -309,7 +408,7 @@ func (incoming SignedField) Intersect(origin SignedField) *[]SignedField {
 				// both current and convertFrom should have the same path
 				switch u.Sign {
 				case SignConvert:
-					convertFrom, cfLastField := restorePath(path)
+					convertFrom, cfLastField := restoreObjectPath()
 					cfCurrSpec := *u.convertFrom.Spec()
 					(*cfLastField).Spec().Object = &[]field.Spec{cfCurrSpec}
 					// set curr.convertFrom
@@ -359,9 +458,9 @@ func (f SignedField) FieldDeepestType() field.FieldType {
 	var dfs func(_field collection.Field) field.FieldType
 	dfs = func(_field collection.Field) field.FieldType {
 		switch _field.Spec().Type {
-		case field.TypeArray:
+		case field.TypeArray, field.TypeMap:
 			return dfs(field.FromFieldSpec(&(*_field.Spec().ArrayFields)[0]))
-		case field.TypeObject:
+		case field.TypeObject, field.TypeOneOf:
 			return dfs(field.FromFieldSpec(&(*_field.Spec().Object)[0]))
 		}
 
@@ -377,10 +476,10 @@ func (f SignedField) SetFieldDeepestType(toType field.FieldType) {
 	var dfs func(_field *field.Spec)
 	dfs = func(_field *field.Spec) {
 		switch (*_field).Type {
-		case field.TypeArray:
+		case field.TypeArray, field.TypeMap:
 			dfs(&(*_field.ArrayFields)[0])
 			return
-		case field.TypeObject:
+		case field.TypeObject, field.TypeOneOf:
 			dfs(&(*_field.Object)[0])
 			return
 		}
@@ -396,13 +495,13 @@ func (f SignedField) RefreshFieldAddresses() SignedField {
 	deepCopyField = func(_field *field.Spec) *field.Spec {
 		newField := *_field
 		switch newField.Type {
-		case field.TypeArray:
+		case field.TypeArray, field.TypeMap:
 			newArrayFields := []field.Spec{}
 			for _, arr := range *newField.ArrayFields {
 				newArrayFields = append(newArrayFields, *deepCopyField(&arr))
 			}
 			newField.ArrayFields = &newArrayFields
-		case field.TypeObject:
+		case field.TypeObject, field.TypeOneOf:
 			newObjectFields := []field.Spec{}
 			for _, obj := range *newField.Object {
 				newObjectFields = append(newObjectFields, *deepCopyField(&obj))
@@ -433,6 +532,48 @@ func (f SignedField) Key() string {
 	return f.Spec().Name
 }
 
+// This resolves indexes those are only different in modifiable options
+// a dropped index and an added index sharing the same base key are merged
+// into a single index modification, holding the dropped index as `convertFrom`.
+// The modified index keeps the name of the dropped index, since it's not rebuilt
+func resolveIndexModifications(signedIndexes []SignedIndex) []SignedIndex {
+	dropped := map[string]SignedIndex{}
+	for _, signedIndex := range signedIndexes {
+		if signedIndex.Sign == SignMinus {
+			dropped[signedIndex.Spec().GetBaseKey()] = signedIndex
+		}
+	}
+
+	modified := map[string]bool{}
+	res := []SignedIndex{}
+	for _, signedIndex := range signedIndexes {
+		from, ok := dropped[signedIndex.Spec().GetBaseKey()]
+		if signedIndex.Sign != SignPlus || !ok || modified[from.Key()] || !from.Spec().IsModifiableTo(signedIndex.Spec()) {
+			continue
+		}
+
+		spec := util.DeepCopy(*signedIndex.Spec())
+		name := from.Spec().GetName()
+		spec.Name = &name
+		origin := from
+		res = append(res, SignedIndex{
+			Index:       index.FromIndexSpec(&spec),
+			convertFrom: &origin,
+			Sign:        SignConvert,
+		})
+		modified[from.Key()] = true
+		modified[signedIndex.Key()] = true
+	}
+
+	for _, signedIndex := range signedIndexes {
+		if !modified[signedIndex.Key()] {
+			res = append(res, signedIndex)
+		}
+	}
+
+	return res
+}
+
 // this returns the difference between two index
 // if both share the same name and type
 // cases that might happen:
@@ -460,7 +601,9 @@ func (f SignedIndex) SetDropCheckpoint() SignedIndex {
 	return f
 }
 
-func (f SignedIndex) ConvertFrom() *SignedIndex { return nil }
+func (f SignedIndex) ConvertFrom() *SignedIndex {
+	return f.convertFrom
+}
 
 func (f SignedIndex) SetSign(sign EntitySign) SignedIndex {
 	f.Sign = sign
@@ -475,8 +618,26 @@ func (f SignedIndex) Key() string {
 	return f.Spec().GetKey()
 }
 
+// this returns the modification of options between two metadata of the same collection
+// the modification is validated, so any option that cannot be modified will panic
 func (f SignedMetadata) Intersect(other SignedMetadata) *[]SignedMetadata {
-	return nil
+	if f.Key() == other.Key() {
+		return nil
+	}
+
+	err := dictionary.ValidateMetadataModification(other.Metadata, f.Metadata)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	origin := other
+	return &[]SignedMetadata{
+		{
+			Metadata:    f.Metadata,
+			convertFrom: &origin,
+			Sign:        SignConvert,
+		},
+	}
 }
 
 func (f SignedMetadata) SetDropCheckpoint() SignedMetadata {
@@ -485,7 +646,9 @@ func (f SignedMetadata) SetDropCheckpoint() SignedMetadata {
 }
 
 
-func (f SignedMetadata) ConvertFrom() *SignedMetadata { return nil }
+func (f SignedMetadata) ConvertFrom() *SignedMetadata {
+	return f.convertFrom
+}
 
 func (f SignedMetadata) SetSign(sign EntitySign) SignedMetadata {
 	f.Sign = sign
@@ -512,16 +675,45 @@ func (f SignedCollection) Intersect(other SignedCollection) *[]SignedCollection
 		return nil
 	}
 
-	// panic if there's any metadata difference
-	// once a collection is declared, it's expected
-	// not to be modfied on any option
-	if f.Metadata.Key() != other.Metadata.Key() {
-		panic(fmt.Sprintf("Metadata should be modified on collection %s", f.Metadata.Spec().Name))
+	// a view holds no data, so any change of its definition
+	// is applied by dropping the previous view and creating the new one
+	if f.Metadata.Spec().Type == metadata.TypeViewCollection && other.Metadata.Spec().Type == metadata.TypeViewCollection {
+		if f.Metadata.Key() == other.Metadata.Key() && len(Union(f.Fields, other.Fields)) == 0 {
+			return nil
+		}
+
+		return &[]SignedCollection{
+			other.SetSign(SignMinus),
+			f.SetSign(SignPlus),
+		}
 	}
 
 	res := []SignedCollection{}
+	// modified metadata options are pushed as a single SignedCollection
+	if signedMetadata := f.Metadata.Intersect(other.Metadata); signedMetadata != nil {
+		for _, m := range *signedMetadata {
+			res = append(res, SignedCollection{
+				Metadata:       m,
+				Sign:           m.Sign,
+				IsIntersection: true,
+			})
+		}
+	}
+
+	// renamed fields are resolved before the union
+	renamedFields, otherFields := resolveFieldRenames(f.Fields, other.Fields)
+	for _, renamedField := range renamedFields {
+		curr := renamedField
+		res = append(res, SignedCollection{
+			Metadata:       f.Metadata,
+			Fields:         []SignedField{curr},
+			Sign:           curr.Sign,
+			IsIntersection: true,
+		})
+	}
+
 	// get union of fields and push as individual SignedCollection(s)
-	signedFields := Union(f.Fields, other.Fields)
+	signedFields := Union(f.Fields, otherFields)
 	for _, signedField := range signedFields {
 		curr := signedField
 		res = append(res, SignedCollection{
@@ -532,7 +724,7 @@ func (f SignedCollection) Intersect(other SignedCollection) *[]SignedCollection
 		})
 	}
 	// get union of indexes and push as individual SignedCollection(s)
-	signedIndexes := Union(f.Indexes, other.Indexes)
+	signedIndexes := resolveIndexModifications(Union(f.Indexes, other.Indexes))
 	for _, signedIndex := range signedIndexes {
 		curr := signedIndex
 		res = append(res, SignedCollection{
@@ -149,6 +149,50 @@ func TestSignedFieldIntersect(t *testing.T) {
 	test.AssertEqual(t, (*case4Intersection)[0].Sign, SignConvert, "Case 4: Intersection sign is not convert")
 	test.AssertEqual(t, (*case4Intersection)[0].Spec().Type, field.TypeDouble, "Case 4: Conversion Type is not Double")
 	test.AssertEqual(t, (*case4Intersection)[0].convertFrom.Spec().Type, field.TypeInt64, "Case 4: ConversionFrom Type is not Int64")
+
+	// test intersection with conversion from string to object id
+	case5Field1 := SignedField{
+		Field: field.ObjectIDField("owner_id"),
+	}
+	case5Field2 := SignedField{
+		Field: field.StringField("owner_id"),
+	}
+	case5Intersection := case5Field1.Intersect(case5Field2)
+
+	test.AssertTrue(t, case5Intersection != nil && len(*case5Intersection) == 1, "Case 5: Intersection is not expected")
+	test.AssertEqual(t, (*case5Intersection)[0].Sign, SignConvert, "Case 5: Intersection sign is not convert")
+	test.AssertEqual(t, (*case5Intersection)[0].convertFrom.Spec().Type, field.TypeString, "Case 5: ConversionFrom Type is not String")
+
+	// test intersection from string to a type without conversion
+	case6Field1 := SignedField{
+		Field: field.BooleanField("active"),
+	}
+	case6Field2 := SignedField{
+		Field: field.StringField("active"),
+	}
+	case6Intersection := case6Field1.Intersect(case6Field2)
+
+	test.AssertTrue(t, case6Intersection != nil && len(*case6Intersection) == 2, "Case 6: Intersection is not expected")
+
+	// test intersection between timestamp and date, both are stored as a BSON date
+	case7Field1 := SignedField{
+		Field: field.DateField("paid_at"),
+	}
+	case7Field2 := SignedField{
+		Field: field.TimestampField("paid_at"),
+	}
+	case7Intersection := case7Field1.Intersect(case7Field2)
+
+	test.AssertTrue(t, case7Intersection != nil && len(*case7Intersection) == 0, "Case 7: No conversion is expected")
+
+	// the same inside an array
+	case8Intersection := SignedField{
+		Field: field.ArrayField("history", field.TimestampField("")),
+	}.Intersect(SignedField{
+		Field: field.ArrayField("history", field.DateField("")),
+	})
+
+	test.AssertTrue(t, case8Intersection != nil && len(*case8Intersection) == 0, "Case 8: No conversion is expected")
 }
 
 func TestSignedFieldUnion(t *testing.T) {
@@ -370,7 +414,22 @@ func TestSignedMetadataIntersect(t *testing.T) {
 	}
 	case2Intersection := case2Metadata1.Intersect(case2Metadata2)
 
-	test.AssertTrue(t, case2Intersection == nil, "Case 2: Intersection is not nil")
+	test.AssertTrue(t, case2Intersection != nil, "Case 2: Intersection is nil")
+	test.AssertEqual(t, len(*case2Intersection), 1, "Case 2: Intersection length must be 1")
+	test.AssertEqual(t, (*case2Intersection)[0].Sign, SignConvert, "Case 2: Unexpected sign")
+	test.AssertEqual(t, (*case2Intersection)[0].ConvertFrom().Key(), case2Metadata2.Key(), "Case 2: Unexpected metadata converted from")
+
+	// test invalid modification
+	defer func() {
+		test.AssertTrue(t, recover() != nil, "Case 3: Uncapping must panic")
+	}()
+	case3Metadata1 := SignedMetadata{
+		Metadata: metadata.InitMetadata("users"),
+	}
+	case3Metadata2 := SignedMetadata{
+		Metadata: metadata.InitMetadata("users").Capped(50000),
+	}
+	case3Metadata1.Intersect(case3Metadata2)
 }
 
 func TestSignedMetadataUnion(t *testing.T) {
@@ -0,0 +1,197 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package sync_strategy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/internal/util"
+	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
+)
+
+/*
+This contains mechanism to reconstruct the actual schema in database
+for all collections managed by go-mongr8, it's used to consolidate
+migration files after manual changes (i.e: hotfixes) in the database.
+
+The rules:
+- Collections: a managed collection is either declared in user-defined collections
+  or migration files. It's considered dropped if it's not found in the database.
+  A user-defined collection only existing in the database is merged the same way
+  with the user-defined collection instead of migration files.
+- Fields: fields are inferred from sampled documents, so a field missing from the sample
+  does not prove the field was dropped. Only new fields and type changes are taken from database.
+- Indexes: indexes in database are authoritative. An index from migration files is kept
+  as it is, if there's an index with the same name and keys in the database.
+- Metadata: modifiable options in database (i.e: capped size, TTL, and time series bucketing)
+  are taken from database, so they're generated as a collection modification.
+  Other changes (i.e: collation or view definition) cannot be modified, the one from migration files is kept.
+*/
+
+func indexFieldsAreEqual(a, b collection.Index) bool {
+	if len(a.Spec().Fields) != len(b.Spec().Fields) {
+		return false
+	}
+
+	for i := range a.Spec().Fields {
+		if a.Spec().Fields[i].Key != b.Spec().Fields[i].Key ||
+			fmt.Sprintf("%v", a.Spec().Fields[i].Value) != fmt.Sprintf("%v", b.Spec().Fields[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func consolidateIndexes(fromMigrations, fromDB []collection.Index) []collection.Index {
+	res := []collection.Index{}
+	for _, dbIndex := range fromDB {
+		curr := dbIndex
+		for _, migrationIndex := range fromMigrations {
+			if migrationIndex.Spec().GetKey() == dbIndex.Spec().GetKey() ||
+				(migrationIndex.Spec().GetName() == dbIndex.Spec().GetName() && indexFieldsAreEqual(migrationIndex, dbIndex)) {
+				curr = migrationIndex
+				break
+			}
+		}
+
+		res = append(res, curr)
+	}
+
+	return res
+}
+
+func consolidateMetadata(fromMigrations, fromDB collection.Metadata) collection.Metadata {
+	if (SignedMetadata{Metadata: fromMigrations}).Key() == (SignedMetadata{Metadata: fromDB}).Key() {
+		return fromMigrations
+	}
+
+	if err := dictionary.ValidateMetadataModification(fromMigrations, fromDB); err != nil {
+		log.Printf("Metadata of collection %s has been modified in database, the change is skipped: %s\n", fromMigrations.Spec().Name, err.Error())
+		return fromMigrations
+	}
+
+	return fromDB
+}
+
+func consolidateFields(fromMigrations, fromDB []collection.Field) []collection.Field {
+	dbFields := map[string]collection.Field{}
+	for _, f := range fromDB {
+		dbFields[f.Spec().Name] = f
+	}
+
+	res := []collection.Field{}
+	added := map[string]bool{}
+	for _, migrationField := range fromMigrations {
+		added[migrationField.Spec().Name] = true
+		dbField, ok := dbFields[migrationField.Spec().Name]
+		if !ok {
+			// not found in the sample, keep it
+			res = append(res, migrationField)
+			continue
+		}
+
+		// a map or a one of is inferred as an object from sampled documents,
+		// since its keys depend on the data
+		if util.InList(migrationField.Spec().Type, []field.FieldType{field.TypeMap, field.TypeOneOf}) && dbField.Spec().Type == field.TypeObject {
+			res = append(res, migrationField)
+			continue
+		}
+
+		if migrationField.Spec().Type != dbField.Spec().Type {
+			// type has been changed in database
+			res = append(res, dbField)
+			continue
+		}
+
+		switch migrationField.Spec().Type {
+		case field.TypeObject:
+			children := collection.SpecsFromFields(consolidateFields(
+				collection.FieldsFromSpecs(migrationField.Spec().Object),
+				collection.FieldsFromSpecs(dbField.Spec().Object),
+			))
+			spec := *migrationField.Spec()
+			spec.Object = &children
+			curr := field.FromFieldSpec(&spec)
+			res = append(res, curr)
+		case field.TypeArray:
+			items := collection.SpecsFromFields(consolidateFields(
+				collection.FieldsFromSpecs(migrationField.Spec().ArrayFields),
+				collection.FieldsFromSpecs(dbField.Spec().ArrayFields),
+			))
+			spec := *migrationField.Spec()
+			spec.ArrayFields = &items
+			curr := field.FromFieldSpec(&spec)
+			res = append(res, curr)
+		default:
+			res = append(res, migrationField)
+		}
+	}
+
+	// new fields in database
+	for _, dbField := range fromDB {
+		if !added[dbField.Spec().Name] {
+			res = append(res, dbField)
+		}
+	}
+
+	return res
+}
+
+// This returns the actual schema in database for all collections managed by go-mongr8
+// `incoming` is the latest user defined collection in `[project dir]/mongr8/collection`
+// `origin` is the schema generated by the migration files in `[project dir]/mongr8/migration`
+// `fromDB` is the schema reconstructed from the database
+func GetConsolidatedCollections(incoming, origin, fromDB []collection.Collection) []collection.Collection {
+	dbCollections := map[string]collection.Collection{}
+	for _, coll := range fromDB {
+		dbCollections[coll.Collection().Spec().Name] = coll
+	}
+
+	res := []collection.Collection{}
+	managed := map[string]bool{}
+	for _, coll := range origin {
+		name := coll.Collection().Spec().Name
+		managed[name] = true
+		dbColl, ok := dbCollections[name]
+		if !ok {
+			// dropped from database
+			continue
+		}
+
+		res = append(res, collection.NewCollection(
+			consolidateMetadata(coll.Collection(), dbColl.Collection()),
+			consolidateFields(coll.Fields(), dbColl.Fields()),
+			consolidateIndexes(coll.Indexes(), dbColl.Indexes()),
+		))
+	}
+
+	// collections those are defined by the user, but only exist in database
+	for _, coll := range incoming {
+		name := coll.Collection().Spec().Name
+		if managed[name] {
+			continue
+		}
+
+		managed[name] = true
+		if dbColl, ok := dbCollections[name]; ok {
+			// the declared fields and indexes are merged over the inferred ones,
+			// so their options (i.e: nullable, constraints, and defaults) are kept
+			res = append(res, collection.NewCollection(
+				dbColl.Collection(),
+				consolidateFields(coll.Fields(), dbColl.Fields()),
+				consolidateIndexes(coll.Indexes(), dbColl.Indexes()),
+			))
+		}
+	}
+
+	return res
+}
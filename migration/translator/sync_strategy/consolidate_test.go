@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package sync_strategy
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/internal/test"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+)
+
+func TestGetConsolidatedCollections(t *testing.T) {
+	origin := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("name"),
+				field.Int32Field("age"),
+				field.ObjectField("additional_info",
+					field.StringField("address"),
+				),
+			},
+			[]collection.Index{
+				index.SingleFieldIndex(index.Field("name", 1)).AsUnique(),
+				index.SingleFieldIndex(index.Field("age", 1)),
+			},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("logs"),
+			[]collection.Field{field.StringField("message")},
+			[]collection.Index{},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("events").Capped(1024),
+			[]collection.Field{field.StringField("type")},
+			[]collection.Index{},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("sessions").SetCollation(map[string]interface{}{"locale": "en"}),
+			[]collection.Field{field.StringField("token")},
+			[]collection.Index{},
+		),
+	}
+	incoming := []collection.Collection{
+		origin[0],
+		collection.NewCollection(
+			metadata.InitMetadata("products"),
+			[]collection.Field{
+				field.StringField("title").MinLength(3).SetDefault("untitled"),
+				field.DoubleField("price").SetNullable(),
+			},
+			[]collection.Index{
+				index.SingleFieldIndex(index.Field("title", 1)).AsUnique(),
+			},
+		),
+	}
+	fromDB := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				// age is not sampled
+				field.StringField("name"),
+				field.ObjectField("additional_info",
+					field.StringField("address"),
+					field.Int32Field("zip_code"),
+				),
+				field.DoubleField("score"),
+			},
+			[]collection.Index{
+				index.SingleFieldIndex(index.Field("name", 1)).AsUnique().SetCustomIndexName("name_1_unique_true"),
+				index.SingleFieldIndex(index.Field("score", -1)),
+			},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("products"),
+			[]collection.Field{field.StringField("title"), field.Int64Field("stock")},
+			[]collection.Index{
+				index.SingleFieldIndex(index.Field("title", 1)).AsUnique().SetCustomIndexName("title_1_true_unique"),
+			},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("events").Capped(2048).TTL(3600),
+			[]collection.Field{field.StringField("type")},
+			[]collection.Index{},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("sessions").SetCollation(map[string]interface{}{"locale": "fr"}),
+			[]collection.Field{field.StringField("token")},
+			[]collection.Index{},
+		),
+		collection.NewCollection(
+			metadata.InitMetadata("unmanaged"),
+			[]collection.Field{field.StringField("title")},
+			[]collection.Index{},
+		),
+	}
+
+	res := GetConsolidatedCollections(incoming, origin, fromDB)
+	test.AssertEqual(t, len(res), 4, "Dropped and unmanaged collections must be excluded")
+
+	// case 1: collection from migrations
+	users := res[0]
+	test.AssertEqual(t, users.Collection().Spec().Name, "users", "Case 1: Collection must be users")
+	test.AssertEqual(t, len(users.Fields()), 4, "Case 1: Unsampled field must be kept and new field must be added")
+	test.AssertEqual(t, users.Fields()[1].Spec().Name, "age", "Case 1: Unsampled field must be kept")
+	test.AssertEqual(t, len(*users.Fields()[2].Spec().Object), 2, "Case 1: Nested new field must be added")
+	test.AssertEqual(t, users.Fields()[3].Spec().Name, "score", "Case 1: New field must be added at the end")
+	test.AssertEqual(t, len(users.Indexes()), 2, "Case 1: Indexes must be taken from database")
+	test.AssertEqual(t, users.Indexes()[0], origin[0].Indexes()[0], "Case 1: Matching index must be kept from migration")
+	test.AssertEqual(t, users.Indexes()[1].Spec().Fields[0].Key, "score", "Case 1: New index must be added")
+	test.AssertEqual(t, len(*origin[0].Fields()[2].Spec().Object), 1, "Case 1: Origin fields must not be modified")
+
+	// case 2: user-defined collection only exists in database
+	products := res[3]
+	test.AssertEqual(t, products.Collection().Spec().Name, "products", "Case 2: Collection must be products")
+	test.AssertEqual(t, len(products.Fields()), 3, "Case 2: Declared fields must be kept and new field must be added")
+	test.AssertEqual(t, products.Fields()[0], incoming[1].Fields()[0], "Case 2: Declared field must keep its constraints and default")
+	test.AssertTrue(t, products.Fields()[1].Spec().Nullable, "Case 2: Unsampled declared field must be kept")
+	test.AssertEqual(t, products.Fields()[2].Spec().Name, "stock", "Case 2: Undeclared field must be taken from database")
+	test.AssertEqual(t, len(products.Indexes()), 1, "Case 2: Indexes must be taken from database")
+	test.AssertEqual(t, products.Indexes()[0], incoming[1].Indexes()[0], "Case 2: Matching index must be kept as declared")
+
+	// case 3: modifiable options are taken from database
+	events := res[1]
+	test.AssertEqual(t, events.Collection().Spec().Name, "events", "Case 3: Collection must be events")
+	test.AssertEqual(t, events.Collection(), fromDB[2].Collection(), "Case 3: Metadata must be taken from database")
+	case3Actions := GetActions([]collection.Collection{events}, []collection.Collection{origin[2]})
+	test.AssertEqual(t, len(case3Actions.First), 1, "Case 3: Up Actions length must be 1")
+	test.AssertEqual(t, len(case3Actions.First[0].SubActions), 1, "Case 3: Up Sub Actions length must be 1")
+	test.AssertEqual(t, case3Actions.First[0].SubActions[0].Type, si.SubActionTypeModifyCollection, "Case 3: The collection must be modified")
+
+	// case 4: options those cannot be modified are kept from migrations
+	sessions := res[2]
+	test.AssertEqual(t, sessions.Collection().Spec().Name, "sessions", "Case 4: Collection must be sessions")
+	test.AssertEqual(t, sessions.Collection(), origin[3].Collection(), "Case 4: Metadata must be kept from migrations")
+}
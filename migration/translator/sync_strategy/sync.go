@@ -10,9 +10,11 @@ package sync_strategy
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/index"
 	dt "github.com/amirkode/go-mongr8/internal/data_type"
 	"github.com/amirkode/go-mongr8/internal/test"
 	"github.com/amirkode/go-mongr8/internal/util"
@@ -83,7 +85,8 @@ As shown on above example, possible actions for each usecase:
 - New Field/Index: Will be added as plus-signed Action (Add)
 - Unused Field/Index: Will be added as negative-signed Action (Drop)
 - Field Type Conversion:
-  - Supported: Any to String, Numeric to Numeric (int to double, double to int, etc)
+  - Supported: Any to String (except binary), Numeric to Numeric (int to double, double to int, decimal, etc),
+    Date to Date (timestamp to date), ObjectID to Date, and String to ObjectID, Decimal, or Date
     perform update query, i.e:
 	db.collectionName.updateMany({}, [
        { $set: { "fieldName": { $toInt: "$fieldName" } } }
@@ -92,7 +95,7 @@ As shown on above example, possible actions for each usecase:
 	for other numerics to Int32 could produce error if
 	the previous integer value exceed the limit of int32
   - Unsupported: Date to Numeric, Timestamp to Numeric, etc
-  - Undefined: String to Any
+  - Undefined: String to other types
 - Index ordering reversal: drop previous index and followed adding new index
 
 User options:
@@ -219,6 +222,32 @@ func GetActions(incoming []collection.Collection, origin []collection.Collection
 		case si.SubActionTypeDropField:
 			upSubAction = si.SubActionDropField(schema)
 			downSubAction = si.SubActionCreateField(schema)
+		case si.SubActionTypeRenameField:
+			upSubAction = si.SubActionRenameField(schema)
+			// set down renaming, from the new name back to the previous name
+			downSchema := schema // assign new address
+			downSchema.Fields = []collection.Field{getReversedRenameField(signedCollection.Fields[0].Field)}
+			downSubAction = si.SubActionRenameField(downSchema)
+		case si.SubActionTypeModifyCollection:
+			convertFrom := signedCollection.Metadata.ConvertFrom().Metadata
+			// set up modification
+			schema.CollectionModifyFrom = convertFrom
+			upSubAction = si.SubActionModifyCollection(schema)
+			// set down modification, from the new options back to the previous options
+			downSchema := schema // assign new address
+			downSchema.Collection = convertFrom
+			downSchema.CollectionModifyFrom = signedCollection.Metadata
+			downSubAction = si.SubActionModifyCollection(downSchema)
+		case si.SubActionTypeModifyIndex:
+			convertFrom := signedCollection.Indexes[0].ConvertFrom().Index
+			// set up modification
+			schema.IndexModifyFrom = convertFrom
+			upSubAction = si.SubActionModifyIndex(schema)
+			// set down modification, from the new options back to the previous options
+			downSchema := schema // assign new address
+			downSchema.Indexes = []collection.Index{convertFrom}
+			downSchema.IndexModifyFrom = signedCollection.Indexes[0].Index
+			downSubAction = si.SubActionModifyIndex(downSchema)
 		}
 
 		// push sub actions
@@ -235,13 +264,19 @@ func GetActions(incoming []collection.Collection, origin []collection.Collection
 	for _, signedCollection := range signedCollections {
 		if signedCollection.IsIntersection {
 			// intersection means that there a change in the collection members
-			// it's either a field or index change
+			// it's either a field, index, or metadata options change
 			// for now, those changes splitted into sub-actions
 			// suppose we add two fields:
 			// - field1
 			// - field2
 			// those will be added in two `signedCollections`
 			// that also applies to indexes
+
+			// modified metadata options has no field and index
+			if signedCollection.Metadata.Sign == SignConvert {
+				fillActionMap(signedCollection, si.SubActionTypeModifyCollection)
+				continue
+			}
 			
 			test.Assert(len(signedCollection.Fields) > 0 || len(signedCollection.Indexes) > 0,
 				"Empty fields and indexes on signed collection", signedCollection.Key())
@@ -261,6 +296,9 @@ func GetActions(incoming []collection.Collection, origin []collection.Collection
 				} else if signedField.Sign == SignMinus {
 					// drop
 					fillActionMap(signedCollection, si.SubActionTypeDropField)
+				} else if signedField.Sign == SignRename {
+					// rename
+					fillActionMap(signedCollection, si.SubActionTypeRenameField)
 				} else {
 					// convert
 					fillActionMap(signedCollection, si.SubActionTypeConvertField)
@@ -271,6 +309,9 @@ func GetActions(incoming []collection.Collection, origin []collection.Collection
 				if signedIndex.Sign == SignPlus {
 					// create
 					fillActionMap(signedCollection, si.SubActionTypeCreateIndex)
+				} else if signedIndex.Sign == SignConvert {
+					// modify
+					fillActionMap(signedCollection, si.SubActionTypeModifyIndex)
 				} else {
 					// drop
 					fillActionMap(signedCollection, si.SubActionTypeDropIndex)
@@ -281,7 +322,7 @@ func GetActions(incoming []collection.Collection, origin []collection.Collection
 			fillActionMap(signedCollection, si.SubActionTypeCreateCollection)
 		} else {
 			// drop collection
-			fillActionMap(signedCollection, si.SubActionTypeCreateCollection)
+			fillActionMap(signedCollection, si.SubActionTypeDropCollection)
 		}
 	}
 
@@ -315,17 +356,213 @@ func GetActions(incoming []collection.Collection, origin []collection.Collection
 	}
 
 	// sort both up actions and down actions
+	// other sub actions refer to the new field names, so renames go first on Up
+	// and the last on Down in reversed order (nested renames before their parents)
+	// a rebuilt index is dropped after the new one is created, the index options conflict
+	// of an equivalent index on the same key is resolved on the execution, @see api_interpreter.createIndexes
 	for _, action := range upActionMap {
-		action.SubActions = sortSubActions(action.SubActions)
+		action.SubActions = moveRenameSubActions(moveRebuiltIndexDrops(sortSubActions(action.SubActions)), true)
 		upActions = append(upActions, action)
 	}
 
 	for _, action := range downActionMap {
-		action.SubActions = sortSubActions(action.SubActions)
+		action.SubActions = moveRenameSubActions(moveRebuiltIndexDrops(sortSubActions(action.SubActions)), false)
 		downActions = append(downActions, action)
 	}
 
-	return dt.NewPair(upActions, downActions)
+	// views are created after their sources, and dropped before their sources
+	return dt.NewPair(
+		sortActionsByViewDependency(upActions, false),
+		sortActionsByViewDependency(downActions, true),
+	)
+}
+
+// This sorts actions by the action key, while the actions of a view are placed
+// after the actions of its source collection, or before them if `reversed`
+func sortActionsByViewDependency(actions []si.Action, reversed bool) []si.Action {
+	// map of view name and its source
+	sources := map[string]string{}
+	for _, action := range actions {
+		for _, subAction := range action.SubActions {
+			if viewOn := subAction.ActionSchema.Collection.Spec().GetViewOn(); viewOn != "" {
+				sources[action.ActionKey] = viewOn
+			}
+		}
+	}
+
+	// number of views between the collection and its root source
+	depth := func(key string) int {
+		res := 0
+		visited := map[string]bool{key: true}
+		for curr, ok := sources[key]; ok && !visited[curr]; curr, ok = sources[curr] {
+			visited[curr] = true
+			res++
+		}
+
+		return res
+	}
+
+	res := make([]si.Action, len(actions))
+	copy(res, actions)
+	sort.SliceStable(res, func(i, j int) bool {
+		depthI, depthJ := depth(res[i].ActionKey), depth(res[j].ActionKey)
+		if depthI != depthJ {
+			return (depthI < depthJ) != reversed
+		}
+
+		return res[i].ActionKey < res[j].ActionKey
+	})
+
+	return res
+}
+
+// This moves index drops after the index creations sharing the same base key,
+// so the index is never missing while it's being rebuilt with different options
+func moveRebuiltIndexDrops(subActions []si.SubAction) []si.SubAction {
+	created := map[string]bool{}
+	for _, subAction := range subActions {
+		if subAction.Type == si.SubActionTypeCreateIndex {
+			for _, idx := range subAction.ActionSchema.Indexes {
+				created[idx.Spec().GetBaseKey()] = true
+			}
+		}
+	}
+
+	rebuilt := []si.SubAction{}
+	others := []si.SubAction{}
+	for _, subAction := range subActions {
+		if subAction.Type == si.SubActionTypeDropIndex && len(subAction.ActionSchema.Indexes) > 0 &&
+			created[subAction.ActionSchema.Indexes[0].Spec().GetBaseKey()] {
+			rebuilt = append(rebuilt, subAction)
+		} else {
+			others = append(others, subAction)
+		}
+	}
+
+	return append(others, rebuilt...)
+}
+
+// This moves rename sub actions to the front in the same order,
+// or to the end in reversed order
+func moveRenameSubActions(subActions []si.SubAction, toFront bool) []si.SubAction {
+	renames := []si.SubAction{}
+	others := []si.SubAction{}
+	for _, subAction := range subActions {
+		if subAction.Type == si.SubActionTypeRenameField {
+			renames = append(renames, subAction)
+		} else {
+			others = append(others, subAction)
+		}
+	}
+
+	if toFront {
+		return append(renames, others...)
+	}
+
+	for i, j := 0, len(renames)-1; i < j; i, j = i+1, j-1 {
+		renames[i], renames[j] = renames[j], renames[i]
+	}
+
+	return append(others, renames...)
+}
+
+// This returns a copy of a renamed field path, renaming it back to the previous name
+func getReversedRenameField(f collection.Field) collection.Field {
+	spec := util.DeepCopy(*f.Spec())
+	res := field.FromFieldSpec(&spec)
+	path := si.GetRenamedFieldPath(res)
+	test.Assert(path != nil, "getReversedRenameField", "Renamed field is not found")
+
+	leaf := path[len(path)-1]
+	oldName := leaf.GetRenamedFrom()
+	leaf.Extra[field.ExtraRenamedFrom] = leaf.Name
+	leaf.Name = oldName
+
+	return res
+}
+
+// This returns a copy of fields with the renamed field of the path taking its new name
+// `path` is the one way path to the renamed field, @see si.GetRenamedFieldPath
+func getRenamedFields(fields []collection.Field, path []*field.Spec) []collection.Field {
+	test.Assert(len(path) > 0, "getRenamedFields", "Renamed field path must not be empty")
+
+	var renameSpecs func(specs []field.Spec, path []*field.Spec, isArrayItem bool) []field.Spec
+	renameSpecs = func(specs []field.Spec, path []*field.Spec, isArrayItem bool) []field.Spec {
+		res := make([]field.Spec, len(specs))
+		copy(res, specs)
+		head := path[0]
+		for i := range res {
+			if len(path) == 1 {
+				if res[i].Name == head.GetRenamedFrom() {
+					res[i].Name = head.Name
+				}
+
+				continue
+			}
+
+			// array items (and map values) are matched by position, since it's a one way path
+			if !isArrayItem && res[i].Name != head.Name {
+				continue
+			}
+
+			if (res[i].Type == field.TypeObject || res[i].Type == field.TypeOneOf) && res[i].Object != nil {
+				children := renameSpecs(*res[i].Object, path[1:], false)
+				res[i].Object = &children
+			} else if (res[i].Type == field.TypeArray || res[i].Type == field.TypeMap) && res[i].ArrayFields != nil {
+				items := renameSpecs(*res[i].ArrayFields, path[1:], true)
+				res[i].ArrayFields = &items
+			}
+		}
+
+		return res
+	}
+
+	specs := renameSpecs(collection.SpecsFromFields(fields), path, false)
+
+	return collection.FieldsFromSpecs(&specs)
+}
+
+// This returns a copy of indexes with the keys on the renamed field taking its new path
+// `path` is the one way path to the renamed field, @see si.GetRenamedFieldPath
+func getRenamedIndexes(indexes []collection.Index, path []*field.Spec) []collection.Index {
+	test.Assert(len(path) > 0, "getRenamedIndexes", "Renamed field path must not be empty")
+
+	segments := []string{}
+	for i, spec := range path {
+		// array items, map values, and one of variants are not part of the dot path
+		if i > 0 && util.InListEq(path[i-1].Type, []field.FieldType{field.TypeArray, field.TypeMap, field.TypeOneOf}) {
+			if i == len(path)-1 {
+				// the renamed field itself has no dot path
+				return indexes
+			}
+
+			continue
+		}
+
+		segments = append(segments, spec.Name)
+	}
+
+	newPath := strings.Join(segments, ".")
+	oldPath := strings.Join(append(segments[:len(segments)-1:len(segments)-1], path[len(path)-1].GetRenamedFrom()), ".")
+
+	res := []collection.Index{}
+	for _, currIndex := range indexes {
+		spec := *currIndex.Spec()
+		spec.Fields = make([]index.IndexField, len(currIndex.Spec().Fields))
+		copy(spec.Fields, currIndex.Spec().Fields)
+		for i := range spec.Fields {
+			if spec.Fields[i].Key == oldPath {
+				spec.Fields[i].Key = newPath
+			} else if strings.HasPrefix(spec.Fields[i].Key, oldPath+".") {
+				// nested fields and wildcard paths
+				spec.Fields[i].Key = newPath + strings.TrimPrefix(spec.Fields[i].Key, oldPath)
+			}
+		}
+
+		res = append(res, index.FromIndexSpec(&spec))
+	}
+
+	return res
 }
 
 // This constructs list of collection from a list of migrations
@@ -349,7 +586,7 @@ func GetCollectionFromMigrations(migrations []migrator.Migration) []collection.C
 				res = append(res, inc)
 			}
 
-			if org.Spec().Type == field.TypeArray {
+			if org.Spec().Type == field.TypeArray || org.Spec().Type == field.TypeMap {
 				merged := mergeFields(
 					collection.FieldsFromSpecs(org.Spec().ArrayFields),
 					collection.FieldsFromSpecs(inc.Spec().ArrayFields),
@@ -361,7 +598,7 @@ func GetCollectionFromMigrations(migrations []migrator.Migration) []collection.C
 					org.Spec().ArrayFields = &arrayFields
 					res = append(res, org)
 				}
-			} else if org.Spec().Type == field.TypeObject {
+			} else if org.Spec().Type == field.TypeObject || org.Spec().Type == field.TypeOneOf {
 				merged := mergeFields(
 					collection.FieldsFromSpecs(org.Spec().Object),
 					collection.FieldsFromSpecs(inc.Spec().Object),
@@ -423,8 +660,40 @@ func GetCollectionFromMigrations(migrations []migrator.Migration) []collection.C
 	}
 	mergeToCollections := func(subAction *si.SubAction, migrationID string) {
 		collectionName := subAction.ActionSchema.Collection.Spec().Name
+		// validator does not change the schema, @see GetValidatorActions
+		if util.InListEq(subAction.Type, []si.SubActionType{
+			si.SubActionTypeSetValidator,
+			si.SubActionTypeUnsetValidator,
+		}) {
+			return
+		}
+
 		coll, ok := collections[collectionName]
-		if subAction.Type == si.SubActionTypeCreateCollection {
+		if subAction.Type == si.SubActionTypeModifyIndex && ok {
+			// replace the previous index with the modified one
+			newIndexes := []collection.Index{}
+			for _, currIndex := range coll.Indexes() {
+				if currIndex.Spec().GetKey() == subAction.ActionSchema.IndexModifyFrom.Spec().GetKey() {
+					newIndexes = append(newIndexes, subAction.ActionSchema.Indexes[0])
+				} else {
+					newIndexes = append(newIndexes, currIndex)
+				}
+			}
+
+			collections[collectionName] = collection.NewCollection(
+				subAction.ActionSchema.Collection,
+				coll.Fields(),
+				newIndexes,
+			)
+		} else if subAction.Type == si.SubActionTypeRenameField && ok {
+			// indexes on the renamed field follow its new path
+			renamedPath := si.GetRenamedFieldPath(subAction.ActionSchema.Fields[0])
+			collections[collectionName] = collection.NewCollection(
+				subAction.ActionSchema.Collection,
+				getRenamedFields(coll.Fields(), renamedPath),
+				getRenamedIndexes(coll.Indexes(), renamedPath),
+			)
+		} else if subAction.Type == si.SubActionTypeCreateCollection {
 			// add new collection
 			collections[collectionName] = collection.NewCollection(
 				subAction.ActionSchema.Collection,
@@ -9,6 +9,7 @@ package sync_strategy
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/amirkode/go-mongr8/internal/test"
@@ -254,6 +255,16 @@ func TestGetActions(t *testing.T) {
 
 	test.AssertTrue(t, collectionsAreEqual(case2ExpectedPayloadAsCollection, case2ActualPayloadAsCollection), "Case 2: Unexpected Action Payload")
 
+	// Case 3: Drop collection
+	case3Actions := GetActions([]collection.Collection{}, case2Origin)
+
+	test.AssertEqual(t, len(case3Actions.First), 1, "Case 3: Up Actions length must be 1")
+	test.AssertEqual(t, len(case3Actions.Second), 1, "Case 3: Down Actions length must be 1")
+	test.AssertEqual(t, len(case3Actions.First[0].SubActions), 1, "Case 3: Unexpected Up Sub Actions length")
+	test.AssertEqual(t, case3Actions.First[0].SubActions[0].Type, si.SubActionTypeDropCollection, "Case 3: The collection must be dropped on Up")
+	test.AssertEqual(t, len(case3Actions.Second[0].SubActions), 1, "Case 3: Unexpected Down Sub Actions length")
+	test.AssertEqual(t, case3Actions.Second[0].SubActions[0].Type, si.SubActionTypeCreateCollection, "Case 3: The collection must be created again on Down")
+
 	// TODO: Add more cases
 }
 
@@ -561,5 +572,664 @@ func TestGetCollectionFromMigrations(t *testing.T) {
 		test.AssertTrue(t, collectionsAreEqual(collection, compCollection), fmt.Sprintf("Case 2: Unexpected Collection %s", collection.Collection().Spec().Name))
 	}
 
+	// Case 3: migrations with rename field action inside an array of object
+	case3Migrations := []migrator.Migration{
+		{
+			ID:   "1",
+			Desc: "a description",
+			Up: []si.Action{
+				{
+					ActionKey: "customers",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateCollection(si.SubActionSchema{
+							Collection: metadata.InitMetadata("customers"),
+							Fields: []collection.Field{
+								field.StringField("name"),
+								field.ArrayField("tx_history",
+									field.ObjectField("",
+										field.StringField("desc"),
+									),
+								),
+							},
+							Indexes: []collection.Index{},
+						}),
+					},
+				},
+			},
+		},
+		{
+			ID:   "2",
+			Desc: "a description",
+			Up: []si.Action{
+				{
+					ActionKey: "customers",
+					SubActions: []si.SubAction{
+						*si.SubActionRenameField(si.SubActionSchema{
+							Collection: metadata.InitMetadata("customers"),
+							Fields: []collection.Field{
+								field.ArrayField("tx_history",
+									field.ObjectField("",
+										field.StringField("description").RenamedFrom("desc"),
+									),
+								),
+							},
+						}),
+					},
+				},
+			},
+		},
+	}
+	case3Collections := GetCollectionFromMigrations(case3Migrations)
+	case3Expected := collection.NewCollection(
+		metadata.InitMetadata("customers"),
+		[]collection.Field{
+			field.StringField("name"),
+			field.ArrayField("tx_history",
+				field.ObjectField("",
+					field.StringField("description"),
+				),
+			),
+		},
+		[]collection.Index{},
+	)
+
+	test.AssertEqual(t, len(case3Collections), 1, "Case 3: The collections length must be 1")
+	test.AssertTrue(t, collectionsAreEqual(case3Collections[0], case3Expected), "Case 3: Unexpected Collection customers")
+
 	// TODO: add more cases
 }
+
+func TestGetActionsWithRenamedField(t *testing.T) {
+	// Case 1: rename top level field
+	case1Incoming := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("full_name").RenamedFrom("name"),
+				field.Int32Field("age"),
+			},
+			[]collection.Index{},
+		),
+	}
+	case1Origin := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("name"),
+				field.Int32Field("age"),
+			},
+			[]collection.Index{},
+		),
+	}
+	case1Actions := GetActions(case1Incoming, case1Origin)
+
+	test.AssertEqual(t, len(case1Actions.First), 1, "Case 1: Up Actions length must be 1")
+	test.AssertEqual(t, len(case1Actions.First[0].SubActions), 1, "Case 1: Up Sub Actions length must be 1")
+	case1Up := case1Actions.First[0].SubActions[0]
+	test.AssertEqual(t, case1Up.Type, si.SubActionTypeRenameField, "Case 1: Up Sub Action must be SubActionTypeRenameField")
+	test.AssertEqual(t, case1Up.ActionSchema.Fields[0].Spec().Name, "full_name", "Case 1: Unexpected Up renamed field")
+	test.AssertEqual(t, case1Up.ActionSchema.Fields[0].Spec().GetRenamedFrom(), "name", "Case 1: Unexpected Up previous name")
+	case1Down := case1Actions.Second[0].SubActions[0]
+	test.AssertEqual(t, case1Down.Type, si.SubActionTypeRenameField, "Case 1: Down Sub Action must be SubActionTypeRenameField")
+	test.AssertEqual(t, case1Down.ActionSchema.Fields[0].Spec().Name, "name", "Case 1: Unexpected Down renamed field")
+	test.AssertEqual(t, case1Down.ActionSchema.Fields[0].Spec().GetRenamedFrom(), "full_name", "Case 1: Unexpected Down previous name")
+
+	// Case 2: rename nested field with a conversion inside the renamed field
+	case2Incoming := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.ObjectField("other_info",
+					field.ObjectField("address",
+						field.StringField("zip_code"),
+					).RenamedFrom("location"),
+				),
+			},
+			[]collection.Index{},
+		),
+	}
+	case2Origin := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.ObjectField("other_info",
+					field.ObjectField("location",
+						field.Int32Field("zip_code"),
+					),
+				),
+			},
+			[]collection.Index{},
+		),
+	}
+	case2Actions := GetActions(case2Incoming, case2Origin)
+
+	test.AssertEqual(t, len(case2Actions.First[0].SubActions), 2, "Case 2: Up Sub Actions length must be 2")
+	// rename goes first on up
+	case2Up := case2Actions.First[0].SubActions
+	test.AssertEqual(t, case2Up[0].Type, si.SubActionTypeRenameField, "Case 2: First Up Sub Action must be SubActionTypeRenameField")
+	test.AssertEqual(t, case2Up[1].Type, si.SubActionTypeConvertField, "Case 2: Second Up Sub Action must be SubActionTypeConvertField")
+	case2UpPath := si.GetRenamedFieldPath(case2Up[0].ActionSchema.Fields[0])
+	test.AssertEqual(t, len(case2UpPath), 2, "Case 2: Unexpected Up renamed path length")
+	test.AssertEqual(t, case2UpPath[1].Name, "address", "Case 2: Unexpected Up renamed field")
+	test.AssertEqual(t, (*(*case2Up[1].ActionSchema.Fields[0].Spec().Object)[0].Object)[0].Name, "zip_code", "Case 2: Conversion must refer to the new path")
+	test.AssertEqual(t, (*case2Up[1].ActionSchema.Fields[0].Spec().Object)[0].Name, "address", "Case 2: Conversion must refer to the new path")
+	// rename goes last on down
+	case2Down := case2Actions.Second[0].SubActions
+	test.AssertEqual(t, case2Down[0].Type, si.SubActionTypeConvertField, "Case 2: First Down Sub Action must be SubActionTypeConvertField")
+	test.AssertEqual(t, case2Down[1].Type, si.SubActionTypeRenameField, "Case 2: Second Down Sub Action must be SubActionTypeRenameField")
+	case2DownPath := si.GetRenamedFieldPath(case2Down[1].ActionSchema.Fields[0])
+	test.AssertEqual(t, case2DownPath[1].Name, "location", "Case 2: Unexpected Down renamed field")
+	test.AssertEqual(t, case2DownPath[1].GetRenamedFrom(), "address", "Case 2: Unexpected Down previous name")
+
+	// Case 3: already renamed on the previous migration, nothing changes
+	case3Origin := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("full_name"),
+				field.Int32Field("age"),
+			},
+			[]collection.Index{},
+		),
+	}
+	case3Actions := GetActions(case1Incoming, case3Origin)
+
+	test.AssertEqual(t, len(case3Actions.First), 0, "Case 3: Up Actions must be empty")
+	test.AssertEqual(t, len(case3Actions.Second), 0, "Case 3: Down Actions must be empty")
+
+	// Case 4: indexes on the renamed fields follow the new path in the migrations state
+	case4Origin := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("name"),
+				field.ObjectField("other_info",
+					field.ObjectField("location",
+						field.StringField("zip_code"),
+					),
+				),
+			},
+			[]collection.Index{
+				index.CompoundIndex(index.Field("name", 1), index.Field("other_info.location.zip_code", -1)),
+				index.WildcardIndex("other_info.location"),
+				index.SingleFieldIndex(index.Field("name_prefix", 1)),
+			},
+		),
+	}
+	case4Incoming := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("full_name").RenamedFrom("name"),
+				field.ObjectField("other_info",
+					field.ObjectField("address",
+						field.StringField("zip_code"),
+					).RenamedFrom("location"),
+				),
+			},
+			case4Origin[0].Indexes(),
+		),
+	}
+	case4Migrations := []migrator.Migration{
+		{ID: "1", Up: GetActions(case4Origin, []collection.Collection{}).First},
+		{ID: "2", Up: GetActions(case4Incoming, case4Origin).First},
+	}
+	case4Collections := GetCollectionFromMigrations(case4Migrations)
+
+	test.AssertEqual(t, len(case4Collections), 1, "Case 4: Collections length must be 1")
+	case4Keys := []string{}
+	for _, currIndex := range case4Collections[0].Indexes() {
+		for _, indexField := range currIndex.Spec().Fields {
+			case4Keys = append(case4Keys, indexField.Key)
+		}
+	}
+	test.AssertTrue(t, reflect.DeepEqual(case4Keys, []string{"full_name", "other_info.address.zip_code", "other_info.address.$**", "name_prefix"}),
+		fmt.Sprintf("Case 4: Unexpected index keys %v", case4Keys))
+	// the previous migration state is not modified
+	test.AssertEqual(t, case4Origin[0].Indexes()[0].Spec().Fields[0].Key, "name", "Case 4: Origin index must not be modified")
+
+	case4Current := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("users"),
+			[]collection.Field{
+				field.StringField("full_name"),
+				field.ObjectField("other_info",
+					field.ObjectField("address",
+						field.StringField("zip_code"),
+					),
+				),
+			},
+			[]collection.Index{
+				index.CompoundIndex(index.Field("full_name", 1), index.Field("other_info.address.zip_code", -1)),
+				index.WildcardIndex("other_info.address"),
+				index.SingleFieldIndex(index.Field("name_prefix", 1)),
+			},
+		),
+	}
+	case4Actions := GetActions(case4Current, case4Collections)
+
+	test.AssertEqual(t, len(case4Actions.First), 0, "Case 4: Up Actions must be empty")
+	test.AssertEqual(t, len(case4Actions.Second), 0, "Case 4: Down Actions must be empty")
+}
+
+func TestGetActionsWithMapField(t *testing.T) {
+	mapCollections := func(value *field.FieldSpec) []collection.Collection {
+		return []collection.Collection{
+			collection.NewCollection(
+				metadata.InitMetadata("products"),
+				[]collection.Field{
+					field.MapField("attributes", value),
+				},
+				[]collection.Index{},
+			),
+		}
+	}
+
+	// Case 1: map value conversion
+	case1Actions := GetActions(mapCollections(field.StringField("")), mapCollections(field.Int32Field("")))
+
+	test.AssertEqual(t, len(case1Actions.First[0].SubActions), 1, "Case 1: Up Sub Actions length must be 1")
+	case1Up := case1Actions.First[0].SubActions[0]
+	test.AssertEqual(t, case1Up.Type, si.SubActionTypeConvertField, "Case 1: Up Sub Action must be SubActionTypeConvertField")
+	test.AssertEqual(t, case1Up.ActionSchema.Fields[0].Spec().GetMapValue().Type, field.TypeString, "Case 1: Unexpected Up map value type")
+	case1Down := case1Actions.Second[0].SubActions[0]
+	test.AssertEqual(t, case1Down.Type, si.SubActionTypeConvertField, "Case 1: Down Sub Action must be SubActionTypeConvertField")
+	test.AssertEqual(t, case1Down.ActionSchema.Fields[0].Spec().GetMapValue().Type, field.TypeInt32, "Case 1: Unexpected Down map value type")
+
+	// Case 2: field added into the map values
+	case2Actions := GetActions(
+		mapCollections(field.ObjectField("", field.StringField("label"), field.Int32Field("rank"))),
+		mapCollections(field.ObjectField("", field.StringField("label"))),
+	)
+
+	test.AssertEqual(t, len(case2Actions.First[0].SubActions), 1, "Case 2: Up Sub Actions length must be 1")
+	case2Up := case2Actions.First[0].SubActions[0]
+	test.AssertEqual(t, case2Up.Type, si.SubActionTypeCreateField, "Case 2: Up Sub Action must be SubActionTypeCreateField")
+	test.AssertEqual(t, (*case2Up.ActionSchema.Fields[0].Spec().GetMapValue().Object)[0].Name, "rank", "Case 2: Unexpected Up created field")
+	test.AssertEqual(t, case2Actions.Second[0].SubActions[0].Type, si.SubActionTypeDropField, "Case 2: Down Sub Action must be SubActionTypeDropField")
+
+	// Case 3: nothing changes
+	case3Actions := GetActions(mapCollections(field.StringField("")), mapCollections(field.StringField("")))
+
+	test.AssertEqual(t, len(case3Actions.First), 0, "Case 3: Up Actions must be empty")
+	test.AssertEqual(t, len(case3Actions.Second), 0, "Case 3: Down Actions must be empty")
+}
+
+func TestGetActionsWithOneOfField(t *testing.T) {
+	oneOfCollections := func(f collection.Field) []collection.Collection {
+		return []collection.Collection{
+			collection.NewCollection(
+				metadata.InitMetadata("events"),
+				[]collection.Field{f},
+				[]collection.Index{},
+			),
+		}
+	}
+	origin := field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"click": field.ObjectField("", field.Int32Field("x")),
+		"view":  field.ObjectField("", field.StringField("page")),
+	})
+	incoming := field.OneOf("payload", "kind", map[string]*field.FieldSpec{
+		"click":  field.ObjectField("", field.Int64Field("x"), field.StringField("button")),
+		"scroll": field.ObjectField("", field.DoubleField("offset")),
+	})
+
+	// Case 1: each variant is compared separately
+	case1Actions := GetActions(oneOfCollections(incoming), oneOfCollections(origin))
+	case1Variants := map[si.SubActionType][]string{}
+	for _, subAction := range case1Actions.First[0].SubActions {
+		spec := subAction.ActionSchema.Fields[0].Spec()
+		test.AssertEqual(t, spec.GetDiscriminator(), "kind", "Case 1: Discriminator must be kept")
+		case1Variants[subAction.Type] = append(case1Variants[subAction.Type], (*spec.Object)[0].Name)
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case1Variants, map[si.SubActionType][]string{
+		si.SubActionTypeDropField:    {"view"},
+		si.SubActionTypeCreateField:  {"click", "scroll"},
+		si.SubActionTypeConvertField: {"click"},
+	}), "Case 1: Unexpected Up Sub Actions")
+
+	// Case 2: the one of is reconstructed from the migrations
+	case2Migrations := []migrator.Migration{
+		{
+			ID: "1",
+			Up: []si.Action{
+				{
+					ActionKey: "events",
+					SubActions: []si.SubAction{
+						{
+							Type: si.SubActionTypeCreateCollection,
+							ActionSchema: si.SubActionSchema{
+								Collection: metadata.InitMetadata("events"),
+								Fields:     []collection.Field{origin},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ID: "2",
+			Up: case1Actions.First,
+		},
+	}
+	case2Collections := GetCollectionFromMigrations(case2Migrations)
+
+	test.AssertTrue(t, fieldsAreEqual(case2Collections[0].Fields()[0], incoming), "Case 2: Unexpected reconstructed field")
+	test.AssertEqual(t, case2Collections[0].Fields()[0].Spec().GetDiscriminator(), "kind", "Case 2: Discriminator must be kept")
+}
+
+type testAddressSchema struct {
+	withZipCode bool
+}
+
+func (testAddressSchema) Name() string {
+	return "address"
+}
+
+func (s testAddressSchema) Fields() []collection.Field {
+	fields := []collection.Field{
+		field.StringField("street"),
+		field.StringField("city"),
+	}
+	if s.withZipCode {
+		fields = append(fields, field.StringField("zip_code"))
+	}
+
+	return fields
+}
+
+func TestGetActionsWithEmbeddedSchema(t *testing.T) {
+	embeddingCollections := func(schema collection.EmbeddedSchema) []collection.Collection {
+		return []collection.Collection{
+			collection.NewCollection(
+				metadata.InitMetadata("users"),
+				[]collection.Field{
+					field.StringField("name"),
+					collection.EmbeddedField("address", schema),
+				},
+				[]collection.Index{},
+			),
+			collection.NewCollection(
+				metadata.InitMetadata("stores"),
+				[]collection.Field{
+					field.ArrayField("branches",
+						field.ObjectField("",
+							collection.EmbeddedField("location", schema),
+						),
+					),
+				},
+				[]collection.Index{},
+			),
+		}
+	}
+
+	// Case 1: each embedding has its own copy of the fields
+	case1Collections := embeddingCollections(testAddressSchema{})
+	case1Address := case1Collections[0].Fields()[1].Spec()
+	(*case1Address.Object)[0].Name = "line_1"
+	case1Location := (*(*case1Collections[1].Fields()[0].Spec().ArrayFields)[0].Object)[0]
+
+	test.AssertEqual(t, case1Location.Type, field.TypeObject, "Case 1: Embedded field must be an object")
+	test.AssertEqual(t, (*case1Location.Object)[0].Name, "street", "Case 1: Embedded fields must not be shared")
+
+	// Case 2: a change of the schema applies to every embedding collection
+	case2Actions := GetActions(embeddingCollections(testAddressSchema{withZipCode: true}), embeddingCollections(testAddressSchema{}))
+	case2ActionKeys := []string{}
+	for _, action := range case2Actions.First {
+		case2ActionKeys = append(case2ActionKeys, action.ActionKey)
+		test.AssertEqual(t, len(action.SubActions), 1, "Case 2: Up Sub Actions length must be 1")
+		test.AssertEqual(t, action.SubActions[0].Type, si.SubActionTypeCreateField, "Case 2: Up Sub Action must be SubActionTypeCreateField")
+	}
+
+	test.AssertTrue(t, reflect.DeepEqual(case2ActionKeys, []string{"stores", "users"}), "Case 2: Unexpected Up Action keys")
+	test.AssertEqual(t, len(case2Actions.Second), 2, "Case 2: Down Actions length must be 2")
+
+	// Case 3: nothing changes
+	case3Actions := GetActions(embeddingCollections(testAddressSchema{}), embeddingCollections(testAddressSchema{}))
+
+	test.AssertEqual(t, len(case3Actions.First), 0, "Case 3: Up Actions must be empty")
+	test.AssertEqual(t, len(case3Actions.Second), 0, "Case 3: Down Actions must be empty")
+}
+
+func TestGetActionsWithView(t *testing.T) {
+	activeUsers := func(status string) collection.Collection {
+		return collection.NewCollection(
+			metadata.InitMetadata("active_users").ViewOn("users", []map[string]interface{}{
+				{"$match": map[string]interface{}{"status": status}},
+			}),
+			[]collection.Field{
+				field.StringField("name"),
+			},
+			[]collection.Index{},
+		)
+	}
+	users := collection.NewCollection(
+		metadata.InitMetadata("users"),
+		[]collection.Field{
+			field.StringField("name"),
+			field.StringField("status"),
+		},
+		[]collection.Index{},
+	)
+
+	// Case 1: create views along with their source collection
+	case1Incoming := []collection.Collection{
+		collection.NewCollection(
+			metadata.InitMetadata("active_user_names").ViewOn("active_users", nil),
+			[]collection.Field{},
+			[]collection.Index{},
+		),
+		activeUsers("active"),
+		users,
+	}
+	case1Actions := GetActions(case1Incoming, []collection.Collection{})
+
+	test.AssertEqual(t, len(case1Actions.First), 3, "Case 1: Up Actions length must be 3")
+	// views are created after their sources
+	test.AssertEqual(t, case1Actions.First[0].ActionKey, "users", "Case 1: Unexpected first Up Action")
+	test.AssertEqual(t, case1Actions.First[1].ActionKey, "active_users", "Case 1: Unexpected second Up Action")
+	test.AssertEqual(t, case1Actions.First[2].ActionKey, "active_user_names", "Case 1: Unexpected third Up Action")
+	// views are dropped before their sources
+	test.AssertEqual(t, case1Actions.Second[0].ActionKey, "active_user_names", "Case 1: Unexpected first Down Action")
+	test.AssertEqual(t, case1Actions.Second[1].ActionKey, "active_users", "Case 1: Unexpected second Down Action")
+	test.AssertEqual(t, case1Actions.Second[2].ActionKey, "users", "Case 1: Unexpected third Down Action")
+
+	// Case 2: pipeline changes, the view is recreated
+	case2Actions := GetActions(
+		[]collection.Collection{users, activeUsers("verified")},
+		[]collection.Collection{users, activeUsers("active")},
+	)
+
+	test.AssertEqual(t, len(case2Actions.First), 1, "Case 2: Up Actions length must be 1")
+	case2Up := case2Actions.First[0].SubActions
+	test.AssertEqual(t, len(case2Up), 2, "Case 2: Up Sub Actions length must be 2")
+	test.AssertEqual(t, case2Up[0].Type, si.SubActionTypeDropCollection, "Case 2: The previous view must be dropped first")
+	test.AssertEqual(t, case2Up[1].Type, si.SubActionTypeCreateCollection, "Case 2: The new view must be created")
+	test.AssertEqual(t, case2Up[1].ActionSchema.Collection.Spec().GetPipeline()[0]["$match"].(map[string]interface{})["status"], "verified", "Case 2: Unexpected Up pipeline")
+	case2Down := case2Actions.Second[0].SubActions
+	test.AssertEqual(t, case2Down[0].Type, si.SubActionTypeDropCollection, "Case 2: The new view must be dropped first on Down")
+	test.AssertEqual(t, case2Down[1].ActionSchema.Collection.Spec().GetPipeline()[0]["$match"].(map[string]interface{})["status"], "active", "Case 2: Unexpected Down pipeline")
+
+	// Case 3: no changes
+	case3Actions := GetActions(
+		[]collection.Collection{users, activeUsers("active")},
+		[]collection.Collection{users, activeUsers("active")},
+	)
+
+	test.AssertEqual(t, len(case3Actions.First), 0, "Case 3: Up Actions must be empty")
+}
+
+func TestGetActionsWithModifiedCollection(t *testing.T) {
+	logs := func(meta collection.Metadata) collection.Collection {
+		return collection.NewCollection(
+			meta,
+			[]collection.Field{
+				field.StringField("message"),
+			},
+			[]collection.Index{},
+		)
+	}
+
+	// Case 1: capped size and TTL changes
+	case1Actions := GetActions(
+		[]collection.Collection{logs(metadata.InitMetadata("logs").Capped(2048).TTL(120))},
+		[]collection.Collection{logs(metadata.InitMetadata("logs").Capped(1024).TTL(60))},
+	)
+
+	test.AssertEqual(t, len(case1Actions.First), 1, "Case 1: Up Actions length must be 1")
+	case1Up := case1Actions.First[0].SubActions
+	test.AssertEqual(t, len(case1Up), 1, "Case 1: Up Sub Actions length must be 1")
+	test.AssertEqual(t, case1Up[0].Type, si.SubActionTypeModifyCollection, "Case 1: Unexpected Up Sub Action type")
+	test.AssertEqual(t, (*case1Up[0].ActionSchema.Collection.Spec().Options)[metadata.CollectionOptionCappedSize], int64(2048), "Case 1: Unexpected Up capped size")
+	test.AssertEqual(t, (*case1Up[0].ActionSchema.CollectionModifyFrom.Spec().Options)[metadata.CollectionOptionCappedSize], int64(1024), "Case 1: Unexpected Up capped size modified from")
+	case1Down := case1Actions.Second[0].SubActions
+	test.AssertEqual(t, case1Down[0].Type, si.SubActionTypeModifyCollection, "Case 1: Unexpected Down Sub Action type")
+	test.AssertEqual(t, (*case1Down[0].ActionSchema.Collection.Spec().Options)[metadata.CollectionOptionCappedSize], int64(1024), "Case 1: Unexpected Down capped size")
+	test.AssertEqual(t, (*case1Down[0].ActionSchema.CollectionModifyFrom.Spec().Options)[metadata.CollectionOptionCappedSize], int64(2048), "Case 1: Unexpected Down capped size modified from")
+
+	// Case 2: the modified metadata is the new state of migrations
+	case2Migrations := []migrator.Migration{
+		{
+			ID: "20231010_000000",
+			Up: []si.Action{
+				{
+					ActionKey: "logs",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateCollection(si.SubActionSchema{
+							Collection: metadata.InitMetadata("logs").Capped(1024),
+							Fields:     []collection.Field{field.StringField("message")},
+						}),
+					},
+				},
+			},
+		},
+		{
+			ID: "20231011_000000",
+			Up: []si.Action{
+				{
+					ActionKey:  "logs",
+					SubActions: case1Up,
+				},
+			},
+		},
+	}
+	case2Collections := GetCollectionFromMigrations(case2Migrations)
+
+	test.AssertEqual(t, len(case2Collections), 1, "Case 2: Collections length must be 1")
+	test.AssertEqual(t, (*case2Collections[0].Collection().Spec().Options)[metadata.CollectionOptionCappedSize], int64(2048), "Case 2: Unexpected capped size")
+	test.AssertEqual(t, len(case2Collections[0].Fields()), 1, "Case 2: Fields must be kept")
+
+	// Case 3: no changes
+	case3Actions := GetActions(
+		[]collection.Collection{logs(metadata.InitMetadata("logs").Capped(1024))},
+		[]collection.Collection{logs(metadata.InitMetadata("logs").Capped(1024))},
+	)
+
+	test.AssertEqual(t, len(case3Actions.First), 0, "Case 3: Up Actions must be empty")
+}
+
+func TestGetActionsWithModifiedIndex(t *testing.T) {
+	logs := func(indexes ...collection.Index) collection.Collection {
+		return collection.NewCollection(
+			metadata.InitMetadata("logs"),
+			[]collection.Field{
+				field.StringField("message"),
+				field.TimestampField("created_at"),
+			},
+			indexes,
+		)
+	}
+
+	// Case 1: only modifiable options change, the index is modified in place
+	case1From := index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(60)
+	case1Actions := GetActions(
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(120).AsHidden())},
+		[]collection.Collection{logs(case1From)},
+	)
+
+	test.AssertEqual(t, len(case1Actions.First), 1, "Case 1: Up Actions length must be 1")
+	case1Up := case1Actions.First[0].SubActions
+	test.AssertEqual(t, len(case1Up), 1, "Case 1: Up Sub Actions length must be 1")
+	test.AssertEqual(t, case1Up[0].Type, si.SubActionTypeModifyIndex, "Case 1: Unexpected Up Sub Action type")
+	test.AssertEqual(t, case1Up[0].ActionSchema.Indexes[0].Spec().GetName(), case1From.Spec().GetName(), "Case 1: The modified index must keep its previous name")
+	test.AssertEqual(t, case1Up[0].ActionSchema.IndexModifyFrom.Spec().GetKey(), case1From.Spec().GetKey(), "Case 1: Unexpected Up index modified from")
+	case1Down := case1Actions.Second[0].SubActions
+	test.AssertEqual(t, case1Down[0].Type, si.SubActionTypeModifyIndex, "Case 1: Unexpected Down Sub Action type")
+	test.AssertEqual(t, case1Down[0].ActionSchema.Indexes[0].Spec().GetKey(), case1From.Spec().GetKey(), "Case 1: Unexpected Down index")
+
+	// Case 2: the modified index replaces the previous one in migrations
+	case2Migrations := []migrator.Migration{
+		{
+			ID: "20231010_000000",
+			Up: []si.Action{
+				{
+					ActionKey: "logs",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateCollection(si.SubActionSchema{
+							Collection: metadata.InitMetadata("logs"),
+							Fields:     logs().Fields(),
+							Indexes:    []collection.Index{case1From},
+						}),
+					},
+				},
+			},
+		},
+		{
+			ID: "20231011_000000",
+			Up: []si.Action{
+				{
+					ActionKey:  "logs",
+					SubActions: case1Up,
+				},
+			},
+		},
+	}
+	case2Collections := GetCollectionFromMigrations(case2Migrations)
+
+	test.AssertEqual(t, len(case2Collections[0].Indexes()), 1, "Case 2: Indexes length must be 1")
+	test.AssertTrue(t, case2Collections[0].Indexes()[0].Spec().HasRule(index.OptionHidden), "Case 2: The index must be modified")
+	// no more changes after the modification
+	case2Actions := GetActions(
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("created_at", 1)).SetTTL(120).AsHidden())},
+		case2Collections,
+	)
+
+	test.AssertEqual(t, len(case2Actions.First), 0, "Case 2: Up Actions must be empty")
+
+	// Case 3: a rebuild creates the new index before dropping the previous one,
+	// both ways between non-sparse and sparse
+	case3Actions := GetActions(
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("message", 1)).AsSparse())},
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("message", 1)))},
+	)
+
+	// Case 4: sparse to non-sparse
+	case4Actions := GetActions(
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("message", 1)))},
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("message", 1)).AsSparse())},
+	)
+
+	for _, actions := range [][]si.Action{case3Actions.First, case3Actions.Second, case4Actions.First, case4Actions.Second} {
+		subActions := actions[0].SubActions
+		test.AssertEqual(t, len(subActions), 2, "Case 3: Sub Actions length must be 2")
+		test.AssertEqual(t, subActions[0].Type, si.SubActionTypeCreateIndex, "Case 3: The new index must be created first")
+		test.AssertEqual(t, subActions[1].Type, si.SubActionTypeDropIndex, "Case 3: The previous index must be dropped last")
+		test.AssertEqual(t, subActions[0].ActionSchema.Indexes[0].Spec().GetBaseKey(), subActions[1].ActionSchema.Indexes[0].Spec().GetBaseKey(), "Case 3: Both indexes must be on the same key")
+	}
+
+	case4Up := case4Actions.First[0].SubActions
+	test.AssertTrue(t, !case4Up[0].ActionSchema.Indexes[0].Spec().HasRule(index.OptionSparse), "Case 4: The non-sparse index must be created")
+	test.AssertTrue(t, case4Up[1].ActionSchema.Indexes[0].Spec().HasRule(index.OptionSparse), "Case 4: The sparse index must be dropped")
+
+	// Case 5: indexes on the same key with different collations can coexist, the new one is created first
+	case5Actions := GetActions(
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("message", 1)).SetCollation(map[string]interface{}{"locale": "fr"}))},
+		[]collection.Collection{logs(index.SingleFieldIndex(index.Field("message", 1)).SetCollation(map[string]interface{}{"locale": "en"}))},
+	)
+
+	case5Up := case5Actions.First[0].SubActions
+	test.AssertEqual(t, len(case5Up), 2, "Case 5: Up Sub Actions length must be 2")
+	test.AssertEqual(t, case5Up[0].Type, si.SubActionTypeCreateIndex, "Case 5: The new index must be created first")
+	test.AssertEqual(t, case5Up[1].Type, si.SubActionTypeDropIndex, "Case 5: The previous index must be dropped last")
+}
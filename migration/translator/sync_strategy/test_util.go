@@ -9,6 +9,7 @@ package sync_strategy
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/amirkode/go-mongr8/collection"
 	"github.com/amirkode/go-mongr8/collection/field"
@@ -21,8 +22,8 @@ func fieldsAreEqual(a, b collection.Field) bool {
 		fmt.Printf("%s, %s -> Field type are different %s and %s\n", a.Spec().Name, b.Spec().Name, a.Spec().Type.ToString(), b.Spec().Type.ToString())
 		return false
 	}
-	// check array comparation
-	if a.Spec().Type == field.TypeArray {
+	// check array comparation (map value is held as an array field)
+	if a.Spec().Type == field.TypeArray || a.Spec().Type == field.TypeMap {
 		if a.Spec().ArrayFields == nil || b.Spec().ArrayFields == nil {
 			fmt.Println("Array Fields cannot be nil")
 			return false
@@ -69,8 +70,8 @@ func fieldsAreEqual(a, b collection.Field) bool {
 		}
 	}
 
-	// check object comparation
-	if a.Spec().Type == field.TypeObject {
+	// check object comparation (one of variants are held as object fields)
+	if a.Spec().Type == field.TypeObject || a.Spec().Type == field.TypeOneOf {
 		if a.Spec().Object == nil || b.Spec().Object == nil {
 			fmt.Println("Object Fields cannot be empty")
 			return false
@@ -156,7 +157,7 @@ func collectionsAreEqual(a, b collection.Collection) bool {
 				return false
 			}
 
-			if okA && aOpt != bOpt {
+			if okA && !reflect.DeepEqual(aOpt, bOpt) {
 				fmt.Println("Collection option are different 3")
 				return false
 			}
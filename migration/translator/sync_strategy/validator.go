@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package sync_strategy
+
+import (
+	"sort"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	dt "github.com/amirkode/go-mongr8/internal/data_type"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	"github.com/amirkode/go-mongr8/migration/translator/dictionary"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+)
+
+/*
+This contains mechanism to sync schema validators ($jsonSchema)
+a validator is generated from all fields of a collection,
+so it's versioned by the fields it was generated from
+
+example:
+- a field "age" is added to collection "users" with schema validation
+- Up: set validator generated from the latest fields (including "age")
+- Down: set validator generated from the previous fields,
+  or unset the validator if there was no validator before
+*/
+
+// This returns the fields of the latest validator of each collection
+// the collection is not listed if it has no validator
+// it also returns the collections those exist after all migrations
+func getValidatorsFromMigrations(migrations []migrator.Migration) (map[string][]collection.Field, map[string]bool) {
+	sorted := make([]migrator.Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	res := map[string][]collection.Field{}
+	existing := map[string]bool{}
+	for _, m := range sorted {
+		for _, action := range m.Up {
+			for _, subAction := range action.SubActions {
+				name := subAction.ActionSchema.Collection.Spec().Name
+				switch subAction.Type {
+				case si.SubActionTypeCreateCollection:
+					existing[name] = true
+				case si.SubActionTypeSetValidator:
+					res[name] = subAction.ActionSchema.Fields
+				case si.SubActionTypeUnsetValidator, si.SubActionTypeDropCollection:
+					// dropping collection also drops the validator
+					delete(res, name)
+					if subAction.Type == si.SubActionTypeDropCollection {
+						delete(existing, name)
+					}
+				}
+			}
+		}
+	}
+
+	return res, existing
+}
+
+// This returns Up and Down actions for validator changes
+// `incoming` is the latest user defined collection in `[project dir]/mongr8/collection`
+// `migrations` is a list of migration declarations in `[project dir]/mongr8/migration`
+// `useSchemaValidation` defines whether the incoming collections must be validated
+func GetValidatorActions(incoming []collection.Collection, migrations []migrator.Migration, useSchemaValidation bool) dt.Pair[[]si.Action, []si.Action] {
+	upActions := []si.Action{}
+	downActions := []si.Action{}
+	validators, existing := getValidatorsFromMigrations(migrations)
+	for _, coll := range incoming {
+		// views cannot have any validator
+		if coll.Collection().Spec().Type == metadata.TypeViewCollection {
+			continue
+		}
+
+		name := coll.Collection().Spec().Name
+		currFields, validated := validators[name]
+		schema := si.SubActionSchema{
+			Collection: coll.Collection(),
+			Fields:     coll.Fields(),
+		}
+		prevSchema := si.SubActionSchema{
+			Collection: coll.Collection(),
+			Fields:     currFields,
+		}
+
+		var upSubAction *si.SubAction
+		var downSubAction *si.SubAction
+		if useSchemaValidation {
+			if validated && dictionary.NewSchemaValidation(coll.Fields()).Equal(dictionary.NewSchemaValidation(currFields)) {
+				continue
+			}
+
+			upSubAction = si.SubActionSetValidator(schema)
+			if validated {
+				downSubAction = si.SubActionSetValidator(prevSchema)
+			} else if existing[name] {
+				downSubAction = si.SubActionUnsetValidator(si.SubActionSchema{Collection: coll.Collection()})
+			}
+			// otherwise, the collection is created in this migration
+			// and dropping it also drops the validator
+		} else {
+			if !validated {
+				continue
+			}
+
+			upSubAction = si.SubActionUnsetValidator(si.SubActionSchema{Collection: coll.Collection()})
+			downSubAction = si.SubActionSetValidator(prevSchema)
+		}
+
+		upActions = append(upActions, si.Action{
+			ActionKey:  name,
+			SubActions: []si.SubAction{*upSubAction},
+		})
+		if downSubAction != nil {
+			downActions = append(downActions, si.Action{
+				ActionKey:  name,
+				SubActions: []si.SubAction{*downSubAction},
+			})
+		}
+	}
+
+	return dt.NewPair(upActions, downActions)
+}
+
+// This merges sub actions of `additional` into `actions` with the same action key
+// the additional sub actions are executed after the existing ones
+func MergeActions(actions, additional []si.Action) []si.Action {
+	res := make([]si.Action, len(actions))
+	copy(res, actions)
+	for _, action := range additional {
+		found := false
+		for i := range res {
+			if res[i].ActionKey == action.ActionKey {
+				subActions := append([]si.SubAction{}, res[i].SubActions...)
+				res[i].SubActions = append(subActions, action.SubActions...)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			res = append(res, action)
+		}
+	}
+
+	return res
+}
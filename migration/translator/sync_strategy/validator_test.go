@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023-present the go-mongr8 Authors and Contributors
+[@see Authors file]
+
+Licensed under the MIT License
+(https://opensource.org/licenses/MIT)
+*/
+package sync_strategy
+
+import (
+	"testing"
+
+	"github.com/amirkode/go-mongr8/internal/test"
+
+	"github.com/amirkode/go-mongr8/collection"
+	"github.com/amirkode/go-mongr8/collection/field"
+	"github.com/amirkode/go-mongr8/collection/metadata"
+	"github.com/amirkode/go-mongr8/migration/migrator"
+	si "github.com/amirkode/go-mongr8/migration/translator/mongodb/schema_interpreter"
+)
+
+func TestGetValidatorActions(t *testing.T) {
+	usersFields := []collection.Field{
+		field.StringField("name"),
+	}
+	migrations := []migrator.Migration{
+		{
+			ID: "1",
+			Up: []si.Action{
+				{
+					ActionKey: "users",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateCollection(si.SubActionSchema{
+							Collection: metadata.InitMetadata("users"),
+							Fields:     usersFields,
+						}),
+						*si.SubActionSetValidator(si.SubActionSchema{
+							Collection: metadata.InitMetadata("users"),
+							Fields:     usersFields,
+						}),
+					},
+				},
+				{
+					ActionKey: "logs",
+					SubActions: []si.SubAction{
+						*si.SubActionCreateCollection(si.SubActionSchema{
+							Collection: metadata.InitMetadata("logs"),
+							Fields:     []collection.Field{field.StringField("message")},
+						}),
+					},
+				},
+			},
+		},
+	}
+
+	// case 1: unchanged validator
+	case1Incoming := []collection.Collection{
+		collection.NewCollection(metadata.InitMetadata("users"), usersFields, nil),
+	}
+	case1Actions := GetValidatorActions(case1Incoming, migrations, true)
+	test.AssertEqual(t, len(case1Actions.First), 0, "Case 1: Unchanged validator must not produce any action")
+
+	// case 2: changed validator, new validator on existing collection, and new collection
+	case2Incoming := []collection.Collection{
+		collection.NewCollection(metadata.InitMetadata("users"), []collection.Field{
+			field.StringField("name"),
+			field.Int32Field("age"),
+		}, nil),
+		collection.NewCollection(metadata.InitMetadata("logs"), []collection.Field{field.StringField("message")}, nil),
+		collection.NewCollection(metadata.InitMetadata("products"), []collection.Field{field.StringField("title")}, nil),
+	}
+	case2Actions := GetValidatorActions(case2Incoming, migrations, true)
+	test.AssertEqual(t, len(case2Actions.First), 3, "Case 2: Up actions must be 3")
+	for _, action := range case2Actions.First {
+		test.AssertEqual(t, action.SubActions[0].Type, si.SubActionTypeSetValidator, "Case 2: Up must set validator")
+	}
+	test.AssertEqual(t, len(case2Actions.Second), 2, "Case 2: Down actions of new collection must be skipped")
+	test.AssertEqual(t, case2Actions.Second[0].SubActions[0].Type, si.SubActionTypeSetValidator, "Case 2: Down must restore previous validator")
+	test.AssertEqual(t, len(case2Actions.Second[0].SubActions[0].ActionSchema.Fields), 1, "Case 2: Down must use previous fields")
+	test.AssertEqual(t, case2Actions.Second[1].SubActions[0].Type, si.SubActionTypeUnsetValidator, "Case 2: Down must unset validator")
+
+	// case 3: schema validation is disabled
+	case3Actions := GetValidatorActions(case1Incoming, migrations, false)
+	test.AssertEqual(t, len(case3Actions.First), 1, "Case 3: Up actions must be 1")
+	test.AssertEqual(t, case3Actions.First[0].SubActions[0].Type, si.SubActionTypeUnsetValidator, "Case 3: Up must unset validator")
+	test.AssertEqual(t, case3Actions.Second[0].SubActions[0].Type, si.SubActionTypeSetValidator, "Case 3: Down must restore validator")
+
+	// case 4: changed value constraint of an existing field
+	case4Incoming := []collection.Collection{
+		collection.NewCollection(metadata.InitMetadata("users"), []collection.Field{
+			field.StringField("name").MaxLength(64),
+		}, nil),
+	}
+	case4Actions := GetValidatorActions(case4Incoming, migrations, true)
+	test.AssertEqual(t, len(case4Actions.First), 1, "Case 4: Up actions must be 1")
+	test.AssertEqual(t, case4Actions.First[0].SubActions[0].Type, si.SubActionTypeSetValidator, "Case 4: Up must set validator")
+	test.AssertEqual(t, case4Actions.Second[0].SubActions[0].Type, si.SubActionTypeSetValidator, "Case 4: Down must restore previous validator")
+}
+
+func TestMergeActions(t *testing.T) {
+	actions := []si.Action{
+		{
+			ActionKey: "users",
+			SubActions: []si.SubAction{
+				*si.SubActionCreateField(si.SubActionSchema{
+					Collection: metadata.InitMetadata("users"),
+					Fields:     []collection.Field{field.Int32Field("age")},
+				}),
+			},
+		},
+	}
+	additional := []si.Action{
+		{
+			ActionKey:  "users",
+			SubActions: []si.SubAction{*si.SubActionUnsetValidator(si.SubActionSchema{Collection: metadata.InitMetadata("users")})},
+		},
+		{
+			ActionKey:  "logs",
+			SubActions: []si.SubAction{*si.SubActionUnsetValidator(si.SubActionSchema{Collection: metadata.InitMetadata("logs")})},
+		},
+	}
+
+	res := MergeActions(actions, additional)
+	test.AssertEqual(t, len(res), 2, "Actions must be merged by key")
+	test.AssertEqual(t, len(res[0].SubActions), 2, "Sub actions must be appended")
+	test.AssertEqual(t, res[0].SubActions[1].Type, si.SubActionTypeUnsetValidator, "Additional sub action must be the last")
+	test.AssertEqual(t, len(actions[0].SubActions), 1, "Original actions must not be modified")
+}